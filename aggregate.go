@@ -0,0 +1,371 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"context"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/overlap"
+	"github.com/cockroachdb/pebble/sstable"
+)
+
+// AggregateSpec configures the aggregates computed by DB.Aggregate.
+type AggregateSpec struct {
+	// Count, KeyBytes and ValueBytes request the number of live point keys in
+	// the span, and the sum of their user key and value lengths.
+	Count      bool
+	KeyBytes   bool
+	ValueBytes bool
+	// Properties configures aggregates over the properties collected by
+	// block-property collectors, such as the minimum and maximum of an
+	// interval or a sum.
+	Properties []PropertyAggregate
+}
+
+// needsKeys returns true if the spec requests aggregates that can't be derived
+// from block properties.
+func (s *AggregateSpec) needsKeys() bool {
+	return s.Count || s.KeyBytes || s.ValueBytes
+}
+
+// PropertyAggregate aggregates the property collected by a block-property
+// collector. The collector must be configured in Options.BlockPropertyCollectors
+// for its properties to be used; keys within tables written without the
+// collector are read and fed to a new collector instead.
+type PropertyAggregate struct {
+	// NewCollector constructs the collector whose property is aggregated.
+	NewCollector func() BlockPropertyCollector
+	// Combine combines two properties in the collector's encoding, appending
+	// the result to acc[:0]. The first call is passed an empty acc. Combine
+	// must not retain prop. See sstable.UnionBlockIntervals for an
+	// implementation suitable for interval collectors.
+	Combine func(acc, prop []byte) ([]byte, error)
+}
+
+// AggregateResult holds the result of DB.Aggregate.
+type AggregateResult struct {
+	// Count is the number of live point keys within the span.
+	Count uint64
+	// KeyBytes is the sum of the lengths of the live point keys' user keys.
+	KeyBytes uint64
+	// ValueBytes is the sum of the lengths of the live point keys' values.
+	ValueBytes uint64
+	// Properties holds the aggregate of each of AggregateSpec.Properties, in
+	// the collector's encoding.
+	Properties [][]byte
+	// TablesAggregated is the number of tables aggregated using their table
+	// properties, without reading their data blocks.
+	TablesAggregated int
+	// BlocksAggregated is the number of data blocks aggregated using their
+	// block properties, without reading them.
+	BlocksAggregated int
+}
+
+// Aggregate computes the aggregates configured by spec over the live point
+// keys within span, as visible at the time of the call. Range keys are
+// ignored.
+//
+// Keys are read with the semantics of ScanInternal: each user key contributes
+// its most recent visible point key, unless that key is a point tombstone or
+// deleted by a range deletion. As with ScanInternal, the span must not
+// contain merge operands or single deletions.
+//
+// Tables that lie wholly within the span, contain no tombstones, merge
+// operands or keys pinned by snapshots, and share no part of the key space
+// with the keys of any other table or memtable are aggregated using their
+// table properties. Other tables' bounds may overlap such a table's, as long
+// as those tables hold no keys within its bounds. If spec only requests
+// property aggregates, such tables that straddle the span's bounds are
+// aggregated using the block properties of their data blocks wholly within
+// the span, and only the boundary blocks are read. All other keys are read.
+func (d *DB) Aggregate(
+	ctx context.Context, span KeyRange, spec AggregateSpec,
+) (AggregateResult, error) {
+	if err := d.closed.Load(); err != nil {
+		panic(err)
+	}
+	if !span.Valid() {
+		return AggregateResult{}, errors.New("pebble: Aggregate requires a span with start and end keys")
+	}
+	a := aggregator{
+		cmp:        d.cmp,
+		spec:       &spec,
+		names:      make([]string, len(spec.Properties)),
+		collectors: make([]BlockPropertyCollector, len(spec.Properties)),
+	}
+	a.res.Properties = make([][]byte, len(spec.Properties))
+	for i := range spec.Properties {
+		a.collectors[i] = spec.Properties[i].NewCollector()
+		a.names[i] = a.collectors[i].Name()
+	}
+
+	iter, err := d.newInternalIter(ctx, snapshotIterOpts{}, &scanInternalOptions{
+		IterOptions: IterOptions{
+			KeyTypes:   IterKeyTypePointsOnly,
+			LowerBound: span.Start,
+			UpperBound: span.End,
+		},
+	})
+	if err != nil {
+		return AggregateResult{}, err
+	}
+	defer iter.close()
+
+	if err := d.aggregateTables(ctx, iter.readState, iter.seqNum, span, &a); err != nil {
+		return AggregateResult{}, err
+	}
+	if err := a.readKeys(iter, span); err != nil {
+		return AggregateResult{}, err
+	}
+	for i := range a.collectors {
+		prop, err := a.collectors[i].FinishDataBlock(nil)
+		if err != nil {
+			return AggregateResult{}, err
+		}
+		if err := a.combine(i, prop); err != nil {
+			return AggregateResult{}, err
+		}
+	}
+	// Table properties and keys read account for all of the key aggregates,
+	// but those accumulated alongside block properties are incomplete.
+	if !spec.Count {
+		a.res.Count = 0
+	}
+	if !spec.KeyBytes {
+		a.res.KeyBytes = 0
+	}
+	if !spec.ValueBytes {
+		a.res.ValueBytes = 0
+	}
+	return a.res, nil
+}
+
+// aggregator holds the state of a DB.Aggregate call.
+type aggregator struct {
+	cmp        Compare
+	spec       *AggregateSpec
+	names      []string
+	collectors []BlockPropertyCollector
+	// skips holds the regions of the key space aggregated from table or block
+	// properties, sorted by key. No other table or memtable holds keys within a
+	// region, so the keys within it must not be read.
+	skips    []aggregateSkip
+	res      AggregateResult
+	valueBuf []byte
+}
+
+// aggregateSkip is a region of the key space aggregated from properties.
+type aggregateSkip struct {
+	// lower is the region's lower bound. It's exclusive if lowerExclusive is
+	// set.
+	lower          []byte
+	lowerExclusive bool
+	// upper is the region's inclusive upper bound.
+	upper []byte
+}
+
+func (s *aggregateSkip) contains(cmp Compare, key []byte) bool {
+	if c := cmp(s.lower, key); c > 0 || (c == 0 && s.lowerExclusive) {
+		return false
+	}
+	return cmp(key, s.upper) <= 0
+}
+
+func (a *aggregator) combine(i int, prop []byte) error {
+	acc, err := a.spec.Properties[i].Combine(a.res.Properties[i], prop)
+	if err != nil {
+		return err
+	}
+	a.res.Properties[i] = acc
+	return nil
+}
+
+// aggregateTables aggregates the tables within the span that can be
+// aggregated from their properties, populating a.skips with the regions of
+// the key space they account for.
+func (d *DB) aggregateTables(
+	ctx context.Context, rs *readState, seqNum base.SeqNum, span KeyRange, a *aggregator,
+) error {
+	current := rs.current
+	checker := overlap.MakeChecker(d.cmp, &overlapChecker{
+		comparer: d.opts.Comparer,
+		newIters: d.newIters,
+		opts:     IterOptions{logger: d.opts.Logger},
+		v:        current,
+	})
+	spanBounds := span.UserKeyBounds()
+	for level := range current.Levels {
+		overlaps := current.Overlaps(level, spanBounds)
+		files := overlaps.Iter()
+		for f := files.First(); f != nil; f = files.Next() {
+			props, ok, err := d.aggregatableTableProps(ctx, rs, &checker, seqNum, f, a.names)
+			if err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+			if d.cmp(span.Start, f.Smallest.UserKey) <= 0 && span.Contains(d.cmp, f.Largest) {
+				a.res.Count += props.NumEntries
+				a.res.KeyBytes += props.RawKeySize - props.NumEntries*base.InternalTrailerLen
+				a.res.ValueBytes += props.RawValueSize
+				for i, name := range a.names {
+					if err := a.combine(i, []byte(props.UserProperties[name][1:])); err != nil {
+						return err
+					}
+				}
+				a.res.TablesAggregated++
+				a.skips = append(a.skips, aggregateSkip{
+					lower: f.Smallest.UserKey,
+					upper: f.Largest.UserKey,
+				})
+				continue
+			}
+			// The table straddles the span's bounds. If only property aggregates
+			// were requested, its data blocks within the span may be aggregated
+			// from their block properties.
+			if a.spec.needsKeys() || len(a.names) == 0 {
+				continue
+			}
+			start := span.Start
+			if d.cmp(start, f.Smallest.UserKey) <= 0 {
+				start = nil
+			}
+			var covered sstable.CoveredDataBlocks
+			err = d.tableCache.withReader(f.PhysicalMeta(), func(r *sstable.Reader) (err error) {
+				covered, err = r.VisitCoveredDataBlocks(start, span.End, a.names, a.combine)
+				return err
+			})
+			if err != nil {
+				return err
+			} else if covered.Count == 0 {
+				continue
+			}
+			a.res.BlocksAggregated += covered.Count
+			skip := aggregateSkip{lower: f.Smallest.UserKey, upper: covered.Through}
+			if covered.After != nil {
+				skip.lower, skip.lowerExclusive = covered.After, true
+			}
+			a.skips = append(a.skips, skip)
+		}
+	}
+	slices.SortFunc(a.skips, func(x, y aggregateSkip) int {
+		return d.cmp(x.lower, y.lower)
+	})
+	return nil
+}
+
+// aggregatableTableProps returns the properties of the table f if the table
+// may be aggregated from its properties: every point key within the table is
+// a live, visible key that isn't shadowed by any other table or memtable.
+func (d *DB) aggregatableTableProps(
+	ctx context.Context,
+	rs *readState,
+	checker *overlap.Checker,
+	seqNum base.SeqNum,
+	f *fileMetadata,
+	names []string,
+) (*sstable.Properties, bool, error) {
+	if f.Virtual || !f.HasPointKeys || f.SyntheticPrefix.IsSet() || f.SyntheticSuffix.IsSet() ||
+		!base.Visible(f.LargestSeqNum, seqNum, base.SeqNumMax) {
+		return nil, false, nil
+	}
+	props, err := d.tableCache.getTableProperties(f)
+	if err != nil {
+		return nil, false, err
+	}
+	if props.NumDeletions > 0 || props.NumMergeOperands > 0 || props.SnapshotPinnedKeys > 0 {
+		return nil, false, nil
+	}
+	for _, name := range names {
+		if len(props.UserProperties[name]) < 1 {
+			return nil, false, nil
+		}
+	}
+	// No other table may hold keys within the table's bounds, or it may shadow
+	// or be shadowed by the table's keys. Tables whose bounds overlap the
+	// table's but whose keys all fall outside of them are common in a
+	// multi-level LSM, and are checked against the table's data.
+	bounds := f.UserKeyBounds()
+	for level := range rs.current.Levels {
+		overlaps := rs.current.Overlaps(level, bounds)
+		files := overlaps.Iter()
+		for o := files.First(); o != nil; o = files.Next() {
+			if o == f {
+				continue
+			}
+			empty, err := checker.EmptyRegion(ctx, bounds, o)
+			if err != nil {
+				return nil, false, err
+			} else if !empty {
+				return nil, false, nil
+			}
+		}
+	}
+	memOverlaps := false
+	for _, mem := range rs.memtables {
+		mem.computePossibleOverlaps(func(bounded) shouldContinue {
+			memOverlaps = true
+			return stopIteration
+		}, f)
+		if memOverlaps {
+			return nil, false, nil
+		}
+	}
+	return props, true, nil
+}
+
+// readKeys reads the live point keys within the span that weren't aggregated
+// from properties.
+func (a *aggregator) readKeys(iter *scanInternalIterator, span KeyRange) error {
+	skips := a.skips
+	for valid := iter.seekGE(span.Start); valid; {
+		kv := iter.iterKV
+		for len(skips) > 0 && a.cmp(skips[0].upper, kv.K.UserKey) < 0 {
+			skips = skips[1:]
+		}
+		if len(skips) > 0 && skips[0].contains(a.cmp, kv.K.UserKey) {
+			upper := skips[0].upper
+			skips = skips[1:]
+			valid = iter.seekGE(upper)
+			if valid && a.cmp(iter.iterKV.K.UserKey, upper) == 0 {
+				valid = iter.next()
+			}
+			continue
+		}
+		switch kv.Kind() {
+		case InternalKeyKindSet, InternalKeyKindSetWithDelete:
+			if err := a.addKey(kv); err != nil {
+				return err
+			}
+		}
+		valid = iter.next()
+	}
+	return iter.error()
+}
+
+func (a *aggregator) addKey(kv *base.InternalKV) error {
+	a.res.Count++
+	a.res.KeyBytes += uint64(len(kv.K.UserKey))
+	a.res.ValueBytes += uint64(kv.V.Len())
+	if len(a.collectors) == 0 {
+		return nil
+	}
+	v, callerOwned, err := kv.V.Value(a.valueBuf)
+	if err != nil {
+		return err
+	}
+	if callerOwned {
+		a.valueBuf = v[:0]
+	}
+	for _, c := range a.collectors {
+		if err := c.AddPointKey(kv.K, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
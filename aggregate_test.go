@@ -0,0 +1,286 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/keyspan"
+	"github.com/cockroachdb/pebble/internal/testkeys"
+	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+)
+
+// valueLenCollector is a block-property collector that sums the lengths of
+// values.
+type valueLenCollector struct {
+	block, index, table uint64
+	lastBlock           uint64
+}
+
+const valueLenCollectorName = "test.value-len"
+
+func (c *valueLenCollector) Name() string { return valueLenCollectorName }
+
+func (c *valueLenCollector) AddPointKey(_ InternalKey, value []byte) error {
+	c.block += uint64(len(value))
+	return nil
+}
+
+func (c *valueLenCollector) AddRangeKeys(keyspan.Span) error { return nil }
+
+func (c *valueLenCollector) AddCollectedWithSuffixReplacement(_, _, _ []byte) error {
+	return errors.New("unsupported")
+}
+
+func (c *valueLenCollector) SupportsSuffixReplacement() bool { return false }
+
+func (c *valueLenCollector) FinishDataBlock(buf []byte) ([]byte, error) {
+	c.lastBlock, c.block = c.block, 0
+	c.table += c.lastBlock
+	return binary.AppendUvarint(buf, c.lastBlock), nil
+}
+
+func (c *valueLenCollector) AddPrevDataBlockToIndexBlock() { c.index += c.lastBlock }
+
+func (c *valueLenCollector) FinishIndexBlock(buf []byte) ([]byte, error) {
+	buf = binary.AppendUvarint(buf, c.index)
+	c.index = 0
+	return buf, nil
+}
+
+func (c *valueLenCollector) FinishTable(buf []byte) ([]byte, error) {
+	return binary.AppendUvarint(buf, c.table), nil
+}
+
+func sumValueLens(acc, prop []byte) ([]byte, error) {
+	var sum uint64
+	for _, b := range [][]byte{acc, prop} {
+		if len(b) == 0 {
+			continue
+		}
+		v, n := binary.Uvarint(b)
+		if n != len(b) {
+			return nil, errors.Newf("malformed value-len property %x", b)
+		}
+		sum += v
+	}
+	return binary.AppendUvarint(acc[:0], sum), nil
+}
+
+// expectedAggregate computes the aggregates of spec over span by iterating
+// over the DB.
+func expectedAggregate(t *testing.T, d *DB, span KeyRange, spec AggregateSpec) AggregateResult {
+	var res AggregateResult
+	res.Properties = make([][]byte, len(spec.Properties))
+	collectors := make([]BlockPropertyCollector, len(spec.Properties))
+	for i := range spec.Properties {
+		collectors[i] = spec.Properties[i].NewCollector()
+	}
+	iter, err := d.NewIter(&IterOptions{LowerBound: span.Start, UpperBound: span.End})
+	require.NoError(t, err)
+	for valid := iter.First(); valid; valid = iter.Next() {
+		res.Count++
+		res.KeyBytes += uint64(len(iter.Key()))
+		res.ValueBytes += uint64(len(iter.Value()))
+		for _, c := range collectors {
+			require.NoError(t, c.AddPointKey(base.MakeInternalKey(iter.Key(), 0, InternalKeyKindSet), iter.Value()))
+		}
+	}
+	require.NoError(t, iter.Close())
+	for i, c := range collectors {
+		prop, err := c.FinishDataBlock(nil)
+		require.NoError(t, err)
+		res.Properties[i], err = spec.Properties[i].Combine(nil, prop)
+		require.NoError(t, err)
+	}
+	return res
+}
+
+func TestAggregate(t *testing.T) {
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+
+	opts := &Options{
+		FS:       vfs.NewMem(),
+		Comparer: testkeys.Comparer,
+		BlockPropertyCollectors: []func() BlockPropertyCollector{
+			sstable.NewTestKeysBlockPropertyCollector,
+			func() BlockPropertyCollector { return &valueLenCollector{} },
+		},
+		DisableAutomaticCompactions: true,
+	}
+	// Use small blocks and tables, so that spans cover many tables and blocks.
+	opts.Levels = make([]LevelOptions, numLevels)
+	for i := range opts.Levels {
+		opts.Levels[i] = LevelOptions{BlockSize: 256, IndexBlockSize: 512, TargetFileSize: 4 << 10}
+	}
+	d, err := Open("", opts.EnsureDefaults())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	const numKeys = 2000
+	key := func(i int) []byte { return []byte(fmt.Sprintf("k%05d@%d", i, 1+rng.Intn(50))) }
+	value := func() []byte { return make([]byte, rng.Intn(100)) }
+	// Populate the bottommost level with tables free of tombstones, and then
+	// write and delete keys over parts of the keyspace in L0 and the memtable.
+	for i := 0; i < numKeys; i++ {
+		require.NoError(t, d.Set(key(i), value(), nil))
+	}
+	require.NoError(t, d.Compact([]byte("k"), []byte("l"), false /* parallelize */))
+	for round := 0; round < 3; round++ {
+		start := rng.Intn(numKeys)
+		for i := start; i < min(start+1+rng.Intn(200), numKeys); i++ {
+			switch rng.Intn(3) {
+			case 0:
+				require.NoError(t, d.Set(key(i), value(), nil))
+			case 1:
+				require.NoError(t, d.Delete(key(i), nil))
+			case 2:
+				require.NoError(t, d.DeleteRange(key(i), key(i+1+rng.Intn(5)), nil))
+			}
+		}
+		if round < 2 {
+			require.NoError(t, d.Flush())
+		}
+	}
+
+	specs := []AggregateSpec{
+		{Count: true, KeyBytes: true, ValueBytes: true},
+		{
+			Count: true,
+			Properties: []PropertyAggregate{
+				{NewCollector: sstable.NewTestKeysBlockPropertyCollector, Combine: sstable.UnionBlockIntervals},
+				{NewCollector: func() BlockPropertyCollector { return &valueLenCollector{} }, Combine: sumValueLens},
+			},
+		},
+		{
+			Properties: []PropertyAggregate{
+				{NewCollector: sstable.NewTestKeysBlockPropertyCollector, Combine: sstable.UnionBlockIntervals},
+				{NewCollector: func() BlockPropertyCollector { return &valueLenCollector{} }, Combine: sumValueLens},
+			},
+		},
+	}
+	var tables, blocks int
+	for i := 0; i < 50; i++ {
+		a, b := rng.Intn(numKeys+1), rng.Intn(numKeys+1)
+		span := KeyRange{Start: []byte(fmt.Sprintf("k%05d", min(a, b))), End: []byte(fmt.Sprintf("k%05d", max(a, b)))}
+		for _, spec := range specs {
+			res, err := d.Aggregate(context.Background(), span, spec)
+			require.NoError(t, err)
+			expected := expectedAggregate(t, d, span, spec)
+			if !spec.Count {
+				expected.Count = 0
+			}
+			if !spec.KeyBytes {
+				expected.KeyBytes = 0
+			}
+			if !spec.ValueBytes {
+				expected.ValueBytes = 0
+			}
+			tables += res.TablesAggregated
+			blocks += res.BlocksAggregated
+			res.TablesAggregated, res.BlocksAggregated = 0, 0
+			require.Equal(t, expected, res, "span %s", span)
+		}
+	}
+	// The table and block properties must have been used.
+	require.Positive(t, tables)
+	require.Positive(t, blocks)
+}
+
+// TestAggregateMultiLevel tests aggregating tables whose bounds overlap the
+// bounds of tables in other levels, but whose keys don't.
+func TestAggregateMultiLevel(t *testing.T) {
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+
+	mem := vfs.NewMem()
+	opts := &Options{
+		FS:       mem,
+		Comparer: testkeys.Comparer,
+		BlockPropertyCollectors: []func() BlockPropertyCollector{
+			func() BlockPropertyCollector { return &valueLenCollector{} },
+		},
+		DisableAutomaticCompactions: true,
+		// Lower the base level, so that ingested tables may be placed above L6.
+		LBaseMaxBytes: 1,
+	}
+	d, err := Open("", opts.EnsureDefaults())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	key := func(i int) []byte { return []byte(fmt.Sprintf("k%05d@%d", i, 1+rng.Intn(50))) }
+	value := func() []byte { return make([]byte, rng.Intn(100)) }
+	// Write a single L6 table with a gap in its keys, [k00400, k01600).
+	for i := 0; i < 2000; i++ {
+		if i == 400 {
+			i = 1600
+		}
+		require.NoError(t, d.Set(key(i), value(), nil))
+	}
+	require.NoError(t, d.Compact([]byte("k"), []byte("l"), false /* parallelize */))
+	// Ingest a table into the gap. Its bounds overlap the L6 table's, so it's
+	// ingested into L5.
+	f, err := mem.Create("ext", vfs.WriteCategoryUnspecified)
+	require.NoError(t, err)
+	// Like the DB's own tables, write the table in a format without value
+	// blocks, so the value-len collector observes its values.
+	w := sstable.NewWriter(objstorageprovider.NewFileWritable(f), d.opts.MakeWriterOptions(5, sstable.TableFormatPebblev2))
+	for i := 1000; i < 1200; i++ {
+		require.NoError(t, w.Set(key(i), value()))
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, d.Ingest(context.Background(), []string{"ext"}))
+	// Flush a table into L0 that also lies within the gap.
+	for i := 600; i < 800; i++ {
+		require.NoError(t, d.Set(key(i), value(), nil))
+	}
+	require.NoError(t, d.Flush())
+
+	m := d.Metrics()
+	require.Equal(t, int64(1), m.Levels[0].NumFiles)
+	require.Equal(t, int64(1), m.Levels[5].NumFiles)
+	require.Equal(t, int64(1), m.Levels[6].NumFiles)
+
+	spec := AggregateSpec{
+		Count:      true,
+		KeyBytes:   true,
+		ValueBytes: true,
+		Properties: []PropertyAggregate{
+			{NewCollector: func() BlockPropertyCollector { return &valueLenCollector{} }, Combine: sumValueLens},
+		},
+	}
+	// The L0 and L5 tables share no keys with the L6 table, and are aggregated
+	// from their properties. The L6 table's bounds contain their keys, so its
+	// keys are read.
+	span := KeyRange{Start: []byte("k"), End: []byte("l")}
+	res, err := d.Aggregate(context.Background(), span, spec)
+	require.NoError(t, err)
+	require.Equal(t, 2, res.TablesAggregated)
+	res.TablesAggregated, res.BlocksAggregated = 0, 0
+	require.Equal(t, expectedAggregate(t, d, span, spec), res)
+
+	// A tombstone flushed to L0 within the L5 table's bounds prevents both the
+	// L5 table and the tombstone's table from being aggregated.
+	require.NoError(t, d.Delete(key(1100), nil))
+	require.NoError(t, d.Flush())
+	res, err = d.Aggregate(context.Background(), span, spec)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.TablesAggregated)
+	res.TablesAggregated, res.BlocksAggregated = 0, 0
+	require.Equal(t, expectedAggregate(t, d, span, spec), res)
+}
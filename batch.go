@@ -400,6 +400,17 @@ type BatchCommitStats struct {
 	// SemaphoreWaitDuration is the wait time for semaphores in
 	// commitPipeline.Commit.
 	SemaphoreWaitDuration time.Duration
+	// PipelineMutexWaitDuration is the wait time for the mutex serializing
+	// writes to the WAL in commitPipeline.Commit.
+	PipelineMutexWaitDuration time.Duration
+	// WALWriteDuration is the time spent writing the batch to the WAL while
+	// holding the commit pipeline mutex. It includes the
+	// MemTableWriteStallDuration, L0ReadAmpWriteStallDuration and
+	// WALRotationDuration below, but not the wait for the WAL sync.
+	WALWriteDuration time.Duration
+	// MemTableApplyDuration is the time spent applying the batch to the
+	// memtable.
+	MemTableApplyDuration time.Duration
 	// WALQueueWaitDuration is the wait time for allocating memory blocks in the
 	// LogWriter (due to the LogWriter not writing fast enough). At the moment
 	// this is duration is always zero because a single WAL will allow
@@ -1663,6 +1674,9 @@ func (b *Batch) SyncWait() error {
 	waitDuration := time.Since(now)
 	b.commitStats.CommitWaitDuration += waitDuration
 	b.commitStats.TotalDuration += waitDuration
+	if b.db != nil {
+		b.db.commit.metrics.syncWaitLatency.Observe(float64(b.commitStats.CommitWaitDuration))
+	}
 	return b.commitErr
 }
 
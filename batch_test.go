@@ -1402,6 +1402,10 @@ func TestBatchCommitStats(t *testing.T) {
 			return errors.Errorf("L0ReadAmpWriteStallDuration %s is too low",
 				stats.L0ReadAmpWriteStallDuration)
 		}
+		if stats.MemTableWriteStallDuration+stats.L0ReadAmpWriteStallDuration > stats.WALWriteDuration {
+			return errors.Errorf("WALWriteDuration %s is lower than the write stalls",
+				stats.WALWriteDuration)
+		}
 		if expectedDuration > stats.CommitWaitDuration {
 			return errors.Errorf("CommitWaitDuration %s is too low",
 				stats.CommitWaitDuration)
@@ -9,6 +9,23 @@ import "github.com/cockroachdb/pebble/internal/cache"
 // Cache exports the cache.Cache type.
 type Cache = cache.Cache
 
+// CachePriority is the priority class of a block in the block cache. Blocks
+// of a higher priority are retained preferentially. Index and filter blocks
+// are always cached with CacheHighPriority. See IterOptions.CachePriority and
+// Options.PinnedSpans.
+type CachePriority = cache.Priority
+
+const (
+	// CacheNormalPriority is the priority of ordinary data blocks.
+	CacheNormalPriority = cache.NormalPriority
+	// CacheHighPriority is the priority of blocks that should be retained
+	// preferentially.
+	CacheHighPriority = cache.HighPriority
+	// CacheLowPriority is the priority of blocks that are unlikely to be
+	// reused, such as those loaded by a full-table scan.
+	CacheLowPriority = cache.LowPriority
+)
+
 // NewCache creates a new cache of the specified size. Memory for the cache is
 // allocated on demand, not during initialization. The cache is created with a
 // reference count of 1. Each DB it is associated with adds a reference, so the
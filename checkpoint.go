@@ -11,6 +11,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/errors/oserror"
 	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/objstorage"
 	"github.com/cockroachdb/pebble/record"
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/cockroachdb/pebble/vfs/atomicfs"
@@ -313,6 +314,17 @@ func (d *DB) Checkpoint(
 				continue
 			}
 
+			if meta.Local.Tier != objstorage.LocalTierHot {
+				// Tables on the cold tier are copied into the checkpoint directory;
+				// a DB opened from the checkpoint finds them on its hot tier.
+				srcPath := d.objProvider.Path(meta)
+				destPath := fs.PathJoin(destDir, base.MakeFilename(fileTypeTable, fileBacking.DiskFileNum))
+				ckErr = vfs.CopyAcrossFS(d.opts.localTierFS(meta.Local.Tier), srcPath, fs, destPath)
+				if ckErr != nil {
+					return ckErr
+				}
+				continue
+			}
 			srcPath := base.MakeFilepath(fs, d.dirname, fileTypeTable, fileBacking.DiskFileNum)
 			destPath := fs.PathJoin(destDir, fs.PathBase(srcPath))
 			ckErr = vfs.LinkOrCopy(fs, srcPath, destPath)
@@ -43,6 +43,8 @@ type batch interface {
 	Commit(opts *pebble.WriteOptions) error
 	Set(key, value []byte, opts *pebble.WriteOptions) error
 	Delete(key []byte, opts *pebble.WriteOptions) error
+	DeleteRange(start, end []byte, opts *pebble.WriteOptions) error
+	RangeKeySet(start, end, suffix, value []byte, opts *pebble.WriteOptions) error
 	LogData(data []byte, opts *pebble.WriteOptions) error
 }
 
@@ -89,6 +91,8 @@ func newPebbleDB(dir string) DB {
 	}
 	opts.Levels[6].FilterPolicy = nil
 	opts.FlushSplitBytes = opts.Levels[0].TargetFileSize
+	opts.Experimental.MemTableApplyConcurrency = memTableApplyConcurrency
+	opts.BlockPropertyCollectors = blockPropertyCollectors
 
 	opts.EnsureDefaults()
 
@@ -9,6 +9,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/internal/crdbtest"
 	"github.com/cockroachdb/pebble/internal/testkeys"
 	"github.com/cockroachdb/pebble/tool"
@@ -29,6 +30,12 @@ var (
 	// If zero, or if !sharedStorageEnabled, secondary cache is
 	// not used.
 	secondaryCacheSize int64
+	// The maximum number of goroutines applying a single batch to the
+	// memtable. See Options.Experimental.MemTableApplyConcurrency.
+	memTableApplyConcurrency int
+	// The block property collectors configured on the benchmarked database,
+	// set by benchmarks that use block property filters.
+	blockPropertyCollectors []func() pebble.BlockPropertyCollector
 )
 
 func main() {
@@ -50,6 +57,7 @@ func main() {
 		ycsbCmd,
 		fsBenchCmd,
 		writeBenchCmd,
+		mvccCmd,
 	)
 
 	rootCmd := &cobra.Command{
@@ -61,7 +69,7 @@ func main() {
 	t := tool.New(tool.Comparers(&crdbtest.Comparer, testkeys.Comparer), tool.Mergers(fauxMVCCMerger))
 	rootCmd.AddCommand(t.Commands...)
 
-	for _, cmd := range []*cobra.Command{replayCmd, scanCmd, syncCmd, tombstoneCmd, writeBenchCmd, ycsbCmd} {
+	for _, cmd := range []*cobra.Command{replayCmd, scanCmd, syncCmd, tombstoneCmd, writeBenchCmd, ycsbCmd, mvccCmd} {
 		cmd.Flags().BoolVarP(
 			&verbose, "verbose", "v", false, "enable verbose event logging")
 		cmd.Flags().StringVar(
@@ -69,15 +77,20 @@ func main() {
 		cmd.Flags().Int64Var(
 			&secondaryCacheSize, "secondary-cache", 0, "secondary cache size in bytes")
 	}
-	for _, cmd := range []*cobra.Command{scanCmd, syncCmd, tombstoneCmd, ycsbCmd} {
+	for _, cmd := range []*cobra.Command{scanCmd, syncCmd, tombstoneCmd, ycsbCmd, mvccCmd} {
 		cmd.Flags().Int64Var(
 			&cacheSize, "cache", 1<<30, "cache size")
 	}
-	for _, cmd := range []*cobra.Command{scanCmd, syncCmd, tombstoneCmd, ycsbCmd, fsBenchCmd, writeBenchCmd} {
+	for _, cmd := range []*cobra.Command{writeBenchCmd, ycsbCmd} {
+		cmd.Flags().IntVar(
+			&memTableApplyConcurrency, "memtable-apply-concurrency", 1,
+			"maximum number of goroutines applying a single batch to the memtable")
+	}
+	for _, cmd := range []*cobra.Command{scanCmd, syncCmd, tombstoneCmd, ycsbCmd, fsBenchCmd, writeBenchCmd, mvccCmd} {
 		cmd.Flags().DurationVarP(
 			&duration, "duration", "d", 10*time.Second, "the duration to run (0, run forever)")
 	}
-	for _, cmd := range []*cobra.Command{scanCmd, syncCmd, tombstoneCmd, ycsbCmd} {
+	for _, cmd := range []*cobra.Command{scanCmd, syncCmd, tombstoneCmd, ycsbCmd, mvccCmd} {
 		cmd.Flags().IntVarP(
 			&concurrency, "concurrency", "c", 1, "number of concurrent workers")
 		cmd.Flags().BoolVar(
@@ -6,10 +6,14 @@ package main
 
 import (
 	"bytes"
+	"encoding/binary"
+	"math"
 
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/internal/bytealloc"
 	"github.com/cockroachdb/pebble/internal/crdbtest"
+	"github.com/cockroachdb/pebble/sstable"
 )
 
 // MVCC routines adapted from CockroachDB sources. Used to perform
@@ -72,3 +76,83 @@ var fauxMVCCMerger = &pebble.Merger{
 		return pebble.DefaultMerger.Merge(key, value)
 	},
 }
+
+// mvccTimeIntervalProperty is the name of the block property collected by
+// newMVCCTimeIntervalCollector.
+const mvccTimeIntervalProperty = "MVCCTimeInterval"
+
+// newMVCCTimeIntervalCollector constructs a block property collector recording
+// the interval of the MVCC wall times of the keys within each block, like the
+// collector CockroachDB configures.
+func newMVCCTimeIntervalCollector() pebble.BlockPropertyCollector {
+	return sstable.NewBlockIntervalCollector(mvccTimeIntervalProperty, mvccTimeIntervalMapper{}, nil)
+}
+
+// newMVCCTimeIntervalFilter constructs a block property filter that excludes
+// blocks containing only keys with MVCC wall times outside [lower, upper).
+func newMVCCTimeIntervalFilter(lower, upper uint64) *sstable.BlockIntervalFilter {
+	return sstable.NewBlockIntervalFilter(mvccTimeIntervalProperty, lower, upper, nil)
+}
+
+// mvccMaskingFilter implements pebble.BlockPropertyFilterMask, excluding
+// blocks containing only keys older than a masking MVCC range key.
+type mvccMaskingFilter struct {
+	*sstable.BlockIntervalFilter
+}
+
+// SetSuffix implements pebble.BlockPropertyFilterMask.
+func (f mvccMaskingFilter) SetSuffix(suffix []byte) error {
+	interval, err := mvccSuffixInterval(suffix)
+	if err != nil {
+		return err
+	}
+	f.BlockIntervalFilter.SetInterval(interval.Lower, math.MaxUint64)
+	return nil
+}
+
+// mvccTimeIntervalMapper maps keys to the interval containing their MVCC wall
+// time. Unversioned keys map to the empty interval.
+type mvccTimeIntervalMapper struct{}
+
+var _ sstable.IntervalMapper = mvccTimeIntervalMapper{}
+
+// MapPointKey is part of the sstable.IntervalMapper interface.
+func (mvccTimeIntervalMapper) MapPointKey(
+	key pebble.InternalKey, value []byte,
+) (sstable.BlockInterval, error) {
+	return mvccSuffixInterval(key.UserKey[crdbtest.Split(key.UserKey):])
+}
+
+// MapRangeKeys is part of the sstable.IntervalMapper interface.
+func (mvccTimeIntervalMapper) MapRangeKeys(span sstable.Span) (sstable.BlockInterval, error) {
+	var result sstable.BlockInterval
+	for _, k := range span.Keys {
+		interval, err := mvccSuffixInterval(k.Suffix)
+		if err != nil {
+			return sstable.BlockInterval{}, err
+		}
+		result.UnionWith(interval)
+	}
+	return result, nil
+}
+
+// mvccSuffixInterval returns the interval containing the wall time encoded in
+// the provided MVCC key suffix.
+func mvccSuffixInterval(suffix []byte) (sstable.BlockInterval, error) {
+	if len(suffix) == 0 {
+		return sstable.BlockInterval{}, nil
+	}
+	// The suffix holds an 8-byte wall time, optionally followed by a 4-byte
+	// logical time and a synthetic bit, and then the length byte.
+	if n := len(suffix) - 1; n != 8 && n != 12 && n != 13 {
+		return sstable.BlockInterval{}, errors.Newf("malformed MVCC suffix %x", suffix)
+	}
+	wall := binary.BigEndian.Uint64(suffix)
+	return sstable.BlockInterval{Lower: wall, Upper: wall + 1}, nil
+}
+
+// mvccSuffix returns the MVCC key suffix encoding the provided wall time.
+func mvccSuffix(walltime uint64) []byte {
+	// Encoding an empty key yields only the 0x00 sentinel and the suffix.
+	return crdbtest.EncodeMVCCKey(nil, nil, walltime, 0)[1:]
+}
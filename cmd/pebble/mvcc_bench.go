@@ -0,0 +1,386 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/internal/crdbtest"
+	"github.com/cockroachdb/pebble/internal/randvar"
+	"github.com/cockroachdb/pebble/internal/rate"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/rand"
+)
+
+var mvccConfig struct {
+	keys            int
+	versions        *randvar.Flag
+	values          *randvar.BytesFlag
+	maxTimestamp    uint64
+	rangeTombstones int
+	rangeKeys       *randvar.Flag
+	gcThreshold     uint64
+	rows            *randvar.Flag
+	filterSince     uint64
+	modes           string
+}
+
+var mvccCmd = &cobra.Command{
+	Use:   "mvcc <dir>",
+	Short: "run the MVCC scan benchmark",
+	Long: `
+Run a benchmark of scans over MVCC data, comparing the throughput of scans with
+and without range-key masking and block-property filters on timestamps.
+
+The benchmark first loads --keys keys, each with a number of versions drawn
+from --versions at timestamps in [1, --max-timestamp]. It then writes
+--range-tombstones MVCC range tombstones through RangeKeySet, each spanning a
+number of keys drawn from --range-keys. If --gc-threshold is nonzero, every
+version older than the threshold that is shadowed by a newer version is then
+deleted, as CockroachDB's garbage collection would.
+
+Each scan reads a number of keys drawn from --rows, in one of the modes listed
+by --modes:
+
+  plain:    points and range keys with no masking or filtering
+  masked:   points and range keys, masking points beneath MVCC range
+            tombstones and skipping blocks in which all points are masked
+  filtered: points only, skipping blocks that contain no versions newer than
+            --filter-since, as an incremental scan would
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMVCC,
+}
+
+func init() {
+	mvccCmd.Flags().IntVar(
+		&mvccConfig.keys, "keys", 100000, "number of distinct keys to load")
+	mvccConfig.versions = randvar.NewFlag("uniform:1-10")
+	mvccCmd.Flags().Var(
+		mvccConfig.versions, "versions", "versions per key distribution [{zipf,uniform}:]min[-max]")
+	mvccConfig.values = randvar.NewBytesFlag("64")
+	mvccCmd.Flags().Var(
+		mvccConfig.values, "values",
+		"value size distribution [{zipf,uniform}:]min[-max][/<target-compression>]")
+	mvccCmd.Flags().Uint64Var(
+		&mvccConfig.maxTimestamp, "max-timestamp", 1000, "maximum MVCC timestamp of the loaded versions")
+	mvccCmd.Flags().IntVar(
+		&mvccConfig.rangeTombstones, "range-tombstones", 100, "number of MVCC range tombstones to write")
+	mvccConfig.rangeKeys = randvar.NewFlag("uniform:10-1000")
+	mvccCmd.Flags().Var(
+		mvccConfig.rangeKeys, "range-keys",
+		"number of keys spanned by each range tombstone [{zipf,uniform}:]min[-max]")
+	mvccCmd.Flags().Uint64Var(
+		&mvccConfig.gcThreshold, "gc-threshold", 0,
+		"delete shadowed versions older than this timestamp (0 disables garbage collection)")
+	mvccConfig.rows = randvar.NewFlag("100")
+	mvccCmd.Flags().Var(
+		mvccConfig.rows, "rows", "number of keys spanned by each scan [{zipf,uniform}:]min[-max]")
+	mvccCmd.Flags().Uint64Var(
+		&mvccConfig.filterSince, "filter-since", 900, "the timestamp from which filtered scans read")
+	mvccCmd.Flags().StringVar(
+		&mvccConfig.modes, "modes", "plain,masked,filtered", "comma-separated list of scan modes")
+}
+
+// mvccScanMode describes the configuration of a scan.
+type mvccScanMode int
+
+const (
+	mvccScanPlain mvccScanMode = iota
+	mvccScanMasked
+	mvccScanFiltered
+	mvccNumScanModes
+)
+
+var mvccScanModeNames = [mvccNumScanModes]string{
+	mvccScanPlain:    "plain",
+	mvccScanMasked:   "masked",
+	mvccScanFiltered: "filtered",
+}
+
+func (m mvccScanMode) String() string { return mvccScanModeNames[m] }
+
+func parseMVCCScanModes(s string) ([]mvccScanMode, error) {
+	var modes []mvccScanMode
+	for _, name := range strings.Split(s, ",") {
+		i := slices.Index(mvccScanModeNames[:], strings.TrimSpace(name))
+		if i < 0 {
+			return nil, errors.Errorf("unknown scan mode: %s", errors.Safe(name))
+		}
+		modes = append(modes, mvccScanMode(i))
+	}
+	return modes, nil
+}
+
+// mvccScanStats accumulates the results of the scans of a single mode. Since
+// workers cycle through the modes, throughput is computed relative to the
+// time spent scanning in each mode.
+type mvccScanStats struct {
+	scans      atomic.Int64
+	rows       atomic.Int64
+	blockBytes atomic.Uint64
+	duration   atomic.Int64
+}
+
+type mvccBench struct {
+	modes []mvccScanMode
+	reg   *histogramRegistry
+	stats [mvccNumScanModes]mvccScanStats
+}
+
+func runMVCC(cmd *cobra.Command, args []string) error {
+	modes, err := parseMVCCScanModes(mvccConfig.modes)
+	if err != nil {
+		return err
+	}
+	if mvccConfig.keys <= 0 || mvccConfig.maxTimestamp == 0 {
+		return errors.New("--keys and --max-timestamp must be positive")
+	}
+	b := &mvccBench{modes: modes, reg: newHistogramRegistry()}
+
+	// Collect MVCC timestamp intervals so that masked and filtered scans may
+	// skip blocks.
+	blockPropertyCollectors = []func() pebble.BlockPropertyCollector{newMVCCTimeIntervalCollector}
+	runTest(args[0], test{
+		init: b.init,
+		tick: b.tick,
+		done: b.done,
+	})
+	return nil
+}
+
+func mvccUserKey(buf []byte, i int) []byte {
+	return encodeUint32Ascending(append(buf[:0], "key-"...), uint32(i))
+}
+
+func (b *mvccBench) init(d DB, wg *sync.WaitGroup) {
+	writeOpts := pebble.Sync
+	if disableWAL {
+		writeOpts = pebble.NoSync
+	}
+	rng := rand.New(rand.NewSource(1449168817))
+	var userKey, key, value []byte
+
+	// Load versioned keys, remembering each key's timestamps in descending
+	// order for garbage collection.
+	var versions, gced int
+	timestamps := make([][]uint64, mvccConfig.keys)
+	batch := d.NewBatch()
+	flushBatch := func() {
+		if err := batch.Commit(writeOpts); err != nil {
+			log.Fatal(err)
+		}
+		batch = d.NewBatch()
+	}
+	for i := range timestamps {
+		n := int(mvccConfig.versions.Uint64(rng))
+		ts := make([]uint64, 0, n)
+		for j := 0; j < n; j++ {
+			ts = append(ts, 1+rng.Uint64n(mvccConfig.maxTimestamp))
+		}
+		slices.Sort(ts)
+		ts = slices.Compact(ts)
+		slices.Reverse(ts)
+		timestamps[i] = ts
+
+		userKey = mvccUserKey(userKey, i)
+		for _, t := range ts {
+			key = crdbtest.EncodeMVCCKey(key, userKey, t, 0)
+			value = mvccConfig.values.Bytes(rng, value)
+			if err := batch.Set(key, value, nil); err != nil {
+				log.Fatal(err)
+			}
+			versions++
+		}
+		if (i+1)%1000 == 0 {
+			flushBatch()
+		}
+	}
+	flushBatch()
+
+	// Write MVCC range tombstones: range keys with a timestamp suffix and an
+	// empty value.
+	var startBuf, endBuf []byte
+	for i := 0; i < mvccConfig.rangeTombstones; i++ {
+		n := max(int(mvccConfig.rangeKeys.Uint64(rng)), 1)
+		start := rng.Intn(mvccConfig.keys)
+		end := min(start+n, mvccConfig.keys)
+		userKey = mvccUserKey(userKey, start)
+		startBuf = crdbtest.EncodeMVCCKey(startBuf, userKey, 0, 0)
+		userKey = mvccUserKey(userKey, end)
+		endBuf = crdbtest.EncodeMVCCKey(endBuf, userKey, 0, 0)
+		suffix := mvccSuffix(1 + rng.Uint64n(mvccConfig.maxTimestamp))
+		if err := batch.RangeKeySet(startBuf, endBuf, suffix, nil, nil); err != nil {
+			log.Fatal(err)
+		}
+	}
+	flushBatch()
+
+	// Garbage collect shadowed versions older than the GC threshold. The
+	// newest version of each key is always retained.
+	if mvccConfig.gcThreshold > 0 {
+		for i, ts := range timestamps {
+			userKey = mvccUserKey(userKey, i)
+			for j := 1; j < len(ts); j++ {
+				t := ts[j]
+				if t >= mvccConfig.gcThreshold {
+					continue
+				}
+				key = crdbtest.EncodeMVCCKey(key, userKey, t, 0)
+				if err := batch.Delete(key, nil); err != nil {
+					log.Fatal(err)
+				}
+				gced++
+			}
+			if (i+1)%1000 == 0 {
+				flushBatch()
+			}
+		}
+		flushBatch()
+	}
+	_ = batch.Close()
+	if err := d.Flush(); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("loaded %d keys with %d versions, %d range tombstones; garbage collected %d versions\n",
+		mvccConfig.keys, versions, mvccConfig.rangeTombstones, gced)
+
+	limiter := maxOpsPerSec.newRateLimiter()
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func(i int) {
+			defer wg.Done()
+			b.run(d, rand.New(rand.NewSource(uint64(i))), limiter, i)
+		}(i)
+	}
+}
+
+// run performs scans, cycling through the configured scan modes.
+func (b *mvccBench) run(d DB, rng *rand.Rand, limiter *rate.Limiter, worker int) {
+	var latency [mvccNumScanModes]*namedHistogram
+	for _, m := range b.modes {
+		latency[m] = b.reg.Register(m.String())
+	}
+	var userKey, lower, upper []byte
+	for i := worker; ; i++ {
+		wait(limiter)
+
+		mode := b.modes[i%len(b.modes)]
+		rows := int(mvccConfig.rows.Uint64(rng))
+		start := rng.Intn(mvccConfig.keys)
+		userKey = mvccUserKey(userKey, start)
+		lower = crdbtest.EncodeMVCCKey(lower, userKey, 0, 0)
+		userKey = mvccUserKey(userKey, start+rows)
+		upper = crdbtest.EncodeMVCCKey(upper, userKey, 0, 0)
+
+		begin := time.Now()
+		count, blockBytes := b.scan(d, mode, lower, upper)
+		elapsed := time.Since(begin)
+		latency[mode].Record(elapsed)
+
+		s := &b.stats[mode]
+		s.duration.Add(int64(elapsed))
+		s.scans.Add(1)
+		s.rows.Add(int64(count))
+		s.blockBytes.Add(blockBytes)
+	}
+}
+
+// scan scans the keys within [lower, upper) in the provided mode, returning
+// the number of keys observed and the bytes of blocks loaded.
+func (b *mvccBench) scan(d DB, mode mvccScanMode, lower, upper []byte) (int, uint64) {
+	opts := &pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+		KeyTypes:   pebble.IterKeyTypePointsAndRanges,
+	}
+	switch mode {
+	case mvccScanMasked:
+		// Mask every point beneath a range tombstone by masking at the
+		// maximum timestamp.
+		opts.RangeKeyMasking = pebble.RangeKeyMasking{
+			Suffix: mvccSuffix(math.MaxUint64),
+			Filter: func() pebble.BlockPropertyFilterMask {
+				return mvccMaskingFilter{newMVCCTimeIntervalFilter(0, math.MaxUint64)}
+			},
+		}
+	case mvccScanFiltered:
+		opts.KeyTypes = pebble.IterKeyTypePointsOnly
+		opts.PointKeyFilters = []pebble.BlockPropertyFilter{
+			newMVCCTimeIntervalFilter(mvccConfig.filterSince, math.MaxUint64),
+		}
+	}
+	it := d.NewIter(opts)
+	var count int
+	for valid := it.First(); valid; valid = it.Next() {
+		_ = it.Value()
+		count++
+	}
+
+	type stats interface {
+		Stats() pebble.IteratorStats
+	}
+	var blockBytes uint64
+	if s, ok := it.(stats); ok {
+		blockBytes = s.Stats().InternalStats.BlockBytes
+	}
+	if err := it.Close(); err != nil {
+		log.Fatal(err)
+	}
+	return count, blockBytes
+}
+
+func (b *mvccBench) tick(elapsed time.Duration, i int) {
+	if i%20 == 0 {
+		fmt.Println("______mode__elapsed__ops/sec(inst)___ops/sec(cum)__p50(ms)__p95(ms)__p99(ms)_pMax(ms)")
+	}
+	b.reg.Tick(func(tick histogramTick) {
+		h := tick.Hist
+		fmt.Printf("%10s %8s %14.1f %14.1f %8.1f %8.1f %8.1f %8.1f\n",
+			tick.Name,
+			time.Duration(elapsed.Seconds()+0.5)*time.Second,
+			float64(h.TotalCount())/tick.Elapsed.Seconds(),
+			float64(tick.Cumulative.TotalCount())/elapsed.Seconds(),
+			time.Duration(h.ValueAtQuantile(50)).Seconds()*1000,
+			time.Duration(h.ValueAtQuantile(95)).Seconds()*1000,
+			time.Duration(h.ValueAtQuantile(99)).Seconds()*1000,
+			time.Duration(h.ValueAtQuantile(100)).Seconds()*1000,
+		)
+	})
+}
+
+func (b *mvccBench) done(elapsed time.Duration) {
+	fmt.Println("\n______mode__elapsed__ops/sec(busy)_rows/sec(busy)___rows/op__block-KB/op__avg(ms)__p99(ms)")
+	b.reg.Tick(func(tick histogramTick) {
+		h := tick.Cumulative
+		var s *mvccScanStats
+		for m, name := range mvccScanModeNames {
+			if name == tick.Name {
+				s = &b.stats[m]
+			}
+		}
+		scans := max(s.scans.Load(), 1)
+		// The time spent scanning in this mode, per worker.
+		busy := time.Duration(s.duration.Load() / int64(concurrency))
+		fmt.Printf("%10s %7.1fs %14.1f %14.1f %9.1f %11.1f %8.1f %8.1f\n",
+			tick.Name, elapsed.Seconds(),
+			float64(s.scans.Load())/busy.Seconds(),
+			float64(s.rows.Load())/busy.Seconds(),
+			float64(s.rows.Load())/float64(scans),
+			float64(s.blockBytes.Load())/float64(scans)/(1<<10),
+			time.Duration(h.Mean()).Seconds()*1000,
+			time.Duration(h.ValueAtQuantile(99)).Seconds()*1000)
+	})
+	fmt.Println()
+}
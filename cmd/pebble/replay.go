@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/pebble/internal/crdbtest"
 	"github.com/cockroachdb/pebble/replay"
 	"github.com/cockroachdb/pebble/vfs"
+	"github.com/cockroachdb/pebble/vfs/errorfs"
 	"github.com/spf13/cobra"
 )
 
@@ -52,6 +53,8 @@ func initReplayCmd() *cobra.Command {
 		&c.pacer, "pacer", "p", "the pacer to use: unpaced, reference-ramp, or fixed-ramp=N")
 	cmd.Flags().Uint64Var(
 		&c.maxWritesMB, "max-writes", 0, "the maximum volume of writes (MB) to apply, with 0 denoting unlimited")
+	cmd.Flags().IntVar(
+		&c.readConcurrency, "read-concurrency", 0, "the number of goroutines replaying the workload's captured reads, with 0 denoting no reads")
 	cmd.Flags().StringVar(
 		&c.optionsString, "options", "", "Pebble options to override, in the OPTIONS ini format but with any whitespace as field delimiters instead of newlines")
 	cmd.Flags().StringVar(
@@ -64,6 +67,12 @@ func initReplayCmd() *cobra.Command {
 		&c.ignoreCheckpoint, "ignore-checkpoint", c.ignoreCheckpoint, "ignore the workload's initial checkpoint")
 	cmd.Flags().StringVar(
 		&c.checkpointDir, "checkpoint-dir", c.checkpointDir, "path to the checkpoint to use if not <WORKLOAD_DIR>/checkpoint")
+	cmd.Flags().StringVar(
+		&c.injectLatency, "inject-latency", "", "an errorfs injector, eg (RandomLatency \"1ms\" 42), with which to inject latency into the replay's filesystem operations")
+	cmd.Flags().StringArrayVar(
+		&c.variants, "variant", nil, "a name=OPTIONS pair describing Pebble options to override for one variant of a sweep; if repeated, the workload is replayed once per variant and the variants are compared")
+	cmd.Flags().BoolVar(
+		&c.inMemory, "in-memory", false, "replay each variant of a sweep within an in-memory filesystem rather than within the run directory")
 	return cmd
 }
 
@@ -73,11 +82,15 @@ type replayConfig struct {
 	runDir           string
 	count            int
 	maxWritesMB      uint64
+	readConcurrency  int
 	streamLogs       bool
 	checkpointDir    string
 	ignoreCheckpoint bool
 	optionsString    string
 	maxCacheSize     int64
+	injectLatency    string
+	variants         []string
+	inMemory         bool
 
 	cleanUpFuncs []func() error
 }
@@ -98,6 +111,9 @@ func (c *replayConfig) args() (args []string) {
 	if c.maxWritesMB != 0 {
 		args = append(args, "--max-writes", fmt.Sprint(c.maxWritesMB))
 	}
+	if c.readConcurrency != 0 {
+		args = append(args, "--read-concurrency", fmt.Sprint(c.readConcurrency))
+	}
 	if c.maxCacheSize != 0 {
 		args = append(args, "--max-cache-size", fmt.Sprint(c.maxCacheSize))
 	}
@@ -113,6 +129,9 @@ func (c *replayConfig) args() (args []string) {
 	if c.optionsString != "" {
 		args = append(args, "--options", c.optionsString)
 	}
+	if c.injectLatency != "" {
+		args = append(args, "--inject-latency", c.injectLatency)
+	}
 	return args
 }
 
@@ -120,9 +139,20 @@ func (c *replayConfig) runE(cmd *cobra.Command, args []string) error {
 	if c.ignoreCheckpoint && c.checkpointDir != "" {
 		return errors.Newf("cannot provide both --checkpoint-dir and --ignore-checkpoint")
 	}
+	if c.injectLatency != "" {
+		if _, err := errorfs.ParseDSL(c.injectLatency); err != nil {
+			return errors.Wrapf(err, "parsing --inject-latency")
+		}
+	}
 	stdout := cmd.OutOrStdout()
 
 	workloadPath := args[0]
+	if len(c.variants) > 0 {
+		if c.count > 1 {
+			return errors.Newf("cannot provide both --count and --variant")
+		}
+		return c.runSweep(stdout, workloadPath)
+	}
 	if err := c.runOnce(stdout, workloadPath); err != nil {
 		return err
 	}
@@ -154,11 +184,12 @@ func (c *replayConfig) runOnce(stdout io.Writer, workloadPath string) error {
 	}
 
 	r := &replay.Runner{
-		RunDir:       c.runDir,
-		WorkloadFS:   vfs.Default,
-		WorkloadPath: workloadPath,
-		Pacer:        c.pacer,
-		Opts:         &pebble.Options{},
+		RunDir:          c.runDir,
+		WorkloadFS:      vfs.Default,
+		WorkloadPath:    workloadPath,
+		Pacer:           c.pacer,
+		Opts:            &pebble.Options{},
+		ReadConcurrency: c.readConcurrency,
 	}
 	if c.maxWritesMB > 0 {
 		r.MaxWriteBytes = c.maxWritesMB * (1 << 20)
@@ -166,9 +197,14 @@ func (c *replayConfig) runOnce(stdout io.Writer, workloadPath string) error {
 	if err := c.initRunDir(r); err != nil {
 		return err
 	}
-	if err := c.initOptions(r); err != nil {
+	if err := c.initOptions(r, ""); err != nil {
 		return err
 	}
+	if c.injectLatency != "" {
+		// The injector was validated by runE.
+		inj, _ := errorfs.ParseDSL(c.injectLatency)
+		r.Opts.FS = errorfs.Wrap(r.Opts.FS, inj)
+	}
 	if verbose {
 		fmt.Fprintln(stdout, "Options:")
 		fmt.Fprintln(stdout, r.Opts.String())
@@ -239,7 +275,87 @@ func (c *replayConfig) initRunDir(r *replay.Runner) error {
 	return nil
 }
 
-func (c *replayConfig) initOptions(r *replay.Runner) error {
+// runSweep replays the workload once for each of the configured variants,
+// printing a report comparing them.
+func (c *replayConfig) runSweep(stdout io.Writer, workloadPath string) error {
+	defer c.cleanUp()
+	if c.name == "" {
+		c.name = vfs.Default.PathBase(workloadPath)
+	}
+
+	s := &replay.Sweep{
+		WorkloadFS:      vfs.Default,
+		WorkloadPath:    workloadPath,
+		Pacer:           c.pacer,
+		ReadConcurrency: c.readConcurrency,
+	}
+	if c.maxWritesMB > 0 {
+		s.MaxWriteBytes = c.maxWritesMB * (1 << 20)
+	}
+	// Use a Runner to resolve the run and checkpoint directories in the same
+	// manner as a single replay.
+	base := &replay.Runner{RunDir: c.runDir, WorkloadFS: vfs.Default, WorkloadPath: workloadPath}
+	if !c.inMemory {
+		if base.RunDir == "" {
+			wd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			base.RunDir, err = os.MkdirTemp(wd, "replay-")
+			if err != nil {
+				return err
+			}
+			c.cleanUpFuncs = append(c.cleanUpFuncs, func() error {
+				return os.RemoveAll(base.RunDir)
+			})
+		}
+		s.RunFS, s.RunDir = vfs.Default, base.RunDir
+	}
+	if !c.ignoreCheckpoint {
+		s.CheckpointDir = c.getCheckpointDir(base)
+	}
+	if c.injectLatency != "" {
+		s.NewInjector = func() errorfs.Injector {
+			// The injector was validated by runE.
+			inj, _ := errorfs.ParseDSL(c.injectLatency)
+			return inj
+		}
+	}
+	for _, v := range c.variants {
+		name, optionsString, ok := strings.Cut(v, "=")
+		if !ok {
+			return errors.Newf("invalid --variant %q; expected name=OPTIONS", v)
+		}
+		s.Variants = append(s.Variants, replay.Variant{
+			Name: name,
+			Configure: func(o *pebble.Options) error {
+				return c.initOptions(&replay.Runner{
+					WorkloadFS:   s.WorkloadFS,
+					WorkloadPath: s.WorkloadPath,
+					Opts:         o,
+				}, optionsString)
+			},
+		})
+	}
+
+	results, err := s.Run(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, "Sweep complete.")
+	for _, r := range results {
+		if err := r.Metrics.WriteBenchmarkString(c.name+"/"+r.Variant, stdout); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(stdout)
+	return results.WriteReport(stdout)
+}
+
+// initOptions initializes the Runner's Options from the workload's checkpoint
+// and the --options flag. If variantOptions is non-empty, it's parsed last,
+// overriding both.
+func (c *replayConfig) initOptions(r *replay.Runner, variantOptions string) error {
 	// If using a workload checkpoint, load the Options from it.
 	// TODO(jackson): Allow overriding the OPTIONS.
 	if !c.ignoreCheckpoint {
@@ -274,6 +390,9 @@ func (c *replayConfig) initOptions(r *replay.Runner) error {
 	if err := c.parseCustomOptions(c.optionsString, r.Opts); err != nil {
 		return err
 	}
+	if err := c.parseCustomOptions(variantOptions, r.Opts); err != nil {
+		return err
+	}
 	// TODO(jackson): If r.Opts.Comparer == nil, peek at the workload's
 	// manifests and pull the comparer out of them.
 	//
@@ -67,12 +67,13 @@ func runTombstoneCmd(cmd *cobra.Command, args []string) error {
 
 	batchDist := ycsbConfig.batch
 	scanDist := ycsbConfig.scans
+	rangeDeleteDist := ycsbConfig.rangeDeletes
 	if err != nil {
 		return err
 	}
 
 	valueDist := ycsbConfig.values
-	y := newYcsb(weights, keyDist, batchDist, scanDist, valueDist)
+	y := newYcsb(weights, keyDist, batchDist, scanDist, rangeDeleteDist, valueDist, ycsbOldValueDist())
 	q, queueOps := queueTest()
 
 	queueStart := []byte("queue-")
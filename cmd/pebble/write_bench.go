@@ -13,6 +13,8 @@ import (
 	"github.com/cockroachdb/pebble/internal/ackseq"
 	"github.com/cockroachdb/pebble/internal/randvar"
 	"github.com/cockroachdb/pebble/internal/rate"
+	"github.com/prometheus/client_golang/prometheus"
+	prometheusgo "github.com/prometheus/client_model/go"
 	"github.com/spf13/cobra"
 )
 
@@ -41,9 +43,9 @@ var writeBenchConfig struct {
 
 var writeBenchCmd = &cobra.Command{
 	Use:   "write <dir>",
-	Short: "Run YCSB F to find an a sustainable write throughput",
+	Short: "Run an insert-only YCSB workload to find an a sustainable write throughput",
 	Long: `
-Run YCSB F (100% writes) at varying levels of sustained write load (ops/sec) to
+Run an insert-only YCSB workload (100% writes) at varying levels of sustained write load (ops/sec) to
 determine an optimal value of write throughput.
 
 The benchmark works by maintaining a fixed amount of write load on the DB for a
@@ -155,7 +157,7 @@ func (r writeBenchResult) String() string {
 }
 
 func runWriteBenchmark(_ *cobra.Command, args []string) error {
-	const workload = "F" // 100% inserts.
+	const workload = "insert=100"
 	var (
 		writers      []*pauseWriter
 		writersWg    *sync.WaitGroup // Tracks completion of all pauseWriters.
@@ -183,8 +185,8 @@ func runWriteBenchmark(_ *cobra.Command, args []string) error {
 	batchDist := writeBenchConfig.batch
 	valueDist := writeBenchConfig.values
 
-	// Construct a new YCSB F benchmark with the configured values.
-	y := newYcsb(weights, keyDist, batchDist, nil /* scans */, valueDist)
+	// Construct a new insert-only YCSB benchmark with the configured values.
+	y := newYcsb(weights, keyDist, batchDist, nil /* scans */, nil /* range deletes */, valueDist, nil /* old values */)
 	y.keyNum = ackseq.New(0)
 
 	setLimit := func(l int) {
@@ -404,14 +406,31 @@ func runWriteBenchmark(_ *cobra.Command, args []string) error {
 			y.reg.Tick(func(tick histogramTick) {
 				total = tick.Cumulative.TotalCount()
 			})
-			fmt.Println("___elapsed___ops(total)")
-			fmt.Printf("%10s %12d\n", elapsed.Truncate(time.Second), total)
+			// The memtable apply latency reflects the effect of
+			// --memtable-apply-concurrency on the time spent inserting each
+			// batch into the memtable.
+			m := y.db.Metrics()
+			fmt.Println("___elapsed___ops(total)___ops/sec(cum)___apply-avg(us)")
+			fmt.Printf("%10s %12d %14.1f %15.1f\n",
+				elapsed.Truncate(time.Second),
+				total,
+				float64(total)/elapsed.Seconds(),
+				histogramMean(m.Commit.MemTableApplyLatency).Seconds()*1e6)
 		},
 	})
 
 	return nil
 }
 
+// histogramMean returns the mean of the durations observed by the histogram.
+func histogramMean(h prometheus.Histogram) time.Duration {
+	var m prometheusgo.Metric
+	if err := h.Write(&m); err != nil || m.Histogram.GetSampleCount() == 0 {
+		return 0
+	}
+	return time.Duration(m.Histogram.GetSampleSum() / float64(m.Histogram.GetSampleCount()))
+}
+
 // debugPrint prints a debug line to stdout if debug logging is enabled via the
 // --debug flag.
 func debugPrint(s string) {
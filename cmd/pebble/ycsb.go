@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/internal/ackseq"
+	"github.com/cockroachdb/pebble/internal/crdbtest"
 	"github.com/cockroachdb/pebble/internal/randvar"
 	"github.com/cockroachdb/pebble/internal/rate"
 	"github.com/spf13/cobra"
@@ -28,6 +29,9 @@ const (
 	ycsbScan
 	ycsbReverseScan
 	ycsbUpdate
+	ycsbReadModifyWrite
+	ycsbDelete
+	ycsbDeleteRange
 	ycsbNumOps
 )
 
@@ -38,7 +42,10 @@ var ycsbConfig struct {
 	prepopulatedKeys int
 	numOps           uint64
 	scans            *randvar.Flag
+	rangeDeletes     *randvar.Flag
 	values           *randvar.BytesFlag
+	oldValues        *randvar.BytesFlag
+	oldKeys          float64
 	workload         string
 }
 
@@ -51,6 +58,8 @@ flag which can take either one of the standard workload mixes (A-F), or
 customizable workload fixes specified as a command separated list of op=weight
 pairs. For example, --workload=read=50,update=50 performs a workload composed
 of 50% reads and 50% updates. This is identical to the standard workload A.
+The available ops are insert, read, scan, rscan (reverse scan), update, rmw
+(read-modify-write), delete and rdel (range deletion).
 
 The --batch, --scans, and --values flags take the specification for a random
 variable: [<type>:]<min>[-<max>]. The <type> parameter must be one of "uniform"
@@ -60,23 +69,33 @@ in a constant 1000. The specification "10-100" results in a uniformly random
 variable in the range [10,100). The specification "zipf(10,100)" results in a
 zipf distribution with a minimum value of 10 and a maximum value of 100.
 
-The --batch flag controls the size of batches used for insert and update
-operations. The --scans flag controls the number of iterations performed by a
-scan operation. Read operations always read a single key.
+The --batch flag controls the size of batches used for insert, update and
+delete operations. The --scans flag controls the number of iterations performed
+by a scan operation. The --range-deletes flag controls the number of existing
+keys spanned by a range deletion. Read and read-modify-write operations always
+read a single key.
+
+The --keys flag selects the distribution of the keys read, updated and deleted.
+The latest distribution skews towards the most recently inserted keys, and is
+the default for workload D.
 
 The --values flag provides for an optional "/<target-compression-ratio>"
 suffix. The default target compression ratio is 1.0 (i.e. incompressible random
 data). A value of 2 will cause random data to be generated that should compress
 to 50% of its uncompressed size.
 
+The --old-values flag ties value sizes to key age: if set, updates and
+read-modify-writes of the oldest --old-keys fraction of the keys draw their
+values from the --old-values distribution rather than from --values.
+
 Standard workloads:
 
   A:  50% reads   /  50% updates
   B:  95% reads   /   5% updates
   C: 100% reads
-  D:  95% reads   /   5% inserts
+  D:  95% reads   /   5% inserts (latest key distribution)
   E:  95% scans   /   5% inserts
-  F: 100% inserts
+  F:  50% reads   /  50% read-modify-writes
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: runYcsb,
@@ -92,7 +111,7 @@ func initYCSB(cmd *cobra.Command) {
 		ycsbConfig.batch, "batch",
 		"batch size distribution [{zipf,uniform}:]min[-max]")
 	cmd.Flags().StringVar(
-		&ycsbConfig.keys, "keys", "zipf", "latest, uniform, or zipf")
+		&ycsbConfig.keys, "keys", "zipf", "latest, uniform, or zipf (defaults to latest for workload D)")
 	cmd.Flags().IntVar(
 		&ycsbConfig.initialKeys, "initial-keys", 10000,
 		"initial number of keys to insert before beginning workload")
@@ -106,6 +125,10 @@ func initYCSB(cmd *cobra.Command) {
 	cmd.Flags().Var(
 		ycsbConfig.scans, "scans",
 		"scan length distribution [{zipf,uniform}:]min[-max]")
+	ycsbConfig.rangeDeletes = randvar.NewFlag("uniform:1-100")
+	cmd.Flags().Var(
+		ycsbConfig.rangeDeletes, "range-deletes",
+		"range deletion length distribution [{zipf,uniform}:]min[-max]")
 	cmd.Flags().StringVar(
 		&ycsbConfig.workload, "workload", "B",
 		"workload type (A-F) or spec (read=X,update=Y,...)")
@@ -113,6 +136,13 @@ func initYCSB(cmd *cobra.Command) {
 	cmd.Flags().Var(
 		ycsbConfig.values, "values",
 		"value size distribution [{zipf,uniform}:]min[-max][/<target-compression>]")
+	ycsbConfig.oldValues = &randvar.BytesFlag{}
+	cmd.Flags().Var(
+		ycsbConfig.oldValues, "old-values",
+		"value size distribution for writes to old keys [{zipf,uniform}:]min[-max][/<target-compression>]")
+	cmd.Flags().Float64Var(
+		&ycsbConfig.oldKeys, "old-keys", 0.5,
+		"the fraction of the oldest keys whose writes use --old-values")
 }
 
 type ycsbWeights []float64
@@ -139,15 +169,14 @@ var ycsbWorkloads = map[string]ycsbWeights{
 	"D": {
 		ycsbInsert: 0.05,
 		ycsbRead:   0.95,
-		// TODO(peter): default to skewed-latest distribution.
 	},
 	"E": {
 		ycsbInsert: 0.05,
 		ycsbScan:   0.95,
 	},
 	"F": {
-		ycsbInsert: 1.0,
-		// TODO(peter): the real workload is read-modify-write.
+		ycsbRead:            0.5,
+		ycsbReadModifyWrite: 0.5,
 	},
 }
 
@@ -176,6 +205,14 @@ func ycsbParseWorkload(w string) (ycsbWeights, error) {
 			iWeights[ycsbReverseScan] = weight
 		case "update":
 			iWeights[ycsbUpdate] = weight
+		case "rmw":
+			iWeights[ycsbReadModifyWrite] = weight
+		case "delete":
+			iWeights[ycsbDelete] = weight
+		case "rdel":
+			iWeights[ycsbDeleteRange] = weight
+		default:
+			return nil, errors.Errorf("unknown op: %s", errors.Safe(parts[0]))
 		}
 	}
 
@@ -198,7 +235,7 @@ func ycsbParseKeyDist(d string) (randvar.Dynamic, error) {
 	totalKeys := uint64(ycsbConfig.initialKeys + ycsbConfig.prepopulatedKeys)
 	switch strings.ToLower(d) {
 	case "latest":
-		return randvar.NewDefaultSkewedLatest()
+		return randvar.NewSkewedLatest(1, totalKeys, 0.99)
 	case "uniform":
 		return randvar.NewUniform(1, totalKeys), nil
 	case "zipf":
@@ -208,6 +245,15 @@ func ycsbParseKeyDist(d string) (randvar.Dynamic, error) {
 	}
 }
 
+// ycsbOldValueDist returns the value size distribution configured for writes
+// to old keys through --old-values, or nil if none is configured.
+func ycsbOldValueDist() *randvar.BytesFlag {
+	if ycsbConfig.oldValues.String() == "" {
+		return nil
+	}
+	return ycsbConfig.oldValues
+}
+
 func runYcsb(cmd *cobra.Command, args []string) error {
 	if wipe && ycsbConfig.prepopulatedKeys > 0 {
 		return errors.New("--wipe and --prepopulated-keys both specified which is nonsensical")
@@ -218,19 +264,24 @@ func runYcsb(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	keyDist, err := ycsbParseKeyDist(ycsbConfig.keys)
+	keys := ycsbConfig.keys
+	if ycsbConfig.workload == "D" && !cmd.Flags().Changed("keys") {
+		keys = "latest"
+	}
+	keyDist, err := ycsbParseKeyDist(keys)
 	if err != nil {
 		return err
 	}
+	if ycsbConfig.oldKeys < 0 || ycsbConfig.oldKeys > 1 {
+		return errors.Errorf("--old-keys must be in [0,1]: %f", ycsbConfig.oldKeys)
+	}
 
 	batchDist := ycsbConfig.batch
 	scanDist := ycsbConfig.scans
-	if err != nil {
-		return err
-	}
+	rangeDeleteDist := ycsbConfig.rangeDeletes
 
 	valueDist := ycsbConfig.values
-	y := newYcsb(weights, keyDist, batchDist, scanDist, valueDist)
+	y := newYcsb(weights, keyDist, batchDist, scanDist, rangeDeleteDist, valueDist, ycsbOldValueDist())
 	runTest(args[0], test{
 		init: y.init,
 		tick: y.tick,
@@ -242,6 +293,7 @@ func runYcsb(cmd *cobra.Command, args []string) error {
 type ycsbBuf struct {
 	rng      *rand.Rand
 	keyBuf   []byte
+	endBuf   []byte
 	valueBuf []byte
 	keyNums  []uint64
 }
@@ -254,7 +306,11 @@ type ycsb struct {
 	keyDist      randvar.Dynamic
 	batchDist    randvar.Static
 	scanDist     randvar.Static
+	rangeDelDist randvar.Static
 	valueDist    *randvar.BytesFlag
+	// oldValueDist, if non-nil, is the value size distribution used for
+	// writes to the oldest keys. See ycsb.randValue.
+	oldValueDist *randvar.BytesFlag
 	readAmpCount atomic.Uint64
 	readAmpSum   atomic.Uint64
 	keyNum       *ackseq.S
@@ -266,17 +322,19 @@ type ycsb struct {
 func newYcsb(
 	weights ycsbWeights,
 	keyDist randvar.Dynamic,
-	batchDist, scanDist randvar.Static,
-	valueDist *randvar.BytesFlag,
+	batchDist, scanDist, rangeDelDist randvar.Static,
+	valueDist, oldValueDist *randvar.BytesFlag,
 ) *ycsb {
 	y := &ycsb{
-		reg:       newHistogramRegistry(),
-		weights:   weights,
-		keyDist:   keyDist,
-		batchDist: batchDist,
-		scanDist:  scanDist,
-		valueDist: valueDist,
-		opsMap:    make(map[string]int),
+		reg:          newHistogramRegistry(),
+		weights:      weights,
+		keyDist:      keyDist,
+		batchDist:    batchDist,
+		scanDist:     scanDist,
+		rangeDelDist: rangeDelDist,
+		valueDist:    valueDist,
+		oldValueDist: oldValueDist,
+		opsMap:       make(map[string]int),
 	}
 	y.writeOpts = pebble.Sync
 	if disableWAL {
@@ -289,6 +347,9 @@ func newYcsb(
 		"rscan":  ycsbReverseScan,
 		"scan":   ycsbScan,
 		"update": ycsbUpdate,
+		"rmw":    ycsbReadModifyWrite,
+		"delete": ycsbDelete,
+		"rdel":   ycsbDeleteRange,
 	}
 	for name, op := range ops {
 		w := y.weights.get(op)
@@ -353,7 +414,8 @@ func (y *ycsb) init(db DB, wg *sync.WaitGroup) {
 
 	// If this workload doesn't produce reads, sample the worst case read-amp
 	// from Metrics() periodically.
-	if y.weights.get(ycsbRead) == 0 && y.weights.get(ycsbScan) == 0 && y.weights.get(ycsbReverseScan) == 0 {
+	if y.weights.get(ycsbRead) == 0 && y.weights.get(ycsbScan) == 0 &&
+		y.weights.get(ycsbReverseScan) == 0 && y.weights.get(ycsbReadModifyWrite) == 0 {
 		wg.Add(1)
 		go y.sampleReadAmp(db, wg)
 	}
@@ -391,6 +453,12 @@ func (y *ycsb) run(db DB, wg *sync.WaitGroup) {
 			y.scan(db, buf, true /* reverse */)
 		case ycsbUpdate:
 			y.update(db, buf)
+		case ycsbReadModifyWrite:
+			y.readModifyWrite(db, buf)
+		case ycsbDelete:
+			y.delete(db, buf)
+		case ycsbDeleteRange:
+			y.deleteRange(db, buf)
 		default:
 			panic("not reached")
 		}
@@ -447,11 +515,14 @@ func (y *ycsb) makeKey(keyNum uint64, buf *ycsbBuf) []byte {
 	return key
 }
 
-func (y *ycsb) nextReadKey(buf *ycsbBuf) []byte {
+func (y *ycsb) nextKeyNum(buf *ycsbBuf) uint64 {
 	// NB: the range of values returned by keyDist is tied to the range returned
 	// by keyNum.Base. See how these are both incremented by ycsb.insert().
-	keyNum := y.keyDist.Uint64(buf.rng)
-	return y.makeKey(keyNum, buf)
+	return y.keyDist.Uint64(buf.rng)
+}
+
+func (y *ycsb) nextReadKey(buf *ycsbBuf) []byte {
+	return y.makeKey(y.nextKeyNum(buf), buf)
 }
 
 func (y *ycsb) randBytes(buf *ycsbBuf) []byte {
@@ -459,6 +530,17 @@ func (y *ycsb) randBytes(buf *ycsbBuf) []byte {
 	return buf.valueBuf
 }
 
+// randValue returns a random value to write to the key with the provided
+// number. Keys are numbered in insertion order, so if an old value
+// distribution is configured it's used for the oldest keys.
+func (y *ycsb) randValue(keyNum uint64, buf *ycsbBuf) []byte {
+	if y.oldValueDist == nil || float64(keyNum) > ycsbConfig.oldKeys*float64(y.keyDist.Max()) {
+		return y.randBytes(buf)
+	}
+	buf.valueBuf = y.oldValueDist.Bytes(buf.rng, buf.valueBuf)
+	return buf.valueBuf
+}
+
 func (y *ycsb) insert(db DB, buf *ycsbBuf) {
 	count := y.batchDist.Uint64(buf.rng)
 	if cap(buf.keyNums) < int(count) {
@@ -534,8 +616,78 @@ func (y *ycsb) update(db DB, buf *ycsbBuf) {
 	count := int(y.batchDist.Uint64(buf.rng))
 	b := db.NewBatch()
 	for i := 0; i < count; i++ {
-		_ = b.Set(y.nextReadKey(buf), y.randBytes(buf), nil)
+		keyNum := y.nextKeyNum(buf)
+		_ = b.Set(y.makeKey(keyNum, buf), y.randValue(keyNum, buf), nil)
+	}
+	if err := b.Commit(y.writeOpts); err != nil {
+		log.Fatal(err)
 	}
+	_ = b.Close()
+}
+
+// readModifyWrite reads a single key and writes a new value for it, as in
+// YCSB workload F. The read and write are not atomic.
+func (y *ycsb) readModifyWrite(db DB, buf *ycsbBuf) {
+	keyNum := y.nextKeyNum(buf)
+	key := y.makeKey(keyNum, buf)
+	iter := db.NewIter(nil)
+	if iter.SeekGE(key) {
+		_ = iter.Value()
+	}
+	if err := iter.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	b := db.NewBatch()
+	_ = b.Set(key, y.randValue(keyNum, buf), nil)
+	if err := b.Commit(y.writeOpts); err != nil {
+		log.Fatal(err)
+	}
+	_ = b.Close()
+}
+
+func (y *ycsb) delete(db DB, buf *ycsbBuf) {
+	count := int(y.batchDist.Uint64(buf.rng))
+	b := db.NewBatch()
+	for i := 0; i < count; i++ {
+		_ = b.Delete(y.nextReadKey(buf), nil)
+	}
+	if err := b.Commit(y.writeOpts); err != nil {
+		log.Fatal(err)
+	}
+	_ = b.Close()
+}
+
+// ycsbKeySpaceEnd is an unversioned key that sorts after every key generated
+// by ycsb.makeKey, since the digits of a key's hash sort before ':'.
+var ycsbKeySpaceEnd = []byte("user:\x00")
+
+// deleteRange deletes a range of keys beginning at a random key and spanning
+// the number of existing keys drawn from the range deletion distribution.
+func (y *ycsb) deleteRange(db DB, buf *ycsbBuf) {
+	count := y.rangeDelDist.Uint64(buf.rng)
+	// Range deletion bounds are unversioned keys, so strip the timestamp
+	// appended by makeKey.
+	start := y.nextReadKey(buf)
+	start = start[:crdbtest.Split(start)]
+
+	iter := db.NewIter(nil)
+	valid := iter.SeekGE(start)
+	for i := uint64(0); valid && i < count; i++ {
+		valid = iter.Next()
+	}
+	end := ycsbKeySpaceEnd
+	if valid {
+		k := iter.Key()
+		buf.endBuf = append(buf.endBuf[:0], k[:crdbtest.Split(k)]...)
+		end = buf.endBuf
+	}
+	if err := iter.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	b := db.NewBatch()
+	_ = b.DeleteRange(start, end, nil)
 	if err := b.Commit(y.writeOpts); err != nil {
 		log.Fatal(err)
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/cockroachdb/pebble/batchrepr"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/record"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // commitQueue is a lock-free fixed-size single-producer, multi-consumer
@@ -190,7 +191,9 @@ type commitEnv struct {
 //
 // As soon as a batch has been written to the WAL, the commitPipeline mutex is
 // released allowing another batch to write to the WAL. Each commit operation
-// individually applies its batch to the memtable providing concurrency. The
+// individually applies its batch to the memtable providing concurrency. A
+// large batch may itself be applied by several goroutines (see
+// Options.Experimental.MemTableApplyConcurrency). The
 // WAL sync happens concurrently with applying to the memtable (see
 // commitPipeline.syncLoop).
 //
@@ -248,6 +251,31 @@ type commitPipeline struct {
 	// The mutex to use for synchronizing access to logSeqNum and serializing
 	// calls to commitEnv.write().
 	mu sync.Mutex
+	// metrics aggregates the BatchCommitStats of committed batches.
+	metrics commitMetrics
+}
+
+// commitMetrics holds histograms of the latencies of the stages of committing
+// a batch. See Metrics.Commit.
+type commitMetrics struct {
+	queueWaitLatency     prometheus.Histogram
+	walWriteLatency      prometheus.Histogram
+	syncWaitLatency      prometheus.Histogram
+	memTableApplyLatency prometheus.Histogram
+}
+
+func newCommitMetrics() commitMetrics {
+	newHistogram := func() prometheus.Histogram {
+		return prometheus.NewHistogram(prometheus.HistogramOpts{
+			Buckets: CommitLatencyBuckets,
+		})
+	}
+	return commitMetrics{
+		queueWaitLatency:     newHistogram(),
+		walWriteLatency:      newHistogram(),
+		syncWaitLatency:      newHistogram(),
+		memTableApplyLatency: newHistogram(),
+	}
 }
 
 func newCommitPipeline(env commitEnv) *commitPipeline {
@@ -270,6 +298,7 @@ func newCommitPipeline(env commitEnv) *commitPipeline {
 		commitQueueSem: make(chan struct{}, record.SyncConcurrency-1),
 		logSyncQSem:    make(chan struct{}, record.SyncConcurrency-1),
 		ingestSem:      make(chan struct{}, 1),
+		metrics:        newCommitMetrics(),
 	}
 	return p
 }
@@ -324,6 +353,7 @@ func (p *commitPipeline) Commit(b *Batch, syncWAL bool, noSyncWait bool) error {
 	}
 
 	// Apply the batch to the memtable.
+	applyStartTime := time.Now()
 	if err := p.env.apply(b, mem); err != nil {
 		b.db = nil // prevent batch reuse on error
 		// NB: we are not doing <-p.commitQueueSem since the batch is still
@@ -331,6 +361,7 @@ func (p *commitPipeline) Commit(b *Batch, syncWAL bool, noSyncWait bool) error {
 		// removing the batch from the pending queue.
 		return err
 	}
+	b.commitStats.MemTableApplyDuration = time.Since(applyStartTime)
 
 	// Publish the batch sequence number.
 	p.publish(b)
@@ -350,6 +381,15 @@ func (p *commitPipeline) Commit(b *Batch, syncWAL bool, noSyncWait bool) error {
 
 	b.commitStats.TotalDuration = time.Since(commitStartTime)
 
+	p.metrics.queueWaitLatency.Observe(
+		float64(b.commitStats.SemaphoreWaitDuration + b.commitStats.PipelineMutexWaitDuration))
+	p.metrics.walWriteLatency.Observe(float64(b.commitStats.WALWriteDuration))
+	p.metrics.memTableApplyLatency.Observe(float64(b.commitStats.MemTableApplyDuration))
+	if syncWAL && !noSyncWait {
+		// With noSyncWait, the sync wait is observed by Batch.SyncWait.
+		p.metrics.syncWaitLatency.Observe(float64(b.commitStats.CommitWaitDuration))
+	}
+
 	return err
 }
 
@@ -452,7 +492,9 @@ func (p *commitPipeline) prepare(b *Batch, syncWAL bool, noSyncWait bool) (*memT
 		b.commit.Add(2)
 	}
 
+	lockStartTime := time.Now()
 	p.mu.Lock()
+	b.commitStats.PipelineMutexWaitDuration = time.Since(lockStartTime)
 
 	// Enqueue the batch in the pending queue. Note that while the pending queue
 	// is lock-free, we want the order of batches to be the same as the sequence
@@ -465,7 +507,9 @@ func (p *commitPipeline) prepare(b *Batch, syncWAL bool, noSyncWait bool) (*memT
 	b.setSeqNum(p.env.logSeqNum.Add(base.SeqNum(n)) - base.SeqNum(n))
 
 	// Write the data to the WAL.
+	writeStartTime := time.Now()
 	mem, err := p.env.write(b, syncWG, syncErr)
+	b.commitStats.WALWriteDuration = time.Since(writeStartTime)
 
 	p.mu.Unlock()
 
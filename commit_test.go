@@ -18,7 +18,9 @@ import (
 	"github.com/cockroachdb/pebble/internal/invariants"
 	"github.com/cockroachdb/pebble/record"
 	"github.com/cockroachdb/pebble/vfs"
+	"github.com/cockroachdb/pebble/vfs/errorfs"
 	"github.com/prometheus/client_golang/prometheus"
+	prometheusgo "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
 )
@@ -354,6 +356,84 @@ func TestCommitPipelineLogDataSeqNum(t *testing.T) {
 	wg.Wait()
 }
 
+func histogramSampleCount(t *testing.T, h prometheus.Histogram) uint64 {
+	m := &prometheusgo.Metric{}
+	require.NoError(t, h.Write(m))
+	return m.Histogram.GetSampleCount()
+}
+
+func TestCommitMetrics(t *testing.T) {
+	d, err := Open("", &Options{FS: vfs.NewMem()})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	const n = 10
+	for i := 0; i < n; i++ {
+		require.NoError(t, d.Set([]byte(fmt.Sprint(i)), nil, NoSync))
+	}
+	for i := 0; i < n; i++ {
+		b := d.NewBatch()
+		require.NoError(t, b.Set([]byte(fmt.Sprint(i)), nil, nil))
+		require.NoError(t, d.ApplyNoSyncWait(b, Sync))
+		require.NoError(t, b.SyncWait())
+		require.NoError(t, b.Close())
+	}
+
+	m := d.Metrics()
+	require.Equal(t, uint64(2*n), histogramSampleCount(t, m.Commit.QueueWaitLatency))
+	require.Equal(t, uint64(2*n), histogramSampleCount(t, m.Commit.WALWriteLatency))
+	require.Equal(t, uint64(2*n), histogramSampleCount(t, m.Commit.MemTableApplyLatency))
+	// Only the commits that requested a sync are included.
+	require.Equal(t, uint64(n), histogramSampleCount(t, m.Commit.SyncWaitLatency))
+}
+
+func TestAdaptiveGroupCommit(t *testing.T) {
+	// Inject latency so that commits queue up behind WAL syncs.
+	fs := errorfs.Wrap(vfs.NewMem(), errorfs.RandomLatency(
+		nil, 200*time.Microsecond, 1 /* seed */, 0 /* no limit */))
+	opts := &Options{FS: fs}
+	opts.Experimental.AdaptiveGroupCommit.TargetLatency = 5 * time.Millisecond
+	opts.Experimental.AdaptiveGroupCommit.MinConcurrency = 2
+	d, err := Open("", opts)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	// The sync is only delayed when enough commits are waiting for it.
+	require.Equal(t, time.Duration(0), d.walMinSyncInterval())
+	d.commit.logSyncQSem <- struct{}{}
+	require.Equal(t, time.Duration(0), d.walMinSyncInterval())
+	d.commit.logSyncQSem <- struct{}{}
+	require.Equal(t, 5*time.Millisecond, d.walMinSyncInterval())
+	// A larger WALMinSyncInterval takes precedence.
+	d.opts.WALMinSyncInterval = func() time.Duration { return 10 * time.Millisecond }
+	require.Equal(t, 10*time.Millisecond, d.walMinSyncInterval())
+	d.opts.WALMinSyncInterval = nil
+	<-d.commit.logSyncQSem
+	<-d.commit.logSyncQSem
+
+	// Concurrent synced commits share syncs.
+	const writers, commits = 8, 20
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for i := 0; i < writers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < commits; j++ {
+				require.NoError(t, d.Set([]byte(fmt.Sprintf("%d-%d", i, j)), nil, Sync))
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Less(t, histogramSampleCount(t, d.Metrics().LogWriter.FsyncLatency), uint64(writers*commits))
+	for i := 0; i < writers; i++ {
+		for j := 0; j < commits; j++ {
+			_, closer, err := d.Get([]byte(fmt.Sprintf("%d-%d", i, j)))
+			require.NoError(t, err)
+			require.NoError(t, closer.Close())
+		}
+	}
+}
+
 func BenchmarkCommitPipeline(b *testing.B) {
 	for _, noSyncWait := range []bool{false, true} {
 		for _, parallelism := range []int{1, 2, 4, 8, 16, 32, 64, 128} {
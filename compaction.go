@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/pebble/objstorage/remote"
 	"github.com/cockroachdb/pebble/sstable"
 	"github.com/cockroachdb/pebble/vfs"
+	"github.com/cockroachdb/tokenbucket"
 )
 
 var errEmptyTable = errors.New("pebble: empty table")
@@ -137,6 +138,10 @@ const (
 	compactionKindRead
 	compactionKindTombstoneDensity
 	compactionKindRewrite
+	// compactionKindTableFormatRewrite denotes a compaction that rewrites a
+	// single table written in an older table format into the same level,
+	// using the newest table format supported by the format major version.
+	compactionKindTableFormatRewrite
 	compactionKindIngestedFlushable
 )
 
@@ -158,6 +163,8 @@ func (k compactionKind) String() string {
 		return "tombstone-density"
 	case compactionKindRewrite:
 		return "rewrite"
+	case compactionKindTableFormatRewrite:
+		return "table-format-rewrite"
 	case compactionKindIngestedFlushable:
 		return "ingested-flushable"
 	case compactionKindCopy:
@@ -179,6 +186,9 @@ type compaction struct {
 	// operation. In this case kind is compactionKindCopy or
 	// compactionKindRewrite.
 	isDownload bool
+	// rewriteOptions is set if this compaction was started as part of a
+	// RewriteTables call. In this case kind is compactionKindRewrite.
+	rewriteOptions *RewriteOptions
 
 	cmp       Compare
 	equal     Equal
@@ -365,17 +375,22 @@ func newCompaction(
 		iter := c.startLevel.files.Iter()
 		meta := iter.First()
 		isRemote := false
+		localTier := objstorage.LocalTierHot
 		// We should always be passed a provider, except in some unit tests.
 		if provider != nil {
-			isRemote = !objstorage.IsLocalTable(provider, meta.FileBacking.DiskFileNum)
+			objMeta, err := provider.Lookup(fileTypeTable, meta.FileBacking.DiskFileNum)
+			isRemote = err != nil || objMeta.IsRemote()
+			localTier = objMeta.Local.Tier
 		}
 		// Avoid a trivial move or copy if all of these are true, as rewriting a
 		// new file is better:
 		//
 		// 1) The source file is a virtual sstable
 		// 2) The existing file `meta` is on non-remote storage
-		// 3) The output level prefers shared storage
-		mustCopy := !isRemote && remote.ShouldCreateShared(opts.Experimental.CreateOnShared, c.outputLevel.level)
+		// 3) The output level prefers shared storage, or places local tables on
+		//    a different local tier than the one `meta` is on
+		mustCopy := !isRemote && (remote.ShouldCreateShared(opts.Experimental.CreateOnShared, c.outputLevel.level) ||
+			localTier != opts.localTier(c.outputLevel.level))
 		if mustCopy {
 			// If the source is virtual, it's best to just rewrite the file as all
 			// conditions in the above comment are met.
@@ -1742,9 +1757,19 @@ func (d *DB) maybeScheduleCompactionPicker(
 			d.mu.compact.manual = d.mu.compact.manual[1:]
 		}
 
+		for len(d.mu.compact.rewrites) > 0 && d.mu.compact.compactingCount < maxCompactions &&
+			d.tryScheduleRewriteCompaction(env) {
+		}
+
 		for !d.opts.DisableAutomaticCompactions && d.mu.compact.compactingCount < maxCompactions &&
 			d.tryScheduleAutoCompaction(env, pickFunc) {
 		}
+
+		// Table-format rewrites are only scheduled if no other automatic
+		// compaction was picked.
+		if !d.opts.DisableAutomaticCompactions && d.mu.compact.compactingCount < maxCompactions {
+			d.tryScheduleTableFormatRewrite(env)
+		}
 	}
 
 	for len(d.mu.compact.downloads) > 0 && d.mu.compact.downloadingCount < maxDownloads &&
@@ -1822,6 +1847,44 @@ func (d *DB) tryScheduleAutoCompaction(
 	return true
 }
 
+// tryScheduleTableFormatRewrite tries to start a compaction rewriting a table
+// written in an older table format, paced according to
+// Options.Experimental.TableFormatRewriteBytesPerSecond. If the rewrite must
+// wait for the rate limit, a timer is set to retry scheduling once it may
+// proceed.
+//
+// Requires d.mu to be held.
+func (d *DB) tryScheduleTableFormatRewrite(env compactionEnv) {
+	rw := &d.mu.compact.tableFormatRewrite
+	if d.opts.Experimental.TableFormatRewriteBytesPerSecond <= 0 || rw.retryTimer != nil {
+		return
+	}
+	// Rewriting a table can only upgrade its format once the format major
+	// version permits writing the newest table format.
+	if d.FormatMajorVersion().MaxTableFormat() != sstable.TableFormatMax {
+		return
+	}
+	env.inProgressCompactions = d.getInProgressCompactionInfoLocked(nil)
+	pc := d.mu.versions.picker.pickTableFormatRewriteCompaction(env)
+	if pc == nil {
+		return
+	}
+	fulfilled, tryAgainAfter := rw.tokens.TryToFulfill(tokenbucket.Tokens(pc.startLevel.files.SizeSum()))
+	if !fulfilled {
+		rw.retryTimer = time.AfterFunc(tryAgainAfter, func() {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			d.mu.compact.tableFormatRewrite.retryTimer = nil
+			d.maybeScheduleCompaction()
+		})
+		return
+	}
+	c := newCompaction(pc, d.opts, d.timeNow(), d.ObjProvider())
+	d.mu.compact.compactingCount++
+	d.addInProgressCompaction(c)
+	go d.compact(c, nil)
+}
+
 // deleteCompactionHintType indicates whether the deleteCompactionHint was
 // generated from a span containing a range del (point key only), a range key
 // delete (range key only), or both a point and range key.
@@ -2188,19 +2251,22 @@ func (d *DB) runCopyCompaction(
 	if err != nil {
 		return nil, compact.Stats{}, err
 	}
+	toShared := remote.ShouldCreateShared(d.opts.Experimental.CreateOnShared, c.outputLevel.level)
+	outputTier := d.opts.localTier(c.outputLevel.level)
 	if !objMeta.IsExternal() {
-		if objMeta.IsRemote() || !remote.ShouldCreateShared(d.opts.Experimental.CreateOnShared, c.outputLevel.level) {
-			panic("pebble: scheduled a copy compaction that is not actually moving files to shared storage")
+		if objMeta.IsRemote() || (!toShared && objMeta.Local.Tier == outputTier) {
+			panic("pebble: scheduled a copy compaction that is not actually moving files to shared storage or another local tier")
 		}
 		// Note that based on logic in the compaction picker, we're guaranteed
 		// inputMeta.Virtual is false.
 		if inputMeta.Virtual {
-			panic(errors.AssertionFailedf("cannot do a copy compaction of a virtual sstable across local/remote storage"))
+			panic(errors.AssertionFailedf("cannot do a copy compaction of a virtual sstable across storage tiers"))
 		}
 	}
 
 	// We are in the relatively more complex case where we need to copy this
-	// file to remote storage. Drop the db mutex while we do the copy
+	// file to remote storage or another local tier. Drop the db mutex while we
+	// do the copy
 	//
 	// To ease up cleanup of the local file and tracking of refs, we create
 	// a new FileNum. This has the potential of making the block cache less
@@ -2228,7 +2294,8 @@ func (d *DB) runCopyCompaction(
 		// We will update this size later after we produce the new backing file.
 		newMeta.InitProviderBacking(base.DiskFileNum(newMeta.FileNum), inputMeta.FileBacking.Size)
 	} else {
-		// local -> shared copy. New file is guaranteed to not be virtual.
+		// local -> shared or local -> local copy. New file is guaranteed to not
+		// be virtual.
 		newMeta.InitPhysicalBacking()
 	}
 
@@ -2269,7 +2336,8 @@ func (d *DB) runCopyCompaction(
 		w, _, err := d.objProvider.Create(
 			ctx, fileTypeTable, newMeta.FileBacking.DiskFileNum,
 			objstorage.CreateOptions{
-				PreferSharedStorage: remote.ShouldCreateShared(d.opts.Experimental.CreateOnShared, c.outputLevel.level),
+				PreferSharedStorage: toShared,
+				LocalTier:           outputTier,
 			},
 		)
 		if err != nil {
@@ -2319,9 +2387,9 @@ func (d *DB) runCopyCompaction(
 		newMeta.FileBacking.Size = wrote
 		newMeta.Size = wrote
 	} else {
-		_, err := d.objProvider.LinkOrCopyFromLocal(context.TODO(), d.opts.FS,
+		_, err := d.objProvider.LinkOrCopyFromLocal(context.TODO(), d.opts.localTierFS(objMeta.Local.Tier),
 			d.objProvider.Path(objMeta), fileTypeTable, newMeta.FileBacking.DiskFileNum,
-			objstorage.CreateOptions{PreferSharedStorage: true})
+			objstorage.CreateOptions{PreferSharedStorage: toShared, LocalTier: outputTier})
 		if err != nil {
 			return nil, compact.Stats{}, err
 		}
@@ -2449,6 +2517,9 @@ func (d *DB) runCompaction(
 		(d.opts.Experimental.EnableValueBlocks == nil || !d.opts.Experimental.EnableValueBlocks()) {
 		tableFormat = sstable.TableFormatPebblev2
 	}
+	if c.rewriteOptions != nil && c.rewriteOptions.TableFormat != sstable.TableFormatUnspecified {
+		tableFormat = c.rewriteOptions.TableFormat
+	}
 
 	// Release the d.mu lock while doing I/O.
 	// Note the unusual order: Unlock and then Lock.
@@ -2538,6 +2609,9 @@ func (d *DB) compactAndWrite(
 		}
 		// Create a new table.
 		writerOpts := d.opts.MakeWriterOptions(c.outputLevel.level, tableFormat)
+		if c.rewriteOptions != nil {
+			c.rewriteOptions.apply(&writerOpts)
+		}
 		objMeta, tw, cpuWorkHandle, err := d.newCompactionOutput(jobID, c, writerOpts)
 		if err != nil {
 			return runner.Finish().WithError(err)
@@ -2617,7 +2691,7 @@ func (c *compaction) makeVersionEdit(result compact.Result) (*versionEdit, error
 		// If the file didn't contain any range deletions, we can fill its
 		// table stats now, avoiding unnecessarily loading the table later.
 		maybeSetStatsFromProperties(
-			fileMeta.PhysicalMeta(), &t.WriterMeta.Properties,
+			fileMeta.PhysicalMeta(), &t.WriterMeta.Properties, t.WriterMeta.TableFormat,
 		)
 
 		if t.WriterMeta.HasPointKeys {
@@ -2702,6 +2776,7 @@ func (d *DB) newCompactionOutput(
 	createOpts := objstorage.CreateOptions{
 		PreferSharedStorage: remote.ShouldCreateShared(d.opts.Experimental.CreateOnShared, c.outputLevel.level),
 		WriteCategory:       writeCategory,
+		LocalTier:           d.opts.localTier(c.outputLevel.level),
 	}
 	writable, objMeta, err := d.objProvider.Create(ctx, fileTypeTable, diskFileNum, createOpts)
 	if err != nil {
@@ -2739,10 +2814,8 @@ func (d *DB) newCompactionOutput(
 			(cpuWorkHandle.Permitted() || d.opts.Experimental.ForceWriterParallelism)
 
 	// TODO(jackson): Make the compaction body generic over the RawWriter type,
-	// so that we don't need to pay the cost of dynamic dispatch. For now, we
-	// type assert into the only concrete RawWriter type we see (until colblk is
-	// integrated).
-	tw := sstable.NewRawWriter(writable, writerOpts).(*sstable.RawRowWriter)
+	// so that we don't need to pay the cost of dynamic dispatch.
+	tw := sstable.NewRawWriter(writable, writerOpts)
 	return objMeta, tw, cpuWorkHandle, nil
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/pebble/internal/humanize"
 	"github.com/cockroachdb/pebble/internal/invariants"
 	"github.com/cockroachdb/pebble/internal/manifest"
+	"github.com/cockroachdb/pebble/sstable"
 )
 
 // The minimum count for an intra-L0 compaction. This matches the RocksDB
@@ -47,6 +48,7 @@ type compactionPicker interface {
 	pickAuto(env compactionEnv) (pc *pickedCompaction)
 	pickElisionOnlyCompaction(env compactionEnv) (pc *pickedCompaction)
 	pickRewriteCompaction(env compactionEnv) (pc *pickedCompaction)
+	pickTableFormatRewriteCompaction(env compactionEnv) (pc *pickedCompaction)
 	pickReadTriggeredCompaction(env compactionEnv) (pc *pickedCompaction)
 	forceBaseLevel1()
 }
@@ -1422,6 +1424,27 @@ var markedForCompactionAnnotator = &manifest.Annotator[fileMetadata]{
 	},
 }
 
+// outdatedTableFormatAnnotator is a manifest.Annotator that annotates B-Tree
+// nodes with the *fileMetadata of a file written in a table format older than
+// sstable.TableFormatMax. If multiple files meet the criteria, it chooses
+// whichever file has the lowest LargestSeqNum.
+var outdatedTableFormatAnnotator = &manifest.Annotator[fileMetadata]{
+	Aggregator: manifest.PickFileAggregator{
+		Filter: func(f *fileMetadata) (eligible bool, cacheOK bool) {
+			if f.IsCompacting() {
+				return false, true
+			}
+			if !f.StatsValid() {
+				return false, false
+			}
+			return f.Stats.TableFormat < sstable.TableFormatMax, true
+		},
+		Compare: func(f1 *fileMetadata, f2 *fileMetadata) bool {
+			return f1.LargestSeqNum < f2.LargestSeqNum
+		},
+	},
+}
+
 // pickedCompactionFromCandidateFile creates a pickedCompaction from a *fileMetadata
 // with various checks to ensure that the file still exists in the expected level
 // and isn't already being compacted.
@@ -1506,6 +1529,28 @@ func (p *compactionPickerByScore) pickRewriteCompaction(env compactionEnv) (pc *
 	return nil
 }
 
+// pickTableFormatRewriteCompaction attempts to construct a compaction that
+// rewrites a table written in a table format older than
+// sstable.TableFormatMax. The table is rewritten into its own level. Tables in
+// L0 are not considered, since they're expected to be compacted into Lbase
+// soon.
+func (p *compactionPickerByScore) pickTableFormatRewriteCompaction(
+	env compactionEnv,
+) (pc *pickedCompaction) {
+	for l := numLevels - 1; l > 0; l-- {
+		candidate := outdatedTableFormatAnnotator.LevelAnnotation(p.vers.Levels[l])
+		if candidate == nil {
+			// Try the next level.
+			continue
+		}
+		pc := p.pickedCompactionFromCandidateFile(candidate, env, l, l, compactionKindTableFormatRewrite)
+		if pc != nil {
+			return pc
+		}
+	}
+	return nil
+}
+
 func (p *compactionPickerByScore) initTombstoneDensityAnnotator(opts *Options) {
 	p.tombstoneDensityAnnotator = &manifest.Annotator[fileMetadata]{
 		Aggregator: manifest.PickFileAggregator{
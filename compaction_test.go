@@ -105,6 +105,12 @@ func (p *compactionPickerForTesting) pickRewriteCompaction(
 	return nil
 }
 
+func (p *compactionPickerForTesting) pickTableFormatRewriteCompaction(
+	env compactionEnv,
+) (pc *pickedCompaction) {
+	return nil
+}
+
 func (p *compactionPickerForTesting) pickReadTriggeredCompaction(
 	env compactionEnv,
 ) (pc *pickedCompaction) {
@@ -1254,10 +1260,20 @@ func TestManualCompaction(t *testing.T) {
 		{
 			testData:   "testdata/manual_compaction_file_boundaries_delsized",
 			minVersion: FormatDeleteSizedAndObsolete,
+			maxVersion: FormatWALCompression,
 		},
 		{
 			testData:   "testdata/manual_compaction_set_with_del_sstable_Pebblev4",
 			minVersion: FormatDeleteSizedAndObsolete,
+			maxVersion: FormatWALCompression,
+		},
+		{
+			testData:   "testdata/manual_compaction_file_boundaries_delsized_Pebblev5",
+			minVersion: FormatColumnarBlocks,
+		},
+		{
+			testData:   "testdata/manual_compaction_set_with_del_sstable_Pebblev5",
+			minVersion: FormatColumnarBlocks,
 		},
 		{
 			testData: "testdata/manual_compaction_multilevel",
@@ -2828,3 +2844,96 @@ func TestCompactionErrorStats(t *testing.T) {
 	d.mu.Unlock()
 	require.NoError(t, d.Close())
 }
+
+// TestCompactionColdTier verifies that compactions place tables on the cold
+// tier according to Options.Experimental.ColdTier.StartLevel, including when a
+// table is moved across the tier boundary.
+func TestCompactionColdTier(t *testing.T) {
+	fs := vfs.NewMem()
+	coldFS := vfs.NewMem()
+	opts := &Options{
+		FS:                          fs,
+		DisableAutomaticCompactions: true,
+	}
+	opts.Experimental.ColdTier.FS = coldFS
+	opts.Experimental.ColdTier.Dir = "cold"
+	opts.Experimental.ColdTier.StartLevel = 5
+	d, err := Open("", opts)
+	require.NoError(t, err)
+
+	listTables := func(fs vfs.FS, dir string) []string {
+		ls, err := fs.List(dir)
+		require.NoError(t, err)
+		var tables []string
+		for _, name := range ls {
+			if ft, _, ok := base.ParseFilename(fs, name); ok && ft == fileTypeTable {
+				tables = append(tables, name)
+			}
+		}
+		return tables
+	}
+
+	require.NoError(t, d.Set([]byte("a"), []byte("1"), nil))
+	require.NoError(t, d.Set([]byte("b"), []byte("2"), nil))
+	require.NoError(t, d.Flush())
+	require.Len(t, listTables(fs, ""), 1)
+	require.Empty(t, listTables(coldFS, "cold"))
+	require.Zero(t, d.Metrics().Table.Local.ColdTierLiveSize)
+
+	// Moving the single L0 table to the bottommost level crosses the tier
+	// boundary, so the table is copied to the cold tier.
+	require.NoError(t, d.Compact([]byte("a"), []byte("c"), false /* parallelize */))
+	d.TestOnlyWaitForCleaning()
+	require.Empty(t, listTables(fs, ""))
+	require.Len(t, listTables(coldFS, "cold"), 1)
+	m := d.Metrics()
+	require.Equal(t, 1, int(m.Levels[numLevels-1].NumFiles))
+	require.NotZero(t, m.Table.Local.ColdTierLiveSize)
+	require.Equal(t, m.Table.Local.LiveSize, m.Table.Local.ColdTierLiveSize)
+
+	// A checkpoint contains a copy of the cold tier tables in its directory.
+	require.NoError(t, d.Checkpoint("checkpoint"))
+	require.Len(t, listTables(fs, "checkpoint"), 1)
+	require.NoError(t, d.Close())
+
+	for _, o := range []*Options{opts, {FS: fs}} {
+		dir := ""
+		if o.Experimental.ColdTier.StartLevel == 0 {
+			dir = "checkpoint"
+		}
+		d, err = Open(dir, o)
+		require.NoError(t, err)
+		v, closer, err := d.Get([]byte("b"))
+		require.NoError(t, err)
+		require.Equal(t, "2", string(v))
+		require.NoError(t, closer.Close())
+		require.NoError(t, d.Close())
+	}
+
+	// The DB can't be opened without the cold tier holding its table.
+	_, err = Open("", &Options{FS: fs})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cold tier not configured")
+
+	// With a StartLevel of zero, the table remains readable on the cold tier,
+	// and compacting it moves its data to the hot tier and deletes it from
+	// the cold tier.
+	opts.Experimental.ColdTier.StartLevel = 0
+	d, err = Open("", opts)
+	require.NoError(t, err)
+	require.NotZero(t, d.Metrics().Table.Local.ColdTierLiveSize)
+	require.NoError(t, d.Set([]byte("a"), []byte("3"), nil))
+	require.NoError(t, d.Flush())
+	require.NoError(t, d.Compact([]byte("a"), []byte("c"), false /* parallelize */))
+	d.TestOnlyWaitForCleaning()
+	require.Len(t, listTables(fs, ""), 1)
+	require.Empty(t, listTables(coldFS, "cold"))
+	require.Zero(t, d.Metrics().Table.Local.ColdTierLiveSize)
+	for k, want := range map[string]string{"a": "3", "b": "2"} {
+		v, closer, err := d.Get([]byte(k))
+		require.NoError(t, err)
+		require.Equal(t, want, string(v))
+		require.NoError(t, closer.Close())
+	}
+	require.NoError(t, d.Close())
+}
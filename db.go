@@ -307,6 +307,10 @@ type DB struct {
 
 	commit *commitPipeline
 
+	// hotRanges tracks sampled reads when
+	// Options.Experimental.HotRangeSamplingPeriod is positive. Nil otherwise.
+	hotRanges *hotRangeTracker
+
 	// readState provides access to the state needed for reading without needing
 	// to acquire DB.mu.
 	readState struct {
@@ -433,6 +437,8 @@ type DB struct {
 			// downloads is the list of pending download tasks. The next download to
 			// perform is at the start of the list. New entries are added to the end.
 			downloads []*downloadSpanTask
+			// rewrites is the list of tasks of in-progress RewriteTables calls.
+			rewrites []*rewriteTask
 			// inProgress is the set of in-progress flushes and compactions.
 			// It's used in the calculation of some metrics and to initialize L0
 			// sublevels' state. Some of the compactions contained within this
@@ -456,6 +462,16 @@ type DB struct {
 			// The idle start time for the flush "loop", i.e., when the flushing
 			// bool above transitions to false.
 			noOngoingFlushStartTime time.Time
+
+			// tableFormatRewrite holds the state used to pace table-format-rewrite
+			// compactions (see Options.Experimental.TableFormatRewriteBytesPerSecond).
+			tableFormatRewrite struct {
+				// tokens is charged the size of each rewritten table.
+				tokens tokenbucket.TokenBucket
+				// retryTimer is non-nil while a timer is pending to retry
+				// scheduling a rewrite once enough tokens are available.
+				retryTimer *time.Timer
+			}
 		}
 
 		// Non-zero when file cleaning is disabled. The disabled count acts as a
@@ -503,6 +519,15 @@ type DB struct {
 			validating bool
 		}
 
+		scrub struct {
+			// cond is a condition variable used to signal the completion of a
+			// scrub.
+			cond sync.Cond
+			// scrubbing is set to true while a scrub is running. At most one
+			// scrub runs at a time.
+			scrubbing bool
+		}
+
 		// annotators contains various instances of manifest.Annotator which
 		// should be protected from concurrent access.
 		annotators struct {
@@ -593,6 +618,10 @@ func (d *DB) getInternal(key []byte, b *Batch, s *Snapshot) ([]byte, io.Closer,
 		l0:      readState.current.L0SublevelFiles,
 		version: readState.current,
 	}
+	if d.hotRanges != nil && d.hotRanges.sampleGet() {
+		get.hotRanges = d.hotRanges
+		d.hotRanges.recordKey(key)
+	}
 
 	// Strip off memtables which cannot possibly contain the seqNum being read
 	// at.
@@ -1400,6 +1429,10 @@ func (i *Iterator) constructPointIter(
 		return
 	}
 	internalOpts := internalIterOpts{stats: &i.stats.InternalStats}
+	if i.opts.BypassCache {
+		i.bufferPool.SetMaxRetainedBytes(bypassCacheBufferPoolBytes)
+		internalOpts.bufferPool = &i.bufferPool
+	}
 	if i.opts.RangeKeyMasking.Filter != nil {
 		internalOpts.boundLimitedFilter = &i.rangeKeyMasking
 	}
@@ -1650,6 +1683,13 @@ func (d *DB) Close() error {
 	for d.mu.tableValidation.validating {
 		d.mu.tableValidation.cond.Wait()
 	}
+	for d.mu.scrub.scrubbing {
+		d.mu.scrub.cond.Wait()
+	}
+	if t := d.mu.compact.tableFormatRewrite.retryTimer; t != nil {
+		t.Stop()
+		d.mu.compact.tableFormatRewrite.retryTimer = nil
+	}
 
 	var err error
 	if n := len(d.mu.compact.inProgress); n > 0 {
@@ -2014,6 +2054,10 @@ func (d *DB) Metrics() *Metrics {
 	d.mu.versions.logUnlock()
 
 	metrics.LogWriter.FsyncLatency = d.mu.log.metrics.fsyncLatency
+	metrics.Commit.QueueWaitLatency = d.commit.metrics.queueWaitLatency
+	metrics.Commit.WALWriteLatency = d.commit.metrics.walWriteLatency
+	metrics.Commit.SyncWaitLatency = d.commit.metrics.syncWaitLatency
+	metrics.Commit.MemTableApplyLatency = d.commit.metrics.memTableApplyLatency
 	if err := metrics.LogWriter.Merge(&d.mu.log.metrics.LogWriterMetrics); err != nil {
 		d.opts.Logger.Errorf("metrics error: %s", err)
 	}
@@ -2021,6 +2065,9 @@ func (d *DB) Metrics() *Metrics {
 	if d.mu.compact.flushing {
 		metrics.Flush.NumInProgress = 1
 	}
+	// Tables written in a table format older than the newest format permitted
+	// by the format major version are pending a table-format rewrite.
+	newestTableFormat := d.FormatMajorVersion().MaxTableFormat()
 	for i := 0; i < numLevels; i++ {
 		metrics.Levels[i].Additional.ValueBlocksSize = *valueBlockSizeAnnotator.LevelAnnotation(vers.Levels[i])
 		compressionTypes := compressionTypeAnnotator.LevelAnnotation(vers.Levels[i])
@@ -2028,16 +2075,33 @@ func (d *DB) Metrics() *Metrics {
 		metrics.Table.CompressedCountSnappy += int64(compressionTypes.snappy)
 		metrics.Table.CompressedCountZstd += int64(compressionTypes.zstd)
 		metrics.Table.CompressedCountNone += int64(compressionTypes.none)
+		formats := tableFormatAnnotator.LevelAnnotation(vers.Levels[i])
+		for f := range formats[:newestTableFormat] {
+			metrics.Compact.TableFormatRewritePendingCount += int64(formats[f].count)
+			metrics.Compact.TableFormatRewritePendingSize += formats[f].size
+		}
+	}
+	// The cold tier live size requires an object provider lookup per live
+	// table, so it's computed after releasing the mutex.
+	coldTierConfigured := d.opts.coldTierConfigured()
+	if coldTierConfigured {
+		vers.Ref()
 	}
 
 	d.mu.Unlock()
 
+	if coldTierConfigured {
+		metrics.Table.Local.ColdTierLiveSize = d.coldTierLiveSize(vers)
+		vers.Unref()
+	}
+
 	metrics.BlockCache = d.opts.Cache.Metrics()
 	metrics.TableCache, metrics.Filter = d.tableCache.metrics()
 	metrics.TableIters = int64(d.tableCache.iterCount())
 	metrics.CategoryStats = d.tableCache.dbOpts.sstStatsCollector.GetStats()
 
 	metrics.SecondaryCacheMetrics = d.objProvider.Metrics()
+	metrics.LocalSecondaryCacheMetrics = d.objProvider.LocalCacheMetrics()
 
 	metrics.Uptime = d.timeNow().Sub(d.openedAt)
 
@@ -2046,6 +2110,28 @@ func (d *DB) Metrics() *Metrics {
 	return metrics
 }
 
+// coldTierLiveSize returns the number of bytes in the live local tables (or
+// backing tables) of the given version that are stored on the cold tier. The
+// caller must hold a reference on vers; d.mu need not be held.
+func (d *DB) coldTierLiveSize(vers *version) uint64 {
+	var size uint64
+	seen := make(map[base.DiskFileNum]struct{})
+	for level := range vers.Levels {
+		iter := vers.Levels[level].Iter()
+		for f := iter.First(); f != nil; f = iter.Next() {
+			if _, ok := seen[f.FileBacking.DiskFileNum]; ok {
+				continue
+			}
+			seen[f.FileBacking.DiskFileNum] = struct{}{}
+			meta, err := d.objProvider.Lookup(fileTypeTable, f.FileBacking.DiskFileNum)
+			if err == nil && !meta.IsRemote() && meta.Local.Tier == objstorage.LocalTierCold {
+				size += f.FileBacking.Size
+			}
+		}
+	}
+	return size
+}
+
 // sstablesOptions hold the optional parameters to retrieve TableInfo for all sstables.
 type sstablesOptions struct {
 	// set to true will return the sstable properties in TableInfo
@@ -2325,10 +2411,39 @@ func (d *DB) walPreallocateSize() int {
 	return int(size)
 }
 
+// walMinSyncInterval returns the minimum duration between WAL syncs when
+// adaptive group commit is enabled (see Options.Experimental.AdaptiveGroupCommit).
+// It is consulted by the LogWriter after each sync. If enough commits are
+// already waiting for the next sync, the sync is delayed by up to the target
+// latency so that more commits can share it.
+func (d *DB) walMinSyncInterval() time.Duration {
+	var interval time.Duration
+	if d.opts.WALMinSyncInterval != nil {
+		interval = d.opts.WALMinSyncInterval()
+	}
+	agc := &d.opts.Experimental.AdaptiveGroupCommit
+	// Commits that requested a sync hold a unit of logSyncQSem until the sync
+	// completes.
+	if agc.TargetLatency > interval && len(d.commit.logSyncQSem) >= agc.MinConcurrency {
+		return agc.TargetLatency
+	}
+	return interval
+}
+
+// walCompression returns the compression to use for a newly created WAL. WAL
+// chunks are only compressed once the format major version guarantees that
+// the WAL can be read by the versions of Pebble able to open the DB.
+func (d *DB) walCompression() Compression {
+	if d.FormatMajorVersion() < FormatWALCompression {
+		return NoCompression
+	}
+	return d.opts.Experimental.WALCompression
+}
+
 func (d *DB) newMemTable(
 	logNum base.DiskFileNum, logSeqNum base.SeqNum, minSize uint64,
 ) (*memTable, *flushableEntry) {
-	targetSize := minSize + uint64(memTableEmptySize)
+	targetSize := minSize + uint64(memTableEmptySize(d.opts.Experimental.MemTableKind))
 	// The targetSize should be less than MemTableSize, because any batch >=
 	// MemTableSize/2 should be treated as a large flushable batch.
 	if targetSize > d.opts.MemTableSize {
@@ -2865,6 +2980,9 @@ func (d *DB) ScanStatistics(
 			KeyTypes:   IterKeyTypePointsAndRanges,
 			LowerBound: lower,
 			UpperBound: upper,
+			// The scan reads every block in the span once, so avoid evicting
+			// the working set from the block cache.
+			BypassCache: true,
 		},
 		rateLimitFunc: rateLimitFunc,
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/pebble/internal/humanize"
 	"github.com/cockroachdb/pebble/internal/invariants"
 	"github.com/cockroachdb/pebble/internal/manifest"
+	"github.com/cockroachdb/pebble/sstable"
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/cockroachdb/redact"
 )
@@ -442,6 +443,53 @@ func (i TableStatsInfo) SafeFormat(w redact.SafePrinter, _ rune) {
 	w.Printf("[JOB %d] all initial table stats loaded", redact.Safe(i.JobID))
 }
 
+// DataCorruptionInfo contains information about corruption found in an
+// sstable, e.g. by a scrub (see DB.Scrub).
+type DataCorruptionInfo struct {
+	// JobID is the ID of the job which found the corruption.
+	JobID int
+	// Path of the object containing the corruption. For a virtual sstable, this
+	// is the path of its backing sstable.
+	Path string
+	// IsRemote is true if the object is on remote storage. The corruption may
+	// be in a local cached copy of the object.
+	IsRemote bool
+	// Level is the level of the table in the LSM.
+	Level int
+	// Meta is the metadata of the table.
+	Meta *fileMetadata
+	// Blocks contains the blocks of the object whose checksums do not match
+	// their contents. It is empty if the corruption is not confined to a
+	// block, e.g. if the keys of the table are out of order.
+	Blocks []sstable.CorruptBlock
+	// Details contains the first error describing the corruption.
+	Details error
+}
+
+func (i DataCorruptionInfo) String() string {
+	return redact.StringWithoutMarkers(i)
+}
+
+// SafeFormat implements redact.SafeFormatter.
+func (i DataCorruptionInfo) SafeFormat(w redact.SafePrinter, _ rune) {
+	w.Printf("[JOB %d] corruption in L%d table %s (%s", redact.Safe(i.JobID),
+		redact.Safe(i.Level), i.Meta.FileNum, i.Path)
+	if i.IsRemote {
+		w.Printf(", remote")
+	}
+	w.Printf(")")
+	for j, b := range i.Blocks {
+		if j == 0 {
+			w.Printf(": ")
+		} else {
+			w.Printf(", ")
+		}
+		w.Printf("%s block [%d, %d)", redact.Safe(b.Name),
+			redact.Safe(b.Handle.Offset), redact.Safe(b.Handle.Offset+b.Handle.Length))
+	}
+	w.Printf("; %s", i.Details)
+}
+
 // TableValidatedInfo contains information on the result of a validation run
 // on an sstable.
 type TableValidatedInfo struct {
@@ -548,6 +596,10 @@ type EventListener struct {
 	// has been installed.
 	CompactionEnd func(CompactionInfo)
 
+	// DataCorruption is invoked when corruption is found in an sstable,
+	// e.g. by a scrub (see DB.Scrub) or when validating an ingested sstable.
+	DataCorruption func(DataCorruptionInfo)
+
 	// DiskSlow is invoked after a disk write operation on a file created with a
 	// disk health checking vfs.FS (see vfs.DefaultWithDiskHealthChecks) is
 	// observed to exceed the specified disk slowness threshold duration. DiskSlow
@@ -634,6 +686,15 @@ func (l *EventListener) EnsureDefaults(logger Logger) {
 	if l.CompactionEnd == nil {
 		l.CompactionEnd = func(info CompactionInfo) {}
 	}
+	if l.DataCorruption == nil {
+		if logger != nil {
+			l.DataCorruption = func(info DataCorruptionInfo) {
+				logger.Errorf("%s", info)
+			}
+		} else {
+			l.DataCorruption = func(info DataCorruptionInfo) {}
+		}
+	}
 	if l.DiskSlow == nil {
 		l.DiskSlow = func(info DiskSlowInfo) {}
 	}
@@ -704,6 +765,9 @@ func MakeLoggingEventListener(logger Logger) EventListener {
 		CompactionEnd: func(info CompactionInfo) {
 			logger.Infof("%s", info)
 		},
+		DataCorruption: func(info DataCorruptionInfo) {
+			logger.Errorf("%s", info)
+		},
 		DiskSlow: func(info DiskSlowInfo) {
 			logger.Infof("%s", info)
 		},
@@ -775,6 +839,10 @@ func TeeEventListener(a, b EventListener) EventListener {
 			a.CompactionEnd(info)
 			b.CompactionEnd(info)
 		},
+		DataCorruption: func(info DataCorruptionInfo) {
+			a.DataCorruption(info)
+			b.DataCorruption(info)
+		},
 		DiskSlow: func(info DiskSlowInfo) {
 			a.DiskSlow(info)
 			b.DiskSlow(info)
@@ -191,6 +191,20 @@ const (
 	// as flushable ingested sstables.
 	FormatFlushableIngestExcises
 
+	// FormatWALCompression is a format major version that allows the chunks of
+	// write-ahead logs to be compressed (see Options.Experimental.WALCompression).
+	// Earlier versions of Pebble are unable to read compressed WAL chunks.
+	FormatWALCompression
+
+	// FormatColumnarBlocks is a format major version enabling use of the
+	// TableFormatPebblev5 table format, which encodes data, index and keyspan
+	// blocks in a columnar format. New sstables are written in the columnar
+	// format once the DB's format major version is ratcheted to
+	// FormatColumnarBlocks. Existing sstables are rewritten in the columnar
+	// format by compactions; see
+	// Options.Experimental.TableFormatRewriteBytesPerSecond.
+	FormatColumnarBlocks
+
 	// TODO(msbutler): add major version for synthetic suffixes
 
 	// -- Add new versions here --
@@ -229,8 +243,10 @@ func (v FormatMajorVersion) MaxTableFormat() sstable.TableFormat {
 	case FormatDefault, FormatFlushableIngest, FormatPrePebblev1MarkedCompacted:
 		return sstable.TableFormatPebblev3
 	case FormatDeleteSizedAndObsolete, FormatVirtualSSTables, FormatSyntheticPrefixSuffix,
-		FormatFlushableIngestExcises:
+		FormatFlushableIngestExcises, FormatWALCompression:
 		return sstable.TableFormatPebblev4
+	case FormatColumnarBlocks:
+		return sstable.TableFormatPebblev5
 	default:
 		panic(fmt.Sprintf("pebble: unsupported format major version: %s", v))
 	}
@@ -242,7 +258,7 @@ func (v FormatMajorVersion) MinTableFormat() sstable.TableFormat {
 	switch v {
 	case FormatDefault, FormatFlushableIngest, FormatPrePebblev1MarkedCompacted,
 		FormatDeleteSizedAndObsolete, FormatVirtualSSTables, FormatSyntheticPrefixSuffix,
-		FormatFlushableIngestExcises:
+		FormatFlushableIngestExcises, FormatWALCompression, FormatColumnarBlocks:
 		return sstable.TableFormatPebblev1
 	default:
 		panic(fmt.Sprintf("pebble: unsupported format major version: %s", v))
@@ -282,6 +298,17 @@ var formatMajorVersionMigrations = map[FormatMajorVersion]func(*DB) error{
 	FormatFlushableIngestExcises: func(d *DB) error {
 		return d.finalizeFormatVersUpgrade(FormatFlushableIngestExcises)
 	},
+	FormatWALCompression: func(d *DB) error {
+		return d.finalizeFormatVersUpgrade(FormatWALCompression)
+	},
+	FormatColumnarBlocks: func(d *DB) error {
+		if err := d.finalizeFormatVersUpgrade(FormatColumnarBlocks); err != nil {
+			return err
+		}
+		// Existing tables may now be rewritten in the columnar format.
+		d.maybeScheduleCompaction()
+		return nil
+	},
 }
 
 const formatVersionMarkerName = `format-version`
@@ -455,10 +482,6 @@ func (d *DB) compactMarkedFilesLocked() error {
 // level.
 type findFilesFunc func(v *version) (found bool, files [numLevels][]*fileMetadata, _ error)
 
-// This method is not used currently, but it will be useful the next time we need
-// to mark files for compaction.
-var _ = (*DB)(nil).markFilesLocked
-
 // markFilesLocked durably marks the files that match the given findFilesFunc for
 // compaction.
 func (d *DB) markFilesLocked(findFn findFilesFunc) error {
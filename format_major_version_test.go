@@ -7,6 +7,7 @@ package pebble
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/pebble/sstable"
 	"github.com/cockroachdb/pebble/vfs"
@@ -25,11 +26,13 @@ func TestFormatMajorVersionStableValues(t *testing.T) {
 	require.Equal(t, FormatVirtualSSTables, FormatMajorVersion(16))
 	require.Equal(t, FormatSyntheticPrefixSuffix, FormatMajorVersion(17))
 	require.Equal(t, FormatFlushableIngestExcises, FormatMajorVersion(18))
+	require.Equal(t, FormatWALCompression, FormatMajorVersion(19))
+	require.Equal(t, FormatColumnarBlocks, FormatMajorVersion(20))
 
 	// When we add a new version, we should add a check for the new version in
 	// addition to updating these expected values.
-	require.Equal(t, FormatNewest, FormatMajorVersion(18))
-	require.Equal(t, internalFormatNewest, FormatMajorVersion(18))
+	require.Equal(t, FormatNewest, FormatMajorVersion(20))
+	require.Equal(t, internalFormatNewest, FormatMajorVersion(20))
 }
 
 func TestFormatMajorVersion_MigrationDefined(t *testing.T) {
@@ -56,6 +59,10 @@ func TestRatchetFormat(t *testing.T) {
 	require.Equal(t, FormatSyntheticPrefixSuffix, d.FormatMajorVersion())
 	require.NoError(t, d.RatchetFormatMajorVersion(FormatFlushableIngestExcises))
 	require.Equal(t, FormatFlushableIngestExcises, d.FormatMajorVersion())
+	require.NoError(t, d.RatchetFormatMajorVersion(FormatWALCompression))
+	require.Equal(t, FormatWALCompression, d.FormatMajorVersion())
+	require.NoError(t, d.RatchetFormatMajorVersion(FormatColumnarBlocks))
+	require.Equal(t, FormatColumnarBlocks, d.FormatMajorVersion())
 
 	require.NoError(t, d.Close())
 
@@ -207,6 +214,8 @@ func TestFormatMajorVersions_TableFormat(t *testing.T) {
 		FormatVirtualSSTables:            {sstable.TableFormatPebblev1, sstable.TableFormatPebblev4},
 		FormatSyntheticPrefixSuffix:      {sstable.TableFormatPebblev1, sstable.TableFormatPebblev4},
 		FormatFlushableIngestExcises:     {sstable.TableFormatPebblev1, sstable.TableFormatPebblev4},
+		FormatWALCompression:             {sstable.TableFormatPebblev1, sstable.TableFormatPebblev4},
+		FormatColumnarBlocks:             {sstable.TableFormatPebblev1, sstable.TableFormatPebblev5},
 	}
 
 	// Valid versions.
@@ -221,3 +230,86 @@ func TestFormatMajorVersions_TableFormat(t *testing.T) {
 	require.Panics(t, func() { _ = fmv.MaxTableFormat() })
 	require.Panics(t, func() { _ = fmv.MinTableFormat() })
 }
+
+// TestTableFormatRewrite tests that table-format-rewrite compactions rewrite
+// existing tables in the newest table format once the format major version is
+// ratcheted to FormatColumnarBlocks, without moving them to another level.
+func TestTableFormatRewrite(t *testing.T) {
+	const numTables = 4
+	open := func(bytesPerSecond int64) *DB {
+		opts := &Options{
+			FS:                 vfs.NewMem(),
+			FormatMajorVersion: FormatWALCompression,
+			Logger:             testLogger{t},
+			// Write one table per key.
+			Levels: []LevelOptions{{TargetFileSize: 1}},
+		}
+		opts.Experimental.TableFormatRewriteBytesPerSecond = bytesPerSecond
+		d, err := Open("", opts)
+		require.NoError(t, err)
+		for i := 0; i < numTables; i++ {
+			key := []byte{byte('a' + i)}
+			require.NoError(t, d.Set(key, key, nil))
+		}
+		require.NoError(t, d.Compact([]byte("a"), []byte("z"), false /* parallelize */))
+		return d
+	}
+	metrics := func(d *DB) *Metrics {
+		d.mu.Lock()
+		d.waitTableStats()
+		d.mu.Unlock()
+		return d.Metrics()
+	}
+	bounds := func(d *DB) []string {
+		tables, err := d.SSTables()
+		require.NoError(t, err)
+		var res []string
+		for l := range tables {
+			for _, f := range tables[l] {
+				res = append(res, fmt.Sprintf("L%d:%s-%s", l, f.Smallest, f.Largest))
+			}
+		}
+		return res
+	}
+
+	t.Run("rewrite", func(t *testing.T) {
+		d := open(1 << 30)
+		defer func() { require.NoError(t, d.Close()) }()
+		before := bounds(d)
+		m := metrics(d)
+		require.Equal(t, int64(0), m.Compact.TableFormatRewritePendingCount)
+
+		require.NoError(t, d.RatchetFormatMajorVersion(FormatColumnarBlocks))
+		require.Eventually(t, func() bool {
+			m = metrics(d)
+			return m.Compact.TableFormatRewritePendingCount == 0
+		}, 10*time.Second, time.Millisecond)
+		require.Equal(t, int64(numTables), m.Compact.TableFormatRewriteCount)
+		require.Equal(t, uint64(0), m.Compact.TableFormatRewritePendingSize)
+		// The rewritten tables have the same bounds and level as the tables
+		// they replaced.
+		require.Equal(t, before, bounds(d))
+		for i := 0; i < numTables; i++ {
+			key := []byte{byte('a' + i)}
+			v, closer, err := d.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, key, v)
+			require.NoError(t, closer.Close())
+		}
+	})
+
+	t.Run("paced", func(t *testing.T) {
+		// At one byte per second, a single table is rewritten before the rate
+		// limit defers the remaining rewrites.
+		d := open(1)
+		defer func() { require.NoError(t, d.Close()) }()
+		require.NoError(t, d.RatchetFormatMajorVersion(FormatColumnarBlocks))
+		var m *Metrics
+		require.Eventually(t, func() bool {
+			m = metrics(d)
+			return m.Compact.TableFormatRewriteCount == 1
+		}, 10*time.Second, time.Millisecond)
+		require.Equal(t, int64(numTables-1), m.Compact.TableFormatRewritePendingCount)
+		require.Less(t, uint64(0), m.Compact.TableFormatRewritePendingSize)
+	})
+}
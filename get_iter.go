@@ -38,7 +38,10 @@ type getIter struct {
 	// field is updated to hold the sequence number of the tombstone.
 	tombstoned       bool
 	tombstonedSeqNum base.SeqNum
-	err              error
+	// hotRanges is non-nil if this Get was sampled for hot range tracking, in
+	// which case every sstable consulted is recorded.
+	hotRanges *hotRangeTracker
+	err       error
 }
 
 // TODO(sumeer): CockroachDB code doesn't use getIter, but, for completeness,
@@ -270,6 +273,9 @@ func (g *getIter) getSSTableIterators(
 		return emptyIter, nil, nil
 	}
 	// m may possibly contain point (or range deletion) keys relevant to g.key.
+	if g.hotRanges != nil {
+		g.hotRanges.recordTable(level.Level(), m)
+	}
 	g.iterOpts.layer = level
 	iters, err := g.newIters(context.Background(), m, &g.iterOpts, internalIterOpts{}, iterPointKeys|iterRangeDeletions)
 	if err != nil {
@@ -0,0 +1,201 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/fastrand"
+	"github.com/cockroachdb/pebble/internal/manifest"
+)
+
+// hotRangeMaxTracked is the maximum number of sstables and key prefixes each
+// tracked by a hotRangeTracker. When the limit is exceeded, all sample counts
+// are halved and entries that decay to zero are dropped, so the tracker
+// retains the most frequently read entries while favoring recent reads.
+const hotRangeMaxTracked = 1024
+
+// HotTable describes a sstable that has received sampled reads.
+type HotTable struct {
+	// Level is the LSM level containing the sstable.
+	Level int
+	// FileNum is the file number of the sstable.
+	FileNum base.FileNum
+	// Smallest and Largest are the user key bounds of the sstable's point
+	// keys, and describe the key range that received the reads.
+	Smallest []byte
+	Largest  []byte
+	// Samples is the (decayed) number of sampled reads that were served by
+	// the sstable.
+	Samples uint64
+}
+
+// HotKey describes a key prefix (as defined by Comparer.Split) that has
+// received sampled reads.
+type HotKey struct {
+	Prefix  []byte
+	Samples uint64
+}
+
+// HotRanges holds the sampled read statistics returned by DB.HotRanges. Tables
+// and Keys are sorted in decreasing order of samples.
+type HotRanges struct {
+	Tables []HotTable
+	Keys   []HotKey
+}
+
+// String implements fmt.Stringer.
+func (h HotRanges) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("tables:\n")
+	for _, t := range h.Tables {
+		fmt.Fprintf(&buf, "  L%d %s [%q-%q]: %d\n", t.Level, t.FileNum, t.Smallest, t.Largest, t.Samples)
+	}
+	buf.WriteString("keys:\n")
+	for _, k := range h.Keys {
+		fmt.Fprintf(&buf, "  %q: %d\n", k.Prefix, k.Samples)
+	}
+	return buf.String()
+}
+
+// hotRangeTracker records sampled reads of sstables and key prefixes. See
+// Options.Experimental.HotRangeSamplingPeriod.
+type hotRangeTracker struct {
+	// getSamplingPeriod is the mean number of Get operations between samples.
+	getSamplingPeriod uint32
+	split             Split
+
+	mu struct {
+		sync.Mutex
+		tables map[base.FileNum]*HotTable
+		keys   map[string]uint64
+	}
+}
+
+func newHotRangeTracker(samplingPeriod int, split Split) *hotRangeTracker {
+	if samplingPeriod <= 0 {
+		return nil
+	}
+	t := &hotRangeTracker{
+		getSamplingPeriod: uint32(samplingPeriod),
+		split:             split,
+	}
+	t.mu.tables = make(map[base.FileNum]*HotTable)
+	t.mu.keys = make(map[string]uint64)
+	return t
+}
+
+// sampleGet returns true if a Get operation should be sampled.
+func (t *hotRangeTracker) sampleGet() bool {
+	return t.getSamplingPeriod == 1 || fastrand.Uint32n(t.getSamplingPeriod) == 0
+}
+
+// recordKey records a sampled read of the provided user key.
+func (t *hotRangeTracker) recordKey(key []byte) {
+	prefix := key[:t.split(key)]
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n, ok := t.mu.keys[string(prefix)]; ok {
+		t.mu.keys[string(prefix)] = n + 1
+		return
+	}
+	for len(t.mu.keys) >= hotRangeMaxTracked {
+		for k, n := range t.mu.keys {
+			if n /= 2; n == 0 {
+				delete(t.mu.keys, k)
+			} else {
+				t.mu.keys[k] = n
+			}
+		}
+	}
+	t.mu.keys[string(prefix)] = 1
+}
+
+// recordTable records a sampled read served by the provided sstable.
+func (t *hotRangeTracker) recordTable(level int, f *manifest.FileMetadata) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if ht, ok := t.mu.tables[f.FileNum]; ok {
+		ht.Samples++
+		return
+	}
+	for len(t.mu.tables) >= hotRangeMaxTracked {
+		for fileNum, ht := range t.mu.tables {
+			if ht.Samples /= 2; ht.Samples == 0 {
+				delete(t.mu.tables, fileNum)
+			}
+		}
+	}
+	t.mu.tables[f.FileNum] = &HotTable{
+		Level:    level,
+		FileNum:  f.FileNum,
+		Smallest: f.SmallestPointKey.UserKey,
+		Largest:  f.LargestPointKey.UserKey,
+		Samples:  1,
+	}
+}
+
+// snapshot returns the tracked statistics. Tables that are no longer present
+// in the provided version are dropped from the tracker.
+func (t *hotRangeTracker) snapshot(v *version) HotRanges {
+	live := make(map[base.FileNum]int)
+	for level := range v.Levels {
+		iter := v.Levels[level].Iter()
+		for f := iter.First(); f != nil; f = iter.Next() {
+			live[f.FileNum] = level
+		}
+	}
+
+	var h HotRanges
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for fileNum, ht := range t.mu.tables {
+		level, ok := live[fileNum]
+		if !ok {
+			delete(t.mu.tables, fileNum)
+			continue
+		}
+		// The file may have been moved to a different level by a move
+		// compaction since it was last sampled.
+		ht.Level = level
+		h.Tables = append(h.Tables, *ht)
+	}
+	for k, n := range t.mu.keys {
+		h.Keys = append(h.Keys, HotKey{Prefix: []byte(k), Samples: n})
+	}
+	slices.SortFunc(h.Tables, func(a, b HotTable) int {
+		if a.Samples != b.Samples {
+			return -cmp.Compare(a.Samples, b.Samples)
+		}
+		return cmp.Compare(a.FileNum, b.FileNum)
+	})
+	slices.SortFunc(h.Keys, func(a, b HotKey) int {
+		if a.Samples != b.Samples {
+			return -cmp.Compare(a.Samples, b.Samples)
+		}
+		return bytes.Compare(a.Prefix, b.Prefix)
+	})
+	return h
+}
+
+// HotRanges returns the sstables and key prefixes that have received the most
+// sampled Get and iterator reads. It returns an empty HotRanges unless
+// Options.Experimental.HotRangeSamplingPeriod is positive.
+func (d *DB) HotRanges() HotRanges {
+	if err := d.closed.Load(); err != nil {
+		panic(err)
+	}
+	if d.hotRanges == nil {
+		return HotRanges{}
+	}
+	readState := d.loadReadState()
+	defer readState.unref()
+	return d.hotRanges.snapshot(readState.current)
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHotRanges(t *testing.T) {
+	opts := &Options{FS: vfs.NewMem()}
+	opts.Experimental.HotRangeSamplingPeriod = 1
+	d, err := Open("", opts)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	// Write two sstables: one in L6 and one in L0 overlapping it.
+	require.NoError(t, d.Set([]byte("a"), []byte("1"), nil))
+	require.NoError(t, d.Set([]byte("c"), []byte("1"), nil))
+	require.NoError(t, d.Flush())
+	require.NoError(t, d.Compact([]byte("a"), []byte("d"), false))
+	require.NoError(t, d.Set([]byte("b"), []byte("2"), nil))
+	require.NoError(t, d.Flush())
+	require.Empty(t, d.HotRanges().Tables)
+
+	for i := 0; i < 3; i++ {
+		_, closer, err := d.Get([]byte("a"))
+		require.NoError(t, err)
+		require.NoError(t, closer.Close())
+	}
+
+	iter, err := d.NewIter(nil)
+	require.NoError(t, err)
+	iter.readSampling.forceReadSampling = true
+	require.True(t, iter.SeekGE([]byte("b")))
+	require.NoError(t, iter.Close())
+
+	h := d.HotRanges()
+	var tables []string
+	for _, ht := range h.Tables {
+		tables = append(tables, fmt.Sprintf("L%d:%s-%s:%d", ht.Level, ht.Smallest, ht.Largest, ht.Samples))
+	}
+	// Each Get of "a" is served by the L6 table, since the L0 table's bounds
+	// exclude "a". The sampled iterator read of "b" hits both tables.
+	require.Equal(t, []string{"L6:a-c:4", "L0:b-b:1"}, tables)
+	require.Equal(t, []HotKey{{Prefix: []byte("a"), Samples: 3}, {Prefix: []byte("b"), Samples: 1}}, h.Keys)
+
+	// Compacting away the tables drops them from the statistics.
+	require.NoError(t, d.Compact([]byte("a"), []byte("d"), false))
+	require.Empty(t, d.HotRanges().Tables)
+}
@@ -289,7 +289,7 @@ func ingestLoad1(
 	// disallowing removal of an open file. Under MemFS, if we don't populate
 	// meta.Stats here, the file will be loaded into the table cache for
 	// calculating stats before we can remove the original link.
-	maybeSetStatsFromProperties(meta.PhysicalMeta(), &r.Properties)
+	maybeSetStatsFromProperties(meta.PhysicalMeta(), &r.Properties, tf)
 
 	{
 		iter, err := r.NewIter(sstable.NoTransforms, nil /* lower */, nil /* upper */)
@@ -2514,8 +2514,8 @@ func (d *DB) validateSSTables() {
 
 		if err != nil {
 			if IsCorruptionError(err) {
-				// TODO(travers): Hook into the corruption reporting pipeline, once
-				// available. See pebble#1192.
+				d.opts.EventListener.DataCorruption(
+					d.makeDataCorruptionInfo(jobID, f.Level, f.Meta, nil /* blocks */, err))
 				d.opts.Logger.Fatalf("pebble: encountered corruption during ingestion: %s", err)
 			} else {
 				// If there was some other, possibly transient, error that
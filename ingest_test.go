@@ -174,6 +174,7 @@ func TestIngestLoadRand(t *testing.T) {
 			path: paths[i],
 		}
 		expected[i].fileMetadata.Stats.CompressionType = block.SnappyCompression
+		expected[i].fileMetadata.Stats.TableFormat = version.MaxTableFormat()
 		expected[i].StatsMarkValid()
 
 		func() {
@@ -103,6 +103,24 @@ func (a *Arena) alloc(size, alignment, overflow uint32) (uint32, uint32, error)
 	return offset, uint32(padded), nil
 }
 
+// AllocBytes allocates a byte slice of the given size from the arena, returning
+// the slice and its offset within the arena. It allows other structures to
+// share an arena with skiplists. Returns ErrArenaFull if the arena has
+// insufficient space.
+func (a *Arena) AllocBytes(size uint32) ([]byte, uint32, error) {
+	offset, _, err := a.alloc(size, 1, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return a.buf[offset : offset+size : offset+size], offset, nil
+}
+
+// Bytes returns the size bytes at the given offset, which must have been
+// returned by AllocBytes.
+func (a *Arena) Bytes(offset, size uint32) []byte {
+	return a.buf[offset : offset+size : offset+size]
+}
+
 func (a *Arena) getBytes(offset uint32, size uint32) []byte {
 	if offset == 0 {
 		return nil
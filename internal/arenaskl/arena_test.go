@@ -51,3 +51,17 @@ func TestArenaSizeOverflow(t *testing.T) {
 	require.Equal(t, ErrArenaFull, err)
 	require.Equal(t, uint32(constants.MaxUint32OrInt), a.Size())
 }
+
+func TestArenaAllocBytes(t *testing.T) {
+	a := newArena(16)
+	b, offset, err := a.AllocBytes(10)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), offset)
+	require.Len(t, b, 10)
+	copy(b, "0123456789")
+	require.Equal(t, []byte("0123456789"), a.Bytes(offset, 10))
+
+	// The arena has only 5 bytes remaining.
+	_, _, err = a.AllocBytes(6)
+	require.Equal(t, ErrArenaFull, err)
+}
@@ -74,6 +74,10 @@ func (h Handle) Release() {
 	h.value.release()
 }
 
+// highPriorityMaxFraction is the fraction of a shard's target size that high
+// priority entries may occupy while being protected from eviction.
+const highPriorityMaxFraction = 0.5
+
 type shard struct {
 	hits   atomic.Int64
 	misses atomic.Int64
@@ -109,15 +113,23 @@ type shard struct {
 	countHot  int64
 	countCold int64
 	countTest int64
+
+	// sizeByPriority and countByPriority track the resident (hot and cold)
+	// entries of each priority class.
+	sizeByPriority  [NumPriorities]int64
+	countByPriority [NumPriorities]int64
+	hitsByPriority  [NumPriorities]atomic.Int64
 }
 
 func (c *shard) Get(id ID, fileNum base.DiskFileNum, offset uint64) Handle {
 	c.mu.RLock()
 	var value *Value
+	var priority Priority
 	if e, _ := c.blocks.Get(key{fileKey{id, fileNum}, offset}); e != nil {
 		value = e.acquireValue()
 		if value != nil {
 			e.referenced.Store(true)
+			priority = e.priority
 		}
 	}
 	c.mu.RUnlock()
@@ -126,10 +138,13 @@ func (c *shard) Get(id ID, fileNum base.DiskFileNum, offset uint64) Handle {
 		return Handle{}
 	}
 	c.hits.Add(1)
+	c.hitsByPriority[priority].Add(1)
 	return Handle{value: value}
 }
 
-func (c *shard) Set(id ID, fileNum base.DiskFileNum, offset uint64, value *Value) Handle {
+func (c *shard) Set(
+	id ID, fileNum base.DiskFileNum, offset uint64, value *Value, priority Priority,
+) Handle {
 	if n := value.refs(); n != 1 {
 		panic(fmt.Sprintf("pebble: Value has already been added to the cache: refs=%d", n))
 	}
@@ -144,23 +159,39 @@ func (c *shard) Set(id ID, fileNum base.DiskFileNum, offset uint64, value *Value
 	case e == nil:
 		// no cache entry? add it
 		e = newEntry(k, int64(len(value.buf)))
+		e.priority = priority
+		if priority == HighPriority {
+			// High priority entries skip the cold probationary period.
+			e.ptype = etHot
+		}
 		e.setValue(value)
-		if c.metaAdd(k, e) {
-			value.ref.trace("add-cold")
-			c.sizeCold += e.size
-			c.countCold++
-		} else {
+		if !c.metaAdd(k, e) {
 			value.ref.trace("skip-cold")
 			e.free()
 			e = nil
+		} else if e.ptype == etHot {
+			value.ref.trace("add-hot")
+			c.sizeHot += e.size
+			c.countHot++
+			c.resident(e, +1)
+		} else {
+			value.ref.trace("add-cold")
+			c.sizeCold += e.size
+			c.countCold++
+			c.resident(e, +1)
 		}
 
 	case e.peekValue() != nil:
 		// cache entry was a hot or cold page
 		e.setValue(value)
 		e.referenced.Store(true)
+		c.resident(e, -1)
 		delta := int64(len(value.buf)) - e.size
 		e.size = int64(len(value.buf))
+		if priority != LowPriority && e.priority != HighPriority {
+			e.priority = priority
+		}
+		c.resident(e, +1)
 		if e.ptype == etHot {
 			value.ref.trace("add-hot")
 			c.sizeHot += delta
@@ -178,18 +209,36 @@ func (c *shard) Set(id ID, fileNum base.DiskFileNum, offset uint64, value *Value
 		c.metaCheck(e)
 
 		e.size = int64(len(value.buf))
+		e.referenced.Store(false)
+		e.setValue(value)
+		e.priority = priority
+		if priority == LowPriority {
+			// A low priority reload of a test page is not evidence of reuse;
+			// admit it as cold without adapting the cold target.
+			if c.metaAdd(k, e) {
+				value.ref.trace("add-cold")
+				c.sizeCold += e.size
+				c.countCold++
+				c.resident(e, +1)
+			} else {
+				value.ref.trace("skip-cold")
+				e.free()
+				e = nil
+			}
+			break
+		}
+
 		c.coldTarget += e.size
 		if c.coldTarget > c.targetSize() {
 			c.coldTarget = c.targetSize()
 		}
 
-		e.referenced.Store(false)
-		e.setValue(value)
 		e.ptype = etHot
 		if c.metaAdd(k, e) {
 			value.ref.trace("add-hot")
 			c.sizeHot += e.size
 			c.countHot++
+			c.resident(e, +1)
 		} else {
 			value.ref.trace("skip-hot")
 			e.free()
@@ -219,6 +268,23 @@ func (c *shard) checkConsistency() {
 	}
 }
 
+// protected returns true if the cold clock hand should treat the entry as
+// referenced, promoting it to hot rather than demoting it to a test entry. High
+// priority entries are protected as long as they make up at most
+// highPriorityMaxFraction of the target size, which bounds the eviction work
+// performed before a victim is found.
+func (c *shard) protected(e *entry) bool {
+	return e.priority == HighPriority &&
+		float64(c.sizeByPriority[HighPriority]) <= highPriorityMaxFraction*float64(c.targetSize())
+}
+
+// resident adjusts the per-priority accounting of resident entries by n
+// copies of e.
+func (c *shard) resident(e *entry, n int64) {
+	c.sizeByPriority[e.priority] += n * e.size
+	c.countByPriority[e.priority] += n
+}
+
 // Delete deletes the cached value for the specified file and offset.
 func (c *shard) Delete(id ID, fileNum base.DiskFileNum, offset uint64) {
 	// The common case is there is nothing to delete, so do a quick check with
@@ -499,9 +565,11 @@ func (c *shard) metaEvict(e *entry) (evictedValue *Value) {
 	case etHot:
 		c.sizeHot -= e.size
 		c.countHot--
+		c.resident(e, -1)
 	case etCold:
 		c.sizeCold -= e.size
 		c.countCold--
+		c.resident(e, -1)
 	case etTest:
 		c.sizeTest -= e.size
 		c.countTest--
@@ -531,14 +599,27 @@ func (c *shard) runHandCold(countColdDebug, sizeColdDebug int64) {
 
 	e := c.handCold
 	if e.ptype == etCold {
-		if e.referenced.Load() {
+		if e.referenced.Load() || c.protected(e) {
+			if e.priority == LowPriority {
+				// A low priority entry that was referenced after its admission
+				// is treated as an ordinary entry from now on.
+				c.resident(e, -1)
+				e.priority = NormalPriority
+				c.resident(e, +1)
+			}
 			e.referenced.Store(false)
 			e.ptype = etHot
 			c.sizeCold -= e.size
 			c.countCold--
 			c.sizeHot += e.size
 			c.countHot++
+		} else if e.priority == LowPriority {
+			// Low priority entries are evicted outright rather than becoming
+			// test entries, so that reloading them does not admit them as hot.
+			e.setValue(nil)
+			c.metaEvict(e)
 		} else {
+			c.resident(e, -1)
 			e.setValue(nil)
 			e.ptype = etTest
 			c.sizeCold -= e.size
@@ -622,6 +703,9 @@ type Metrics struct {
 	Hits int64
 	// The number of cache misses.
 	Misses int64
+	// Priorities holds the metrics of each priority class, indexed by
+	// Priority. Misses are not attributed to a priority class.
+	Priorities [NumPriorities]PriorityMetrics
 }
 
 // Cache implements Pebble's sharded block cache. The Clock-PRO algorithm is
@@ -821,9 +905,19 @@ func (c *Cache) Get(id ID, fileNum base.DiskFileNum, offset uint64) Handle {
 // Set sets the cache value for the specified file and offset, overwriting an
 // existing value if present. A Handle is returned which provides faster
 // retrieval of the cached value than Get (lock-free and avoidance of the map
-// lookup). The value must have been allocated by Cache.Alloc.
+// lookup). The value must have been allocated by Cache.Alloc. The value is
+// cached with NormalPriority.
 func (c *Cache) Set(id ID, fileNum base.DiskFileNum, offset uint64, value *Value) Handle {
-	return c.getShard(id, fileNum, offset).Set(id, fileNum, offset, value)
+	return c.getShard(id, fileNum, offset).Set(id, fileNum, offset, value, NormalPriority)
+}
+
+// SetWithPriority is like Set, but caches the value with the provided
+// priority. If the value replaces an existing HighPriority value, the entry
+// retains HighPriority.
+func (c *Cache) SetWithPriority(
+	id ID, fileNum base.DiskFileNum, offset uint64, value *Value, priority Priority,
+) Handle {
+	return c.getShard(id, fileNum, offset).Set(id, fileNum, offset, value, priority)
 }
 
 // Delete deletes the cached value for the specified file and offset.
@@ -903,9 +997,16 @@ func (c *Cache) Metrics() Metrics {
 		s.mu.RLock()
 		m.Count += int64(s.blocks.Len())
 		m.Size += s.sizeHot + s.sizeCold
+		for p := range m.Priorities {
+			m.Priorities[p].Size += s.sizeByPriority[p]
+			m.Priorities[p].Count += s.countByPriority[p]
+		}
 		s.mu.RUnlock()
 		m.Hits += s.hits.Load()
 		m.Misses += s.misses.Load()
+		for p := range m.Priorities {
+			m.Priorities[p].Hits += s.hitsByPriority[p].Load()
+		}
 	}
 	return m
 }
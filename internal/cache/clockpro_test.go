@@ -277,3 +277,66 @@ func TestReserveColdTarget(t *testing.T) {
 		t.Fatalf("expected positive cache size %d, but found %d", 48, cache.Size())
 	}
 }
+
+func TestCachePriority(t *testing.T) {
+	cache := newShards(100, 1)
+	defer cache.Unref()
+
+	// Insert a high priority block, and then scan through many single-use
+	// normal priority blocks. The high priority block should survive the scan.
+	cache.SetWithPriority(1, base.DiskFileNum(0), 0, testValue(cache, "a", 10), HighPriority).Release()
+	for i := 1; i < 100; i++ {
+		cache.Set(1, base.DiskFileNum(i), 0, testValue(cache, "b", 10)).Release()
+	}
+	h := cache.Get(1, base.DiskFileNum(0), 0)
+	require.NotNil(t, h.Get())
+	h.Release()
+
+	m := cache.Metrics()
+	require.Equal(t, PriorityMetrics{Size: 10, Count: 1, Hits: 1}, m.Priorities[HighPriority])
+	require.Equal(t, m.Size-10, m.Priorities[NormalPriority].Size)
+
+	// High priority blocks are only protected while they occupy at most half
+	// of the cache, so a cache filled with them still evicts.
+	for i := 1; i < 100; i++ {
+		cache.SetWithPriority(1, base.DiskFileNum(i), 1, testValue(cache, "c", 10), HighPriority).Release()
+	}
+	m = cache.Metrics()
+	require.LessOrEqual(t, m.Size, int64(100))
+	require.Less(t, m.Priorities[HighPriority].Count, int64(99))
+
+	cache.EvictFile(1, base.DiskFileNum(0))
+	require.Equal(t, int64(1), cache.Metrics().Priorities[HighPriority].Hits)
+}
+
+func TestCacheLowPriority(t *testing.T) {
+	// scan loads 100 blocks twice through a cache that can hold only 10 of
+	// them, returning the shard's test size and the number of hot entries.
+	scan := func(priority Priority) (sizeTest int64, countHot int64) {
+		cache := newShards(100, 1)
+		defer cache.Unref()
+		for j := 0; j < 2; j++ {
+			for i := 0; i < 100; i++ {
+				if h := cache.Get(1, base.DiskFileNum(i), 0); h.Get() != nil {
+					h.Release()
+					continue
+				}
+				cache.SetWithPriority(1, base.DiskFileNum(i), 0, testValue(cache, "a", 10), priority).Release()
+			}
+		}
+		s := &cache.shards[0]
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.sizeTest, s.countHot
+	}
+
+	// A normal priority scan leaves test entries behind, whose reloading
+	// admits blocks as hot.
+	sizeTest, _ := scan(NormalPriority)
+	require.Greater(t, sizeTest, int64(0))
+
+	// A low priority scan leaves no trace beyond its resident cold blocks.
+	sizeTest, countHot := scan(LowPriority)
+	require.Equal(t, int64(0), sizeTest)
+	require.Equal(t, int64(0), countHot)
+}
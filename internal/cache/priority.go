@@ -0,0 +1,51 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package cache
+
+// Priority is the priority class of a cached block. Blocks of a higher
+// priority are retained preferentially when the cache must evict blocks.
+type Priority int8
+
+const (
+	// NormalPriority is the priority of ordinary data blocks.
+	NormalPriority Priority = iota
+	// HighPriority is the priority of blocks that should be retained
+	// preferentially, such as index and filter blocks or the data blocks of
+	// pinned key spans. A high priority block is admitted to the cache as a
+	// hot block (rather than as a cold block on probation), and is not evicted
+	// while high priority blocks occupy at most half of the cache.
+	HighPriority
+	// LowPriority is the priority of blocks that are unlikely to be reused,
+	// such as the data blocks loaded by a full table scan. A low priority block
+	// is admitted to the cache as a cold block and is evicted outright, rather
+	// than being remembered as a test block, unless it is referenced again
+	// before the cold clock hand reaches it.
+	LowPriority
+	// NumPriorities is the number of priority classes.
+	NumPriorities
+)
+
+// String implements fmt.Stringer.
+func (p Priority) String() string {
+	switch p {
+	case NormalPriority:
+		return "normal"
+	case HighPriority:
+		return "high"
+	case LowPriority:
+		return "low"
+	}
+	return "unknown"
+}
+
+// PriorityMetrics holds the metrics of a single priority class.
+type PriorityMetrics struct {
+	// The number of bytes of resident blocks of this priority.
+	Size int64
+	// The count of resident blocks of this priority.
+	Count int64
+	// The number of cache hits on blocks of this priority.
+	Hits int64
+}
@@ -11,6 +11,10 @@ type Data struct {
 
 	// Keys contains all table boundary keys, in sorted key order.
 	Keys []string `json:"keys"`
+
+	// Compactions contains the compactions that were in progress when the data
+	// was generated.
+	Compactions []Compaction `json:"compactions,omitempty"`
 }
 
 // Level contains the data for a level of the LSM.
@@ -27,4 +31,24 @@ type Table struct {
 	SmallestKey int      `json:"smallest_key"`
 	LargestKey  int      `json:"largest_key"`
 	Details     []string `json:"details"`
+	// Virtual is set if the table is a virtual table.
+	Virtual bool `json:"virtual,omitempty"`
+}
+
+// Compaction contains the data for an in-progress compaction.
+type Compaction struct {
+	Kind string `json:"kind"`
+	// Inputs contains the input tables of the compaction, grouped by level.
+	Inputs []CompactionLevel `json:"inputs"`
+	// OutputLevel is the name of the level the compaction writes to.
+	OutputLevel string `json:"output_level"`
+	// Duration is how long the compaction has been running for.
+	Duration string `json:"duration"`
+}
+
+// CompactionLevel contains the input tables of a compaction in a level.
+type CompactionLevel struct {
+	Name string `json:"level_name"`
+	// Tables contains the labels of the input tables, matching Table.Label.
+	Tables []string `json:"tables"`
 }
@@ -225,6 +225,10 @@ type Iterator struct {
 	// For use in LazyValue.Value.
 	lazyValueBuf []byte
 	valueCloser  io.Closer
+	// bufferPool holds the buffers into which blocks are read when
+	// IterOptions.BypassCache is set. The memory it retains is bounded by
+	// bypassCacheBufferPoolBytes.
+	bufferPool sstable.BufferPool
 	// boundsBuf holds two buffers used to store the lower and upper bounds.
 	// Whenever the Iterator's bounds change, the new bounds are copied into
 	// boundsBuf[boundsBufIdx]. The two bounds share a slice to reduce
@@ -833,6 +837,9 @@ func (i *Iterator) sampleRead() {
 	if mi == nil {
 		return
 	}
+	if t := i.readState.db.hotRanges; t != nil {
+		i.sampleHotRanges(t)
+	}
 	if len(mi.levels) > 1 {
 		mi.ForEachLevelIter(func(li *levelIter) (done bool) {
 			if li.layer.IsFlushableIngests() {
@@ -892,6 +899,24 @@ func (i *Iterator) sampleRead() {
 	}
 }
 
+// sampleHotRanges records the iterator's current key, and every sstable
+// containing it that the iterator's level iterators are positioned at, with
+// the hot range tracker.
+func (i *Iterator) sampleHotRanges(t *hotRangeTracker) {
+	t.recordKey(i.key)
+	i.merging.ForEachLevelIter(func(li *levelIter) (done bool) {
+		if li.layer.IsFlushableIngests() {
+			return false
+		}
+		if f := li.iterFile; f != nil && f.HasPointKeys &&
+			i.cmp(f.SmallestPointKey.UserKey, i.key) <= 0 &&
+			i.cmp(f.LargestPointKey.UserKey, i.key) >= 0 {
+			t.recordTable(li.layer.Level(), f)
+		}
+		return false
+	})
+}
+
 func (i *Iterator) findPrevEntry(limit []byte) {
 	i.iterValidityState = IterExhausted
 	i.pos = iterPosCurReverse
@@ -2329,6 +2354,12 @@ func (i *Iterator) Error() error {
 
 const maxKeyBufCacheSize = 4 << 10 // 4 KB
 
+// bypassCacheBufferPoolBytes bounds the memory retained by the buffer pool of
+// an iterator with IterOptions.BypassCache set. The blocks in use by the
+// iterator may exceed the bound, but they're freed rather than retained once
+// released.
+const bypassCacheBufferPoolBytes = 256 << 10 // 256 KB
+
 // Close closes the iterator and returns any accumulated error. Exhausting
 // all the key/value pairs in a table is not considered to be an error.
 // It is not valid to call any method, including Close, after the iterator
@@ -2396,6 +2427,9 @@ func (i *Iterator) Close() error {
 		err = firstError(err, i.valueCloser.Close())
 		i.valueCloser = nil
 	}
+	// All the sstable iterators have been closed, so none of the pooled buffers
+	// remain in use.
+	i.bufferPool.Release()
 
 	if i.rangeKey != nil {
 
@@ -2586,7 +2620,7 @@ func (i *Iterator) SetOptions(o *IterOptions) {
 	// reconstruct it.
 	if i.pointIter != nil && (closeBoth || len(o.PointKeyFilters) > 0 || len(i.opts.PointKeyFilters) > 0 ||
 		o.RangeKeyMasking.Filter != nil || i.opts.RangeKeyMasking.Filter != nil || o.SkipPoint != nil ||
-		i.opts.SkipPoint != nil) {
+		i.opts.SkipPoint != nil || o.Projection != nil || i.opts.Projection != nil) {
 		i.err = firstError(i.err, i.pointIter.Close())
 		i.pointIter = nil
 	}
@@ -1190,6 +1190,56 @@ func TestIteratorGuaranteedDurable(t *testing.T) {
 	})
 }
 
+func TestIteratorCacheAdmission(t *testing.T) {
+	d, err := Open("", &Options{FS: vfs.NewMem()})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, d.Set([]byte(fmt.Sprintf("%04d", i)), bytes.Repeat([]byte("v"), 100), nil))
+	}
+	require.NoError(t, d.Flush())
+
+	// scan reads the entire table, returning the number of blocks of each
+	// priority class the scan added to the block cache.
+	scan := func(o *IterOptions) (counts [CacheLowPriority + 1]int64) {
+		before := d.Metrics().BlockCache.Priorities
+		iter, err := d.NewIter(o)
+		require.NoError(t, err)
+		n := 0
+		for valid := iter.First(); valid; valid = iter.Next() {
+			n++
+		}
+		require.Equal(t, 1000, n)
+		require.NoError(t, iter.Close())
+		after := d.Metrics().BlockCache.Priorities
+		for p := range counts {
+			counts[p] = after[p].Count - before[p].Count
+		}
+		return counts
+	}
+
+	// The first scan opens the table, caching blocks such as the properties
+	// block that are read when opening a table.
+	scan(&IterOptions{BypassCache: true})
+	counts := scan(&IterOptions{BypassCache: true})
+	require.Zero(t, counts[CacheNormalPriority])
+	require.Zero(t, counts[CacheLowPriority])
+
+	counts = scan(&IterOptions{CachePriority: CacheLowPriority})
+	require.Zero(t, counts[CacheNormalPriority])
+	require.Greater(t, counts[CacheLowPriority], int64(1))
+
+	// Blocks already resident in the cache are used by an iterator that
+	// bypasses the cache.
+	iter, err := d.NewIter(&IterOptions{BypassCache: true})
+	require.NoError(t, err)
+	for valid := iter.First(); valid; valid = iter.Next() {
+	}
+	require.Equal(t, iter.Stats().InternalStats.BlockBytes, iter.Stats().InternalStats.BlockBytesInCache)
+	require.NoError(t, iter.Close())
+}
+
 func TestIteratorBoundsLifetimes(t *testing.T) {
 	rng := rand.New(rand.NewSource(uint64(time.Now().UnixNano())))
 	d := newPointTestkeysDatabase(t, testkeys.Alpha(2))
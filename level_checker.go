@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/keyspan"
 	"github.com/cockroachdb/pebble/internal/manifest"
+	"github.com/cockroachdb/pebble/sstable"
 )
 
 // This file implements DB.CheckLevels() which checks that every entry in the
@@ -675,6 +676,58 @@ func checkLevelsInternal(c *checkConfig) (err error) {
 	return checkRangeTombstones(c)
 }
 
+// checkTableKeyOrder checks that the point keys of a single table are in
+// strictly increasing internal key order, and lie within the point key bounds
+// recorded in the table's metadata. Unlike CheckLevels, it does not compare
+// keys across tables, which allows a scrub to verify tables one at a time.
+func checkTableKeyOrder(
+	ctx context.Context,
+	cmp Compare,
+	formatKey base.FormatKey,
+	newIters tableNewIters,
+	m *fileMetadata,
+) (err error) {
+	if !m.HasPointKeys {
+		return nil
+	}
+	// The check reads every block of the table once, so read the blocks into a
+	// small private buffer pool rather than evicting the working set from the
+	// block cache.
+	var bufferPool sstable.BufferPool
+	bufferPool.Init(4)
+	bufferPool.SetMaxRetainedBytes(bypassCacheBufferPoolBytes)
+	defer bufferPool.Release()
+	iterOpts := IterOptions{BypassCache: true}
+	iters, err := newIters(ctx, m, &iterOpts, internalIterOpts{bufferPool: &bufferPool}, iterPointKeys)
+	if err != nil {
+		return err
+	}
+	defer func() { err = firstError(err, iters.CloseAll()) }()
+
+	iter := iters.Point()
+	var prev InternalKey
+	for kv := iter.First(); kv != nil; kv = iter.Next() {
+		switch {
+		case prev.UserKey == nil && base.InternalCompare(cmp, kv.K, m.SmallestPointKey) < 0:
+			return base.CorruptionErrorf("pebble: table %s: key %s is below the smallest point key %s",
+				m.FileNum, kv.K.Pretty(formatKey), m.SmallestPointKey.Pretty(formatKey))
+		case prev.UserKey != nil && base.InternalCompare(cmp, prev, kv.K) >= 0:
+			return base.CorruptionErrorf("pebble: table %s: keys out of order: %s, %s",
+				m.FileNum, prev.Pretty(formatKey), kv.K.Pretty(formatKey))
+		}
+		prev.Trailer = kv.K.Trailer
+		prev.UserKey = append(prev.UserKey[:0], kv.K.UserKey...)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if prev.UserKey != nil && base.InternalCompare(cmp, prev, m.LargestPointKey) > 0 {
+		return base.CorruptionErrorf("pebble: table %s: key %s is above the largest point key %s",
+			m.FileNum, prev.Pretty(formatKey), m.LargestPointKey.Pretty(formatKey))
+	}
+	return nil
+}
+
 type simpleMergingIterItem struct {
 	index int
 	key   InternalKey
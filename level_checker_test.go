@@ -180,6 +180,11 @@ func TestCheckLevelsCornerCases(t *testing.T) {
 					TableFormat: FormatNewest.MaxTableFormat(),
 					Comparer:    testkeys.Comparer,
 				}
+				if writeUnfragmented {
+					// Unfragmented range deletions can only be written to
+					// row-oriented range deletion blocks.
+					writerOpts.TableFormat = sstable.TableFormatPebblev4
+				}
 				writerOpts.SetInternal(sstableinternal.WriterOptions{
 					DisableKeyOrderChecks: disableKeyOrderChecks,
 				})
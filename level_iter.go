@@ -164,6 +164,7 @@ func (l *levelIter) init(
 	}
 	l.tableOpts.UseL6Filters = opts.UseL6Filters
 	l.tableOpts.CategoryAndQoS = opts.CategoryAndQoS
+	l.tableOpts.CachePriority = opts.CachePriority
 	l.tableOpts.layer = l.layer
 	l.tableOpts.snapshotForHideObsoletePoints = opts.snapshotForHideObsoletePoints
 	l.comparer = comparer
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/humanize"
@@ -18,10 +19,29 @@ import (
 
 // LSMViewURL returns an URL which shows a diagram of the LSM.
 func (d *DB) LSMViewURL() string {
+	data := d.lsmViewData(false /* withCompactions */)
+	url, err := lsmview.GenerateURL(data)
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	return url.String()
+}
+
+// LSMViewData returns the data used to draw a diagram of the LSM, along with
+// the compactions that are in progress.
+func (d *DB) LSMViewData() lsmview.Data {
+	return d.lsmViewData(true /* withCompactions */)
+}
+
+func (d *DB) lsmViewData(withCompactions bool) lsmview.Data {
+	var compactions []lsmview.Compaction
 	v := func() *version {
 		d.mu.Lock()
 		defer d.mu.Unlock()
 
+		if withCompactions {
+			compactions = d.lsmViewCompactionsLocked()
+		}
 		v := d.mu.versions.currentVersion()
 		v.Ref()
 		return v
@@ -38,11 +58,50 @@ func (d *DB) LSMViewURL() string {
 	b.InitLevels(v)
 	b.PopulateKeys()
 	data := b.Build(d.objProvider, d.newIters)
-	url, err := lsmview.GenerateURL(data)
-	if err != nil {
-		return fmt.Sprintf("error: %s", err)
+	data.Compactions = compactions
+	return data
+}
+
+// lsmViewCompactionsLocked returns the compactions in progress, in the order in
+// which they began. Requires d.mu to be held.
+func (d *DB) lsmViewCompactionsLocked() []lsmview.Compaction {
+	var cs []*compaction
+	for c := range d.mu.compact.inProgress {
+		cs = append(cs, c)
 	}
-	return url.String()
+	slices.SortFunc(cs, func(a, b *compaction) int {
+		return a.beganAt.Compare(b.beganAt)
+	})
+	now := d.timeNow()
+	res := make([]lsmview.Compaction, len(cs))
+	for i, c := range cs {
+		res[i].Kind = c.kind.String()
+		for _, in := range c.inputs {
+			var tables []string
+			in.files.Each(func(f *fileMetadata) {
+				tables = append(tables, lsmViewTableLabel(f))
+			})
+			if len(tables) > 0 {
+				res[i].Inputs = append(res[i].Inputs, lsmview.CompactionLevel{
+					Name:   fmt.Sprintf("L%d", in.level),
+					Tables: tables,
+				})
+			}
+		}
+		if c.outputLevel != nil {
+			res[i].OutputLevel = fmt.Sprintf("L%d", c.outputLevel.level)
+		}
+		res[i].Duration = now.Sub(c.beganAt).Round(time.Millisecond).String()
+	}
+	return res
+}
+
+// lsmViewTableLabel returns the label of a table in the LSM diagram.
+func lsmViewTableLabel(f *fileMetadata) string {
+	if !f.Virtual {
+		return fmt.Sprintf("%d", f.FileNum)
+	}
+	return fmt.Sprintf("%d (%d)", f.FileNum, f.FileBacking.DiskFileNum)
 }
 
 type lsmViewBuilder struct {
@@ -142,11 +201,8 @@ func (b *lsmViewBuilder) Build(
 		l.Tables = make([]lsmview.Table, len(files))
 		for j, f := range files {
 			t := &l.Tables[j]
-			if !f.Virtual {
-				t.Label = fmt.Sprintf("%d", f.FileNum)
-			} else {
-				t.Label = fmt.Sprintf("%d (%d)", f.FileNum, f.FileBacking.DiskFileNum)
-			}
+			t.Label = lsmViewTableLabel(f)
+			t.Virtual = f.Virtual
 
 			t.Size = f.Size
 			t.SmallestKey = b.keys[string(f.Smallest.UserKey)]
@@ -5,13 +5,14 @@
 package pebble
 
 import (
-	"bytes"
 	"fmt"
+	"math"
 	"os"
 	"sync"
 	"sync/atomic"
 
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble/batchrepr"
 	"github.com/cockroachdb/pebble/internal/arenaskl"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/keyspan"
@@ -24,30 +25,19 @@ func memTableEntrySize(keyBytes, valueBytes int) uint64 {
 	return arenaskl.MaxNodeSize(uint32(keyBytes)+8, uint32(valueBytes))
 }
 
-// memTableEmptySize is the amount of allocated space in the arena when the
-// memtable is empty.
-var memTableEmptySize = func() uint32 {
-	var pointSkl arenaskl.Skiplist
-	var rangeDelSkl arenaskl.Skiplist
-	var rangeKeySkl arenaskl.Skiplist
-	arena := arenaskl.NewArena(make([]byte, 16<<10 /* 16 KB */))
-	pointSkl.Reset(arena, bytes.Compare)
-	rangeDelSkl.Reset(arena, bytes.Compare)
-	rangeKeySkl.Reset(arena, bytes.Compare)
-	return arena.Size()
-}()
-
 // A memTable implements an in-memory layer of the LSM. A memTable is mutable,
 // but append-only. Records are added, but never removed. Deletion is supported
 // via tombstones, but it is up to higher level code (see Iterator) to support
 // processing those tombstones.
 //
-// A memTable is implemented on top of a lock-free arena-backed skiplist. An
-// arena is a fixed size contiguous chunk of memory (see
+// By default, a memTable is implemented on top of a lock-free arena-backed
+// skiplist. An arena is a fixed size contiguous chunk of memory (see
 // Options.MemTableSize). A memTable's memory consumption is thus fixed at the
 // time of creation (with the exception of the cached fragmented range
 // tombstones). The arena-backed skiplist provides both forward and reverse
-// links which makes forward and reverse iteration the same speed.
+// links which makes forward and reverse iteration the same speed. Point keys
+// may instead be held in an alternative structure allocated from the same
+// arena (see Options.Experimental.MemTableKind).
 //
 // A batch is "applied" to a memTable in a two step process: prepare(batch) ->
 // apply(batch). memTable.prepare() is not thread-safe and must be called with
@@ -67,9 +57,16 @@ type memTable struct {
 	formatKey   base.FormatKey
 	equal       Equal
 	arenaBuf    []byte
-	skl         arenaskl.Skiplist
+	arena       *arenaskl.Arena
+	points      memTablePointKeys
 	rangeDelSkl arenaskl.Skiplist
 	rangeKeySkl arenaskl.Skiplist
+	// emptySize is the amount of allocated space in the arena when the
+	// memtable is empty.
+	emptySize uint32
+	// applyConcurrency is the maximum number of goroutines used to apply a
+	// single batch (see Options.Experimental.MemTableApplyConcurrency).
+	applyConcurrency int
 	// reserved tracks the amount of space used by the memtable, both by actual
 	// data stored in the memtable as well as inflight batch commit
 	// operations. This value is incremented pessimistically by prepare() in
@@ -136,6 +133,7 @@ func (m *memTable) init(opts memTableOptions) {
 		arenaBuf:                     opts.arenaBuf,
 		logSeqNum:                    opts.logSeqNum,
 		releaseAccountingReservation: opts.releaseAccountingReservation,
+		applyConcurrency:             opts.Experimental.MemTableApplyConcurrency,
 	}
 	m.writerRefs.Store(1)
 	m.tombstones = keySpanCache{
@@ -155,11 +153,12 @@ func (m *memTable) init(opts memTableOptions) {
 		m.arenaBuf = make([]byte, opts.size)
 	}
 
-	arena := arenaskl.NewArena(m.arenaBuf)
-	m.skl.Reset(arena, m.cmp)
-	m.rangeDelSkl.Reset(arena, m.cmp)
-	m.rangeKeySkl.Reset(arena, m.cmp)
-	m.reserved = arena.Size()
+	m.arena = arenaskl.NewArena(m.arenaBuf)
+	m.points = opts.Experimental.MemTableKind.newPointKeys(m.arena, opts.Comparer)
+	m.rangeDelSkl.Reset(m.arena, m.cmp)
+	m.rangeKeySkl.Reset(m.arena, m.cmp)
+	m.emptySize = m.arena.Size()
+	m.reserved = m.emptySize
 }
 
 func (m *memTable) writerRef() {
@@ -206,15 +205,110 @@ func (m *memTable) apply(batch *Batch, seqNum base.SeqNum) error {
 		return base.CorruptionErrorf("pebble: batch seqnum %d is less than memtable creation seqnum %d",
 			errors.Safe(seqNum), errors.Safe(m.logSeqNum))
 	}
+	if numChunks := min(m.applyConcurrency, int(batch.Count()/memTableMinApplyChunkSize)); numChunks > 1 {
+		return m.applyConcurrently(batch, seqNum, numChunks)
+	}
+
+	endSeqNum, tombstoneCount, rangeKeyCount, err := m.applyEntries(batch.Reader(), seqNum, math.MaxUint32)
+	if err != nil {
+		return err
+	}
+	if endSeqNum != seqNum+base.SeqNum(batch.Count()) {
+		return base.CorruptionErrorf("pebble: inconsistent batch count: %d vs %d",
+			errors.Safe(endSeqNum), errors.Safe(seqNum+base.SeqNum(batch.Count())))
+	}
+	m.invalidateSpans(tombstoneCount, rangeKeyCount)
+	return nil
+}
+
+// memTableMinApplyChunkSize is the minimum number of entries applied by each
+// goroutine when a batch is applied concurrently (see
+// Options.Experimental.MemTableApplyConcurrency). Smaller chunks don't
+// amortize the cost of starting a goroutine.
+const memTableMinApplyChunkSize = 64
+
+// applyConcurrently applies the batch by splitting its entries into numChunks
+// contiguous chunks that are applied to the memtable concurrently. Every entry
+// of a batch has a distinct sequence number, so the order in which the entries
+// are inserted does not matter. The batch's sequence numbers are not published
+// until apply returns, so readers never observe a partially applied batch.
+func (m *memTable) applyConcurrently(batch *Batch, seqNum base.SeqNum, numChunks int) error {
+	count := batch.Count()
+	chunkSize := (count + uint32(numChunks) - 1) / uint32(numChunks)
+
+	// Find the start of each chunk. Decoding the batch is cheap relative to
+	// inserting its entries into the memtable.
+	type chunk struct {
+		r              batchrepr.Reader
+		seqNum         base.SeqNum
+		tombstoneCount uint32
+		rangeKeyCount  uint32
+		err            error
+	}
+	chunks := make([]chunk, 0, numChunks)
+	var n uint32
+	for r := batch.Reader(); ; {
+		start := r
+		kind, _, _, ok, err := r.Next()
+		if !ok {
+			if err != nil {
+				return err
+			}
+			break
+		}
+		if kind == InternalKeyKindLogData {
+			continue
+		}
+		if n%chunkSize == 0 {
+			chunks = append(chunks, chunk{r: start, seqNum: seqNum + base.SeqNum(n)})
+		}
+		n++
+	}
+	if n != count {
+		return base.CorruptionErrorf("pebble: inconsistent batch count: %d vs %d",
+			errors.Safe(seqNum+base.SeqNum(n)), errors.Safe(seqNum+base.SeqNum(count)))
+	}
+
+	applyChunk := func(c *chunk) {
+		_, c.tombstoneCount, c.rangeKeyCount, c.err = m.applyEntries(c.r, c.seqNum, chunkSize)
+	}
+	var wg sync.WaitGroup
+	for i := 1; i < len(chunks); i++ {
+		wg.Add(1)
+		go func(c *chunk) {
+			defer wg.Done()
+			applyChunk(c)
+		}(&chunks[i])
+	}
+	applyChunk(&chunks[0])
+	wg.Wait()
 
-	var ins arenaskl.Inserter
 	var tombstoneCount, rangeKeyCount uint32
-	startSeqNum := seqNum
-	for r := batch.Reader(); ; seqNum++ {
+	for i := range chunks {
+		if chunks[i].err != nil {
+			return chunks[i].err
+		}
+		tombstoneCount += chunks[i].tombstoneCount
+		rangeKeyCount += chunks[i].rangeKeyCount
+	}
+	m.invalidateSpans(tombstoneCount, rangeKeyCount)
+	return nil
+}
+
+// applyEntries applies the entries read from r to the memtable, assigning
+// sequence numbers starting at seqNum, until the reader is exhausted or n
+// entries have been applied. LogData entries are skipped and are not counted.
+// It returns the sequence number following the last applied entry, and the
+// number of range deletions and range keys applied.
+func (m *memTable) applyEntries(
+	r batchrepr.Reader, seqNum base.SeqNum, n uint32,
+) (_ base.SeqNum, tombstoneCount, rangeKeyCount uint32, _ error) {
+	var ins memTableInserter
+	for end := seqNum + base.SeqNum(n); seqNum < end; seqNum++ {
 		kind, ukey, value, ok, err := r.Next()
 		if !ok {
 			if err != nil {
-				return err
+				return 0, 0, 0, err
 			}
 			break
 		}
@@ -233,35 +327,37 @@ func (m *memTable) apply(batch *Batch, seqNum base.SeqNum) error {
 		case InternalKeyKindIngestSST, InternalKeyKindExcise:
 			panic("pebble: cannot apply ingested sstable or excise kind keys to memtable")
 		default:
-			err = ins.Add(&m.skl, ikey, value)
+			err = m.points.add(&ins, ikey, value)
 		}
 		if err != nil {
-			return err
+			return 0, 0, 0, err
 		}
 	}
-	if seqNum != startSeqNum+base.SeqNum(batch.Count()) {
-		return base.CorruptionErrorf("pebble: inconsistent batch count: %d vs %d",
-			errors.Safe(seqNum), errors.Safe(startSeqNum+base.SeqNum(batch.Count())))
-	}
+	m.points.finish(&ins)
+	return seqNum, tombstoneCount, rangeKeyCount, nil
+}
+
+// invalidateSpans invalidates the cached range deletion and range key
+// fragments after the provided number of each were applied.
+func (m *memTable) invalidateSpans(tombstoneCount, rangeKeyCount uint32) {
 	if tombstoneCount != 0 {
 		m.tombstones.invalidate(tombstoneCount)
 	}
 	if rangeKeyCount != 0 {
 		m.rangeKeys.invalidate(rangeKeyCount)
 	}
-	return nil
 }
 
 // newIter is part of the flushable interface. It returns an iterator that is
 // unpositioned (Iterator.Valid() will return false). The iterator can be
 // positioned via a call to SeekGE, SeekLT, First or Last.
 func (m *memTable) newIter(o *IterOptions) internalIterator {
-	return m.skl.NewIter(o.GetLowerBound(), o.GetUpperBound())
+	return m.points.newIter(o.GetLowerBound(), o.GetUpperBound())
 }
 
 // newFlushIter is part of the flushable interface.
 func (m *memTable) newFlushIter(o *IterOptions) internalIterator {
-	return m.skl.NewFlushIter()
+	return m.points.newFlushIter()
 }
 
 // newRangeDelIter is part of the flushable interface.
@@ -288,7 +384,7 @@ func (m *memTable) containsRangeKeys() bool {
 }
 
 func (m *memTable) availBytes() uint32 {
-	a := m.skl.Arena()
+	a := m.arena
 	if m.writerRefs.Load() == 1 {
 		// Note that one ref is maintained as long as the memtable is the
 		// current mutable memtable, so when evaluating whether the current
@@ -306,17 +402,17 @@ func (m *memTable) availBytes() uint32 {
 
 // inuseBytes is part of the flushable interface.
 func (m *memTable) inuseBytes() uint64 {
-	return uint64(m.skl.Size() - memTableEmptySize)
+	return uint64(m.arena.Size() - m.emptySize)
 }
 
 // totalBytes is part of the flushable interface.
 func (m *memTable) totalBytes() uint64 {
-	return uint64(m.skl.Arena().Capacity())
+	return uint64(m.arena.Capacity())
 }
 
 // empty returns whether the MemTable has no key/value pairs.
 func (m *memTable) empty() bool {
-	return m.skl.Size() == memTableEmptySize
+	return m.arena.Size() == m.emptySize
 }
 
 // computePossibleOverlaps is part of the flushable interface.
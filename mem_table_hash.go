@@ -0,0 +1,248 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"context"
+	"fmt"
+	"hash/maphash"
+
+	"github.com/cockroachdb/pebble/internal/arenaskl"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/treeprinter"
+)
+
+// HashBucketMemTable returns a MemTableKind that partitions point keys across
+// numBuckets skiplists by a hash of the key's prefix, as determined by
+// Comparer.Split. All the keys sharing a prefix are held in the same
+// skiplist, so prefix seeks (Iterator.SeekPrefixGE) and the point lookups
+// built on them (DB.Get) only search a skiplist holding a fraction of the
+// memtable's keys. Inserts are similarly cheaper.
+//
+// Iteration that is not confined to a prefix merges all of the buckets, which
+// is considerably more expensive than iterating a single skiplist. The hash
+// bucket memtable is intended for point-lookup-heavy workloads with a Split
+// function that distinguishes prefixes. Each bucket consumes a small fixed
+// amount of the memtable's arena even when empty.
+func HashBucketMemTable(numBuckets int) MemTableKind {
+	if numBuckets <= 0 {
+		panic(fmt.Sprintf("pebble: invalid number of memtable hash buckets: %d", numBuckets))
+	}
+	return &hashBucketMemTableKind{numBuckets: numBuckets}
+}
+
+type hashBucketMemTableKind struct {
+	numBuckets int
+}
+
+// Name implements MemTableKind.
+func (f *hashBucketMemTableKind) Name() string {
+	return fmt.Sprintf("hash_bucket(%d)", f.numBuckets)
+}
+
+func (f *hashBucketMemTableKind) newPointKeys(
+	arena *arenaskl.Arena, comparer *base.Comparer,
+) memTablePointKeys {
+	p := &hashBucketPointKeys{
+		comparer: comparer,
+		seed:     maphash.MakeSeed(),
+		buckets:  make([]arenaskl.Skiplist, f.numBuckets),
+	}
+	for i := range p.buckets {
+		p.buckets[i].Reset(arena, comparer.Compare)
+	}
+	return p
+}
+
+func (f *hashBucketMemTableKind) pointKeysEmptySize() uint32 {
+	return uint32(f.numBuckets) * emptySkiplistSize
+}
+
+// hashBucketPointKeys implements memTablePointKeys using a skiplist per hash
+// bucket.
+type hashBucketPointKeys struct {
+	comparer *base.Comparer
+	seed     maphash.Seed
+	buckets  []arenaskl.Skiplist
+}
+
+// bucket returns the index of the bucket holding the keys with the provided
+// prefix.
+func (p *hashBucketPointKeys) bucket(prefix []byte) int {
+	return int(maphash.Bytes(p.seed, prefix) % uint64(len(p.buckets)))
+}
+
+func (p *hashBucketPointKeys) add(ins *memTableInserter, key base.InternalKey, value []byte) error {
+	// NB: The inserter's cached splice is specific to a skiplist, so it can't
+	// be shared across the keys of a batch that land in different buckets.
+	return p.buckets[p.bucket(key.UserKey[:p.comparer.Split(key.UserKey)])].Add(key, value)
+}
+
+func (p *hashBucketPointKeys) finish(*memTableInserter) {}
+
+func (p *hashBucketPointKeys) newIter(lower, upper []byte) internalIterator {
+	return &hashBucketIter{p: p, lower: lower, upper: upper, prefixBucket: -1}
+}
+
+func (p *hashBucketPointKeys) newFlushIter() internalIterator {
+	return p.newIter(nil, nil)
+}
+
+// hashBucketIter is an iterator over a hash bucket memtable. Prefix seeks are
+// served by an iterator over the prefix's bucket; all other operations are
+// served by a merging iterator over every bucket, which is constructed lazily.
+type hashBucketIter struct {
+	p     *hashBucketPointKeys
+	lower []byte
+	upper []byte
+	// merged is the merging iterator over all of the buckets.
+	merged *mergingIter
+	stats  base.InternalIteratorStats
+	// prefixIter is the iterator over the bucket prefixBucket.
+	prefixIter   *arenaskl.Iterator
+	prefixBucket int
+	// cur is the iterator that was last positioned: either merged or
+	// prefixIter.
+	cur internalIterator
+}
+
+var _ base.InternalIterator = (*hashBucketIter)(nil)
+
+func (it *hashBucketIter) useMerged() {
+	if it.merged == nil {
+		levels := make([]mergingIterLevel, len(it.p.buckets))
+		for i := range it.p.buckets {
+			levels[i].iter = it.p.buckets[i].NewIter(it.lower, it.upper)
+		}
+		// NB: The merging iterator must be aware of the bounds, since it
+		// relies on them when switching directions.
+		it.merged = &mergingIter{}
+		it.merged.init(&IterOptions{LowerBound: it.lower, UpperBound: it.upper},
+			&it.stats, it.p.comparer.Compare, it.p.comparer.Split, levels...)
+	}
+	it.cur = it.merged
+}
+
+// SeekGE implements base.InternalIterator.
+func (it *hashBucketIter) SeekGE(key []byte, flags base.SeekGEFlags) *base.InternalKV {
+	if it.merged == nil || it.cur != internalIterator(it.merged) {
+		flags = flags.DisableTrySeekUsingNext()
+		it.useMerged()
+	}
+	return it.merged.SeekGE(key, flags)
+}
+
+// SeekPrefixGE implements base.InternalIterator. Only the bucket holding the
+// prefix is searched. Subsequent calls to Next may return keys from the bucket
+// that do not match the prefix, but they are returned in key order.
+func (it *hashBucketIter) SeekPrefixGE(
+	prefix, key []byte, flags base.SeekGEFlags,
+) *base.InternalKV {
+	b := it.p.bucket(prefix)
+	if it.prefixIter == nil || b != it.prefixBucket {
+		if it.prefixIter != nil {
+			_ = it.prefixIter.Close()
+		}
+		it.prefixIter = it.p.buckets[b].NewIter(it.lower, it.upper)
+		it.prefixBucket = b
+		flags = flags.DisableTrySeekUsingNext()
+	} else if it.cur != internalIterator(it.prefixIter) {
+		flags = flags.DisableTrySeekUsingNext()
+	}
+	it.cur = it.prefixIter
+	return it.prefixIter.SeekPrefixGE(prefix, key, flags)
+}
+
+// SeekLT implements base.InternalIterator.
+func (it *hashBucketIter) SeekLT(key []byte, flags base.SeekLTFlags) *base.InternalKV {
+	it.useMerged()
+	return it.merged.SeekLT(key, flags)
+}
+
+// First implements base.InternalIterator.
+func (it *hashBucketIter) First() *base.InternalKV {
+	it.useMerged()
+	return it.merged.First()
+}
+
+// Last implements base.InternalIterator.
+func (it *hashBucketIter) Last() *base.InternalKV {
+	it.useMerged()
+	return it.merged.Last()
+}
+
+// Next implements base.InternalIterator.
+func (it *hashBucketIter) Next() *base.InternalKV {
+	if it.cur == nil {
+		it.useMerged()
+	}
+	return it.cur.Next()
+}
+
+// NextPrefix implements base.InternalIterator.
+func (it *hashBucketIter) NextPrefix(succKey []byte) *base.InternalKV {
+	if it.cur == nil {
+		it.useMerged()
+	}
+	return it.cur.NextPrefix(succKey)
+}
+
+// Prev implements base.InternalIterator. Reverse iteration is not supported
+// in prefix iteration mode.
+func (it *hashBucketIter) Prev() *base.InternalKV {
+	if it.cur == nil {
+		it.useMerged()
+	}
+	return it.cur.Prev()
+}
+
+// Error implements base.InternalIterator.
+func (it *hashBucketIter) Error() error {
+	if it.merged != nil {
+		return it.merged.Error()
+	}
+	return nil
+}
+
+// Close implements base.InternalIterator.
+func (it *hashBucketIter) Close() error {
+	var err error
+	if it.merged != nil {
+		err = it.merged.Close()
+	}
+	if it.prefixIter != nil {
+		_ = it.prefixIter.Close()
+	}
+	*it = hashBucketIter{}
+	return err
+}
+
+// SetBounds implements base.InternalIterator.
+func (it *hashBucketIter) SetBounds(lower, upper []byte) {
+	it.lower = lower
+	it.upper = upper
+	if it.merged != nil {
+		it.merged.SetBounds(lower, upper)
+	}
+	if it.prefixIter != nil {
+		it.prefixIter.SetBounds(lower, upper)
+	}
+	it.cur = nil
+}
+
+// SetContext implements base.InternalIterator.
+func (it *hashBucketIter) SetContext(_ context.Context) {}
+
+func (it *hashBucketIter) String() string {
+	return "memtable"
+}
+
+// DebugTree is part of the InternalIterator interface.
+func (it *hashBucketIter) DebugTree(tp treeprinter.Node) {
+	n := tp.Childf("%T(%p)", it, it)
+	if it.merged != nil {
+		it.merged.DebugTree(n)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble/internal/arenaskl"
+	"github.com/cockroachdb/pebble/internal/base"
+)
+
+// MemTableKind selects the data structure used by a memtable to hold point
+// keys. Range deletions and range keys are always held in skiplists. All of
+// the memtable's structures are allocated from a single fixed-size arena (see
+// Options.MemTableSize), so the choice of kind does not change the memtable's
+// memory footprint, only how point keys are organized within it.
+//
+// MemTableKind is a closed set of the kinds built into Pebble: SkiplistMemTable
+// (the default), VectorMemTable and HashBucketMemTable. It cannot be
+// implemented outside of this package. Every kind supports concurrent inserts.
+type MemTableKind interface {
+	// Name returns the name of the kind. The name is persisted in the OPTIONS
+	// file and is parsed by ParseMemTableKind.
+	Name() string
+
+	// newPointKeys returns a new, empty point key structure allocated from the
+	// provided arena.
+	newPointKeys(arena *arenaskl.Arena, comparer *base.Comparer) memTablePointKeys
+	// pointKeysEmptySize returns the number of bytes allocated from the arena
+	// by an empty point key structure.
+	pointKeysEmptySize() uint32
+}
+
+// memTablePointKeys holds the point keys of a memTable.
+//
+// Every implementation must be safe for concurrent use: add and finish are
+// called concurrently, each with its own inserter, by commits applying their
+// batches and by the goroutines applying the chunks of a single batch (see
+// Options.Experimental.MemTableApplyConcurrency), and newIter and newFlushIter
+// are called concurrently with both.
+type memTablePointKeys interface {
+	// add adds the key/value pair to the structure. The inserter is scoped to
+	// the application of a single batch.
+	add(ins *memTableInserter, key base.InternalKey, value []byte) error
+	// finish is called once all of the keys of a batch have been added with
+	// the batch's inserter. Keys added with an inserter are only guaranteed to
+	// be visible to iterators created after finish returns.
+	finish(ins *memTableInserter)
+	// newIter returns an iterator over the point keys, constrained to the
+	// provided bounds.
+	newIter(lower, upper []byte) internalIterator
+	// newFlushIter returns an iterator used to flush the point keys. It only
+	// needs to support First and Next.
+	newFlushIter() internalIterator
+}
+
+// memTableInserter holds per-batch insertion state for memTablePointKeys.
+type memTableInserter struct {
+	skl arenaskl.Inserter
+	// offsets holds the arena offsets of the entries added to a vector
+	// memtable that have not yet been published.
+	offsets []uint32
+}
+
+// emptySkiplistSize is the number of bytes allocated from an arena by an empty
+// arenaskl.Skiplist.
+var emptySkiplistSize = func() uint32 {
+	var skl arenaskl.Skiplist
+	arena := arenaskl.NewArena(make([]byte, 16<<10 /* 16 KB */))
+	before := arena.Size()
+	skl.Reset(arena, bytes.Compare)
+	return arena.Size() - before
+}()
+
+// memTableEmptySize returns the amount of allocated space in the arena when a
+// memtable of the provided kind is empty. The arena holds the
+// point keys and two skiplists for range deletions and range keys, and never
+// allocates its first byte.
+func memTableEmptySize(f MemTableKind) uint32 {
+	return 1 + 2*emptySkiplistSize + f.pointKeysEmptySize()
+}
+
+// SkiplistMemTable holds point keys in a lock-free, arena-backed skiplist. It
+// provides efficient inserts, point lookups and iteration, with forward and
+// reverse iteration performing equally.
+var SkiplistMemTable MemTableKind = skiplistMemTableKind{}
+
+type skiplistMemTableKind struct{}
+
+// Name implements MemTableKind.
+func (skiplistMemTableKind) Name() string { return "skiplist" }
+
+func (skiplistMemTableKind) newPointKeys(
+	arena *arenaskl.Arena, comparer *base.Comparer,
+) memTablePointKeys {
+	p := &skiplistPointKeys{}
+	p.skl.Reset(arena, comparer.Compare)
+	return p
+}
+
+func (skiplistMemTableKind) pointKeysEmptySize() uint32 { return emptySkiplistSize }
+
+// skiplistPointKeys implements memTablePointKeys using an arenaskl.Skiplist.
+type skiplistPointKeys struct {
+	skl arenaskl.Skiplist
+}
+
+func (p *skiplistPointKeys) add(ins *memTableInserter, key base.InternalKey, value []byte) error {
+	return ins.skl.Add(&p.skl, key, value)
+}
+
+func (p *skiplistPointKeys) finish(*memTableInserter) {}
+
+func (p *skiplistPointKeys) newIter(lower, upper []byte) internalIterator {
+	return p.skl.NewIter(lower, upper)
+}
+
+func (p *skiplistPointKeys) newFlushIter() internalIterator {
+	return p.skl.NewFlushIter()
+}
+
+// ParseMemTableKind parses the name of a MemTableKind, as returned by
+// MemTableKind.Name.
+func ParseMemTableKind(name string) (MemTableKind, error) {
+	switch name {
+	case SkiplistMemTable.Name():
+		return SkiplistMemTable, nil
+	case VectorMemTable.Name():
+		return VectorMemTable, nil
+	}
+	if s, ok := strings.CutPrefix(name, "hash_bucket("); ok {
+		if s, ok = strings.CutSuffix(s, ")"); ok {
+			n, err := strconv.Atoi(s)
+			if err == nil && n > 0 {
+				return HashBucketMemTable(n), nil
+			}
+		}
+	}
+	return nil, errors.Errorf("pebble: unknown memtable kind: %q", errors.Safe(name))
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -19,7 +20,10 @@ import (
 	"github.com/cockroachdb/pebble/internal/arenaskl"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/itertest"
+	"github.com/cockroachdb/pebble/internal/keyspan"
 	"github.com/cockroachdb/pebble/internal/rangekey"
+	"github.com/cockroachdb/pebble/internal/testkeys"
+	"github.com/cockroachdb/pebble/vfs"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
 	"golang.org/x/sync/errgroup"
@@ -28,7 +32,8 @@ import (
 // get gets the value for the given key. It returns ErrNotFound if the DB does
 // not contain the key.
 func (m *memTable) get(key []byte) (value []byte, err error) {
-	it := m.skl.NewIter(nil, nil)
+	it := m.newIter(nil)
+	defer it.Close()
 	kv := it.SeekGE(key, base.SeekGEFlagsNone)
 	if kv == nil {
 		return nil, ErrNotFound
@@ -62,7 +67,12 @@ func (m *memTable) set(key InternalKey, value []byte) error {
 		m.rangeKeys.invalidate(1)
 		return nil
 	}
-	return m.skl.Add(key, value)
+	var ins memTableInserter
+	if err := m.points.add(&ins, key, value); err != nil {
+		return err
+	}
+	m.points.finish(&ins)
+	return nil
 }
 
 // count returns the number of entries in a DB.
@@ -227,13 +237,25 @@ func TestMemTable1000Entries(t *testing.T) {
 }
 
 func TestMemTableIter(t *testing.T) {
+	for _, kind := range []MemTableKind{
+		SkiplistMemTable, VectorMemTable, HashBucketMemTable(4),
+	} {
+		t.Run(kind.Name(), func(t *testing.T) {
+			testMemTableIter(t, kind)
+		})
+	}
+}
+
+func testMemTableIter(t *testing.T, kind MemTableKind) {
+	opts := &Options{}
+	opts.Experimental.MemTableKind = kind
 	var mem *memTable
 	for _, testdata := range []string{
 		"testdata/internal_iter_next", "testdata/internal_iter_bounds"} {
 		datadriven.RunTest(t, testdata, func(t *testing.T, d *datadriven.TestData) string {
 			switch d.Cmd {
 			case "define":
-				mem = newMemTable(memTableOptions{})
+				mem = newMemTable(memTableOptions{Options: opts})
 				for _, key := range strings.Split(d.Input, "\n") {
 					j := strings.Index(key, ":")
 					if err := mem.set(base.ParseInternalKey(key[:j]), []byte(key[j+1:])); err != nil {
@@ -273,6 +295,221 @@ func TestMemTableIter(t *testing.T) {
 	}
 }
 
+// TestMemTableKinds writes to a DB configured with each MemTableKind
+// across many memtable rotations, checking that point lookups, prefix seeks
+// and full iteration in both directions observe the expected keys.
+func TestMemTableKinds(t *testing.T) {
+	for _, kind := range []MemTableKind{
+		SkiplistMemTable, VectorMemTable, HashBucketMemTable(64),
+	} {
+		t.Run(kind.Name(), func(t *testing.T) {
+			opts := &Options{
+				Comparer:     testkeys.Comparer,
+				FS:           vfs.NewMem(),
+				MemTableSize: 256 << 10,
+			}
+			opts.Experimental.MemTableKind = kind
+			d, err := Open("", opts)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, d.Close()) }()
+
+			rng := rand.New(rand.NewSource(uint64(time.Now().UnixNano())))
+			ks := testkeys.Alpha(2)
+			expected := map[string]string{}
+			for i := 0; i < 200; i++ {
+				b := d.NewBatch()
+				for j := 0; j < 100; j++ {
+					k := testkeys.KeyAt(ks, rng.Int63n(ks.Count()), rng.Int63n(3))
+					if rng.Intn(10) == 0 {
+						require.NoError(t, b.Delete(k, nil))
+						delete(expected, string(k))
+						continue
+					}
+					v := fmt.Sprintf("%d-%d", i, j)
+					require.NoError(t, b.Set(k, []byte(v), nil))
+					expected[string(k)] = v
+				}
+				require.NoError(t, b.Commit(nil))
+
+				// Point lookups are served by prefix seeks.
+				for j := 0; j < 10; j++ {
+					k := testkeys.KeyAt(ks, rng.Int63n(ks.Count()), rng.Int63n(3))
+					v, closer, err := d.Get(k)
+					if want, ok := expected[string(k)]; ok {
+						require.NoError(t, err)
+						require.Equal(t, want, string(v))
+						require.NoError(t, closer.Close())
+					} else {
+						require.ErrorIs(t, err, ErrNotFound)
+					}
+				}
+			}
+			require.Greater(t, d.Metrics().Flush.Count, int64(0))
+
+			var keys []string
+			for k := range expected {
+				keys = append(keys, k)
+			}
+			slices.SortFunc(keys, func(a, b string) int {
+				return testkeys.Comparer.Compare([]byte(a), []byte(b))
+			})
+			iter, err := d.NewIter(nil)
+			require.NoError(t, err)
+			var got []string
+			for valid := iter.First(); valid; valid = iter.Next() {
+				require.Equal(t, expected[string(iter.Key())], string(iter.Value()))
+				got = append(got, string(iter.Key()))
+			}
+			require.Equal(t, keys, got)
+			got = got[:0]
+			for valid := iter.Last(); valid; valid = iter.Prev() {
+				got = append(got, string(iter.Key()))
+			}
+			slices.Reverse(got)
+			require.Equal(t, keys, got)
+			for i := 0; i < 100; i++ {
+				prefix := testkeys.Key(ks, rng.Int63n(ks.Count()))
+				want := []string{}
+				for _, k := range keys {
+					if bytes.Equal(prefix, []byte(k)[:testkeys.Comparer.Split([]byte(k))]) {
+						want = append(want, k)
+					}
+				}
+				got = got[:0]
+				for valid := iter.SeekPrefixGE(prefix); valid; valid = iter.Next() {
+					got = append(got, string(iter.Key()))
+				}
+				require.Equal(t, want, got)
+			}
+			require.NoError(t, iter.Close())
+		})
+	}
+}
+
+// TestMemTableApplyConcurrently checks that applying a batch concurrently
+// produces the same memtable contents as applying it from a single goroutine.
+func TestMemTableApplyConcurrently(t *testing.T) {
+	rng := rand.New(rand.NewSource(uint64(time.Now().UnixNano())))
+	b := newBatch(nil)
+	for i := 0; i < 1000; i++ {
+		k := []byte(fmt.Sprintf("%04d", rng.Intn(500)))
+		switch rng.Intn(20) {
+		case 0:
+			require.NoError(t, b.LogData(k, nil))
+		case 1:
+			require.NoError(t, b.DeleteRange(k, append(k, 'x'), nil))
+		case 2:
+			require.NoError(t, b.RangeKeySet(k, append(k, 'x'), nil, k, nil))
+		case 3:
+			require.NoError(t, b.Delete(k, nil))
+		default:
+			require.NoError(t, b.Set(k, []byte(fmt.Sprint(i)), nil))
+		}
+	}
+
+	// describe returns the contents of the memtable after applying the batch
+	// with the provided concurrency.
+	describe := func(kind MemTableKind, concurrency int) string {
+		opts := &Options{}
+		opts.Experimental.MemTableKind = kind
+		opts.Experimental.MemTableApplyConcurrency = concurrency
+		m := newMemTable(memTableOptions{Options: opts})
+		require.NoError(t, m.prepare(b))
+		require.NoError(t, m.apply(b, 10))
+		var buf strings.Builder
+		it := m.newIter(nil)
+		for kv := it.First(); kv != nil; kv = it.Next() {
+			fmt.Fprintf(&buf, "%s:%s\n", kv.K, kv.InPlaceValue())
+		}
+		require.NoError(t, it.Close())
+		for _, iter := range []keyspan.FragmentIterator{m.newRangeDelIter(nil), m.newRangeKeyIter(nil)} {
+			s, err := iter.First()
+			for ; s != nil; s, err = iter.Next() {
+				fmt.Fprintf(&buf, "%s\n", s)
+			}
+			require.NoError(t, err)
+			iter.Close()
+		}
+		return buf.String()
+	}
+	for _, kind := range []MemTableKind{
+		SkiplistMemTable, VectorMemTable, HashBucketMemTable(8),
+	} {
+		t.Run(kind.Name(), func(t *testing.T) {
+			expected := describe(kind, 1)
+			for _, concurrency := range []int{2, 3, 8} {
+				require.Equal(t, expected, describe(kind, concurrency))
+			}
+		})
+	}
+}
+
+// TestMemTableConcurrentApply applies batches from several goroutines at once,
+// each split across goroutines, to memtables of each kind. Each worker
+// verifies that its keys are visible once its batch is applied while the other
+// workers continue to insert.
+func TestMemTableConcurrentApply(t *testing.T) {
+	for _, kind := range []MemTableKind{
+		SkiplistMemTable, VectorMemTable, HashBucketMemTable(8),
+	} {
+		t.Run(kind.Name(), func(t *testing.T) {
+			opts := &Options{MemTableSize: 64 << 20}
+			opts.Experimental.MemTableKind = kind
+			opts.Experimental.MemTableApplyConcurrency = 4
+			m := newMemTable(memTableOptions{Options: opts})
+
+			const workers = 8
+			const batches = 50
+			const keysPerBatch = 100
+			eg, _ := errgroup.WithContext(context.Background())
+			var seqNum base.AtomicSeqNum
+			seqNum.Store(1)
+			for i := 0; i < workers; i++ {
+				i := i
+				eg.Go(func() error {
+					lower := []byte(fmt.Sprintf("%02d-", i))
+					upper := []byte(fmt.Sprintf("%02d.", i))
+					for j := 0; j < batches; j++ {
+						b := newBatch(nil)
+						for k := 0; k < keysPerBatch; k++ {
+							key := fmt.Sprintf("%02d-%03d-%03d", i, j, k)
+							if err := b.Set([]byte(key), []byte(key), nil); err != nil {
+								return err
+							}
+						}
+						if err := m.prepare(b); err != nil {
+							return err
+						}
+						n := seqNum.Add(base.SeqNum(b.Count())) - base.SeqNum(b.Count())
+						if err := m.apply(b, n); err != nil {
+							return err
+						}
+						b.Close()
+
+						var count int
+						it := m.newIter(&IterOptions{LowerBound: lower, UpperBound: upper})
+						for kv := it.SeekGE(lower, base.SeekGEFlagsNone); kv != nil; kv = it.Next() {
+							if !bytes.Equal(kv.K.UserKey, kv.InPlaceValue()) {
+								return errors.Errorf("%d: key %q has value %q", i, kv.K.UserKey, kv.InPlaceValue())
+							}
+							count++
+						}
+						if err := it.Close(); err != nil {
+							return err
+						}
+						if expected := (j + 1) * keysPerBatch; count != expected {
+							return errors.Errorf("%d: expected %d keys, but found %d", i, expected, count)
+						}
+					}
+					return nil
+				})
+			}
+			require.NoError(t, eg.Wait())
+			require.Equal(t, workers*batches*keysPerBatch, m.count())
+		})
+	}
+}
+
 func TestMemTableDeleteRange(t *testing.T) {
 	var mem *memTable
 	var seqNum base.SeqNum
@@ -371,7 +608,7 @@ func TestMemTableReserved(t *testing.T) {
 	m.writerRef()
 	// The initial reservation accounts for the already allocated bytes from the
 	// arena.
-	require.Equal(t, m.reserved, m.skl.Arena().Size())
+	require.Equal(t, m.reserved, m.arena.Size())
 	b := newBatch(nil)
 	b.Set([]byte("blueberry"), []byte("pie"), nil)
 	require.NotEqual(t, 0, int(b.memTableSize))
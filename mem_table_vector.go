@@ -0,0 +1,288 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"context"
+	"encoding/binary"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/pebble/internal/arenaskl"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/treeprinter"
+)
+
+// VectorMemTable holds point keys in an append-only vector that is only
+// sorted when the memtable is read. Inserts are a copy into the arena and an
+// append, avoiding the cost of maintaining a skiplist, which makes the vector
+// memtable well suited to bulk loading where the memtable is written but rarely
+// read before being flushed.
+//
+// Creating an iterator sorts the entries added since the previous iterator was
+// created and merges them into a new sorted vector, which is O(n) in the number
+// of entries in the memtable. Workloads that interleave reads and writes should
+// use SkiplistMemTable instead. An iterator only observes the entries that were
+// present when it was created.
+var VectorMemTable MemTableKind = vectorMemTableKind{}
+
+type vectorMemTableKind struct{}
+
+// Name implements MemTableKind.
+func (vectorMemTableKind) Name() string { return "vector" }
+
+func (vectorMemTableKind) newPointKeys(
+	arena *arenaskl.Arena, comparer *base.Comparer,
+) memTablePointKeys {
+	return &vectorPointKeys{arena: arena, cmp: comparer.Compare}
+}
+
+func (vectorMemTableKind) pointKeysEmptySize() uint32 { return 0 }
+
+// vectorEntryHeaderLen is the length of the header preceding the key and value
+// of each entry of a vector memtable: the key trailer (8 bytes), followed by
+// the key length (4 bytes) and the value length (4 bytes).
+const vectorEntryHeaderLen = 16
+
+// vectorPointKeys implements memTablePointKeys using an append-only vector of
+// arena offsets. Each offset points to an entry holding a header, key and
+// value.
+type vectorPointKeys struct {
+	arena *arenaskl.Arena
+	cmp   base.Compare
+	mu    struct {
+		sync.Mutex
+		// sorted holds the offsets of the entries merged by the most recent
+		// call to newIter, in key order. The slice is never modified once
+		// published, allowing iterators to share it.
+		sorted []uint32
+		// unsorted holds the offsets of the entries added since the most
+		// recent call to newIter, in insertion order.
+		unsorted []uint32
+	}
+}
+
+func (p *vectorPointKeys) add(ins *memTableInserter, key base.InternalKey, value []byte) error {
+	buf, offset, err := p.arena.AllocBytes(uint32(vectorEntryHeaderLen + len(key.UserKey) + len(value)))
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint64(buf[0:8], uint64(key.Trailer))
+	binary.LittleEndian.PutUint32(buf[8:12], uint32(len(key.UserKey)))
+	binary.LittleEndian.PutUint32(buf[12:16], uint32(len(value)))
+	copy(buf[vectorEntryHeaderLen:], key.UserKey)
+	copy(buf[vectorEntryHeaderLen+len(key.UserKey):], value)
+	ins.offsets = append(ins.offsets, offset)
+	return nil
+}
+
+func (p *vectorPointKeys) finish(ins *memTableInserter) {
+	if len(ins.offsets) == 0 {
+		return
+	}
+	p.mu.Lock()
+	p.mu.unsorted = append(p.mu.unsorted, ins.offsets...)
+	p.mu.Unlock()
+	ins.offsets = ins.offsets[:0]
+}
+
+// decode decodes the entry at the provided offset.
+func (p *vectorPointKeys) decode(offset uint32) (base.InternalKey, []byte) {
+	hdr := p.arena.Bytes(offset, vectorEntryHeaderLen)
+	keyLen := binary.LittleEndian.Uint32(hdr[8:12])
+	valueLen := binary.LittleEndian.Uint32(hdr[12:16])
+	k := base.InternalKey{
+		UserKey: p.arena.Bytes(offset+vectorEntryHeaderLen, keyLen),
+		Trailer: base.InternalKeyTrailer(binary.LittleEndian.Uint64(hdr[0:8])),
+	}
+	return k, p.arena.Bytes(offset+vectorEntryHeaderLen+keyLen, valueLen)
+}
+
+// userKey returns the user key of the entry at the provided offset.
+func (p *vectorPointKeys) userKey(offset uint32) []byte {
+	hdr := p.arena.Bytes(offset, vectorEntryHeaderLen)
+	return p.arena.Bytes(offset+vectorEntryHeaderLen, binary.LittleEndian.Uint32(hdr[8:12]))
+}
+
+func (p *vectorPointKeys) compare(a, b uint32) int {
+	ak, _ := p.decode(a)
+	bk, _ := p.decode(b)
+	return base.InternalCompare(p.cmp, ak, bk)
+}
+
+// snapshot returns the offsets of all the published entries in key order,
+// sorting and merging the entries added since the previous snapshot.
+func (p *vectorPointKeys) snapshot() []uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.mu.unsorted) == 0 {
+		return p.mu.sorted
+	}
+	slices.SortFunc(p.mu.unsorted, p.compare)
+	a, b := p.mu.sorted, p.mu.unsorted
+	merged := make([]uint32, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if p.compare(a[0], b[0]) <= 0 {
+			merged = append(merged, a[0])
+			a = a[1:]
+		} else {
+			merged = append(merged, b[0])
+			b = b[1:]
+		}
+	}
+	merged = append(merged, a...)
+	merged = append(merged, b...)
+	p.mu.sorted = merged
+	p.mu.unsorted = p.mu.unsorted[:0]
+	return merged
+}
+
+func (p *vectorPointKeys) newIter(lower, upper []byte) internalIterator {
+	return &vectorIter{p: p, offsets: p.snapshot(), lower: lower, upper: upper, pos: -1}
+}
+
+func (p *vectorPointKeys) newFlushIter() internalIterator {
+	return p.newIter(nil, nil)
+}
+
+// vectorIter is an iterator over a sorted snapshot of a vector memtable.
+type vectorIter struct {
+	p       *vectorPointKeys
+	offsets []uint32
+	// pos is the index of the current entry within offsets. It is -1 when the
+	// iterator is positioned before the first entry and len(offsets) when it
+	// is positioned after the last entry.
+	pos   int
+	lower []byte
+	upper []byte
+	kv    base.InternalKV
+}
+
+var _ base.InternalIterator = (*vectorIter)(nil)
+
+// search returns the index of the first entry at or after start with a user
+// key greater than or equal to key.
+func (it *vectorIter) search(start int, key []byte) int {
+	return start + sort.Search(len(it.offsets)-start, func(i int) bool {
+		return it.p.cmp(it.p.userKey(it.offsets[start+i]), key) >= 0
+	})
+}
+
+// loadForward loads the entry at pos, returning nil if the iterator is
+// exhausted or the entry is at or past the upper bound.
+func (it *vectorIter) loadForward() *base.InternalKV {
+	if it.pos >= len(it.offsets) {
+		it.pos = len(it.offsets)
+		return nil
+	}
+	it.load()
+	if it.upper != nil && it.p.cmp(it.upper, it.kv.K.UserKey) <= 0 {
+		return nil
+	}
+	return &it.kv
+}
+
+// loadBackward loads the entry at pos, returning nil if the iterator is
+// exhausted or the entry is before the lower bound.
+func (it *vectorIter) loadBackward() *base.InternalKV {
+	if it.pos < 0 {
+		it.pos = -1
+		return nil
+	}
+	it.load()
+	if it.lower != nil && it.p.cmp(it.lower, it.kv.K.UserKey) > 0 {
+		return nil
+	}
+	return &it.kv
+}
+
+func (it *vectorIter) load() {
+	var value []byte
+	it.kv.K, value = it.p.decode(it.offsets[it.pos])
+	it.kv.V = base.MakeInPlaceValue(value)
+}
+
+// SeekGE implements base.InternalIterator. Like the skiplist iterator, it only
+// checks the upper bound.
+func (it *vectorIter) SeekGE(key []byte, flags base.SeekGEFlags) *base.InternalKV {
+	start := 0
+	if flags.TrySeekUsingNext() && it.pos >= 0 {
+		if it.pos >= len(it.offsets) {
+			return nil
+		}
+		start = it.pos
+	}
+	it.pos = it.search(start, key)
+	return it.loadForward()
+}
+
+// SeekPrefixGE implements base.InternalIterator.
+func (it *vectorIter) SeekPrefixGE(prefix, key []byte, flags base.SeekGEFlags) *base.InternalKV {
+	return it.SeekGE(key, flags)
+}
+
+// SeekLT implements base.InternalIterator. Like the skiplist iterator, it only
+// checks the lower bound.
+func (it *vectorIter) SeekLT(key []byte, flags base.SeekLTFlags) *base.InternalKV {
+	it.pos = it.search(0, key) - 1
+	return it.loadBackward()
+}
+
+// First implements base.InternalIterator.
+func (it *vectorIter) First() *base.InternalKV {
+	it.pos = 0
+	return it.loadForward()
+}
+
+// Last implements base.InternalIterator.
+func (it *vectorIter) Last() *base.InternalKV {
+	it.pos = len(it.offsets) - 1
+	return it.loadBackward()
+}
+
+// Next implements base.InternalIterator.
+func (it *vectorIter) Next() *base.InternalKV {
+	it.pos++
+	return it.loadForward()
+}
+
+// NextPrefix implements base.InternalIterator.
+func (it *vectorIter) NextPrefix(succKey []byte) *base.InternalKV {
+	return it.SeekGE(succKey, base.SeekGEFlagsNone.EnableTrySeekUsingNext())
+}
+
+// Prev implements base.InternalIterator.
+func (it *vectorIter) Prev() *base.InternalKV {
+	it.pos--
+	return it.loadBackward()
+}
+
+// Error implements base.InternalIterator.
+func (it *vectorIter) Error() error { return nil }
+
+// Close implements base.InternalIterator.
+func (it *vectorIter) Close() error {
+	*it = vectorIter{}
+	return nil
+}
+
+// SetBounds implements base.InternalIterator.
+func (it *vectorIter) SetBounds(lower, upper []byte) {
+	it.lower = lower
+	it.upper = upper
+}
+
+// SetContext implements base.InternalIterator.
+func (it *vectorIter) SetContext(_ context.Context) {}
+
+func (it *vectorIter) String() string {
+	return "memtable"
+}
+
+// DebugTree is part of the InternalIterator interface.
+func (it *vectorIter) DebugTree(tp treeprinter.Node) {
+	tp.Childf("%T(%p)", it, it)
+}
@@ -21,6 +21,7 @@ const (
 	OpDBFlush
 	OpDBRatchetFormatMajorVersion
 	OpDBRestart
+	OpDBSetCreatorID
 	OpIterClose
 	OpIterFirst
 	OpIterLast
@@ -147,6 +148,7 @@ func DefaultOpConfig() OpConfig {
 			OpDBFlush:                     2,
 			OpDBRatchetFormatMajorVersion: 1,
 			OpDBRestart:                   2,
+			OpDBSetCreatorID:              1,
 			OpIterClose:                   5,
 			OpIterFirst:                   100,
 			OpIterLast:                    100,
@@ -208,6 +210,7 @@ func ReadOpConfig() OpConfig {
 			OpDBFlush:                     0,
 			OpDBRatchetFormatMajorVersion: 0,
 			OpDBRestart:                   0,
+			OpDBSetCreatorID:              0,
 			OpIterClose:                   5,
 			OpIterFirst:                   100,
 			OpIterLast:                    100,
@@ -266,6 +269,7 @@ func WriteOpConfig() OpConfig {
 			OpDBFlush:                     2,
 			OpDBRatchetFormatMajorVersion: 1,
 			OpDBRestart:                   2,
+			OpDBSetCreatorID:              0,
 			OpIterClose:                   0,
 			OpIterFirst:                   0,
 			OpIterLast:                    0,
@@ -87,6 +87,9 @@ type generator struct {
 	liveWriters objIDSlice
 	// externalObjects contains the external objects created.
 	externalObjects objIDSlice
+	// creatorIDSet contains the DBs for which a dbSetCreatorIDOp has been
+	// generated.
+	creatorIDSet objIDSet
 
 	// Maps used to find associated objects during generation. These maps are not
 	// needed during test execution.
@@ -137,6 +140,7 @@ func newGenerator(rng *rand.Rand, cfg OpConfig, km *keyManager) *generator {
 		itersLastOpts:         make(map[objID]iterOpts),
 		iterCreationTimestamp: make(map[objID]int),
 		iterReaderID:          make(map[objID]objID),
+		creatorIDSet:          make(objIDSet),
 	}
 	for i := 1; i < cfg.numInstances; i++ {
 		g.liveReaders = append(g.liveReaders, makeObjID(dbTag, uint32(i+1)))
@@ -160,6 +164,7 @@ func generate(rng *rand.Rand, count uint64, cfg OpConfig, km *keyManager) []op {
 		OpDBFlush:                     g.dbFlush,
 		OpDBRatchetFormatMajorVersion: g.dbRatchetFormatMajorVersion,
 		OpDBRestart:                   g.dbRestart,
+		OpDBSetCreatorID:              g.dbSetCreatorID,
 		OpIterClose:                   g.randIter(g.iterClose),
 		OpIterFirst:                   g.randIter(g.iterFirst),
 		OpIterLast:                    g.randIter(g.iterLast),
@@ -438,9 +443,14 @@ func (g *generator) dbRatchetFormatMajorVersion() {
 }
 
 func (g *generator) dbRestart() {
-	// Close any live iterators and snapshots, so that we can close the DB
-	// cleanly.
 	dbID := g.dbs.rand(g.rng)
+	g.closeAllReadersAndBatches()
+	g.add(&dbRestartOp{dbID: dbID})
+}
+
+// closeAllReadersAndBatches closes all live iterators, snapshots and batches,
+// so that a DB can be closed cleanly.
+func (g *generator) closeAllReadersAndBatches() {
 	for len(g.liveIters) > 0 {
 		g.randIter(g.iterClose)()
 	}
@@ -457,7 +467,21 @@ func (g *generator) dbRestart() {
 		panic(fmt.Sprintf("unexpected counts: liveReaders %d, liveWriters: %d",
 			len(g.liveReaders), len(g.liveWriters)))
 	}
-	g.add(&dbRestartOp{dbID: dbID})
+}
+
+func (g *generator) dbSetCreatorID() {
+	dbID := g.dbs.rand(g.rng)
+	if _, ok := g.creatorIDSet[dbID]; ok {
+		return
+	}
+	g.creatorIDSet[dbID] = struct{}{}
+	// When the creator ID is set lazily, the op reopens the DB so that it
+	// begins creating objects on shared storage (see
+	// TestOptions.lazyCreatorID), so the DB must be closable.
+	g.closeAllReadersAndBatches()
+	// Each DB's creator ID is derived from its slot so that the IDs are unique
+	// across the instances sharing remote storage.
+	g.add(&dbSetCreatorIDOp{dbID: dbID, creatorID: uint64(dbID.slot())})
 }
 
 // maybeSetSnapshotIterBounds must be called whenever creating a new iterator or
@@ -980,12 +1004,51 @@ func (g *generator) replicate() {
 	}
 
 	startKey, endKey := g.prefixKeyRange()
+	span := pebble.KeyRange{Start: startKey, End: endKey}
+
+	// Sometimes take a snapshot of the destination over the replicated span
+	// beforehand. Reads through the snapshot, which is an EFOS in runs that
+	// may replicate through an excise, must not observe the replicated keys.
+	var snapID objID
+	if g.rng.Intn(4) == 0 {
+		snapID = g.newSnapshotWithBounds(dest, []pebble.KeyRange{span})
+	}
 	g.add(&replicateOp{
 		source: source,
 		dest:   dest,
 		start:  startKey,
 		end:    endKey,
 	})
+	if snapID != 0 {
+		g.replicatedSpanGets(snapID, span)
+	}
+
+	// Sometimes download the replicated span in the destination, rewriting any
+	// shared or external tables it received from the source as its own.
+	if g.rng.Intn(4) == 0 {
+		g.add(&downloadOp{
+			dbID: dest,
+			spans: []pebble.DownloadSpan{{
+				StartKey:               startKey,
+				EndKey:                 endKey,
+				ViaBackingFileDownload: g.rng.Intn(2) == 0,
+			}},
+		})
+		g.replicatedSpanGets(dest, span)
+	}
+}
+
+// replicatedSpanGets reads a few keys within a replicated span through the
+// provided DB or snapshot of the replication's destination.
+func (g *generator) replicatedSpanGets(readerID objID, span pebble.KeyRange) {
+	derivedDBID := objID(0)
+	if readerID.tag() == snapTag {
+		derivedDBID = g.deriveDB(readerID)
+	}
+	for n := 1 + g.expRandInt(2); n > 0; n-- {
+		key := g.keyGenerator.RandKeyInRange(0.001, span)
+		g.add(&getOp{readerID: readerID, key: key, derivedDBID: derivedDBID})
+	}
 }
 
 // generateDisjointKeyRanges generates n disjoint key ranges.
@@ -1002,31 +1065,34 @@ func (g *generator) generateDisjointKeyRanges(n int) []pebble.KeyRange {
 }
 
 func (g *generator) newSnapshot() {
+	// Impose bounds on the keys that may be read with the snapshot. Setting bounds
+	// allows some runs of the metamorphic test to use a EventuallyFileOnlySnapshot
+	// instead of a Snapshot, testing equivalence between the two for reads within
+	// those bounds.
+	dbID := g.dbs.rand(g.rng)
+	g.newSnapshotWithBounds(dbID, g.generateDisjointKeyRanges(1+g.expRandInt(3)))
+}
+
+// newSnapshotWithBounds creates a snapshot of the provided DB that may only be
+// used to read keys within the provided bounds, returning its ID.
+func (g *generator) newSnapshotWithBounds(dbID objID, bounds []pebble.KeyRange) objID {
 	snapID := makeObjID(snapTag, g.init.snapshotSlots)
 	g.init.snapshotSlots++
 	g.liveSnapshots = append(g.liveSnapshots, snapID)
 	g.liveReaders = append(g.liveReaders, snapID)
-	dbID := g.dbs.rand(g.rng)
 	g.objDB[snapID] = dbID
 
 	iters := make(objIDSet)
 	g.snapshots[snapID] = iters
 	g.readers[snapID] = iters
 
-	s := &newSnapshotOp{
+	g.snapshotBounds[snapID] = bounds
+	g.add(&newSnapshotOp{
 		dbID:   dbID,
 		snapID: snapID,
-	}
-
-	// Impose bounds on the keys that may be read with the snapshot. Setting bounds
-	// allows some runs of the metamorphic test to use a EventuallyFileOnlySnapshot
-	// instead of a Snapshot, testing equivalence between the two for reads within
-	// those bounds.
-	s.bounds = g.generateDisjointKeyRanges(
-		1 + g.expRandInt(3),
-	)
-	g.snapshotBounds[snapID] = s.bounds
-	g.add(s)
+		bounds: bounds,
+	})
+	return snapID
 }
 
 func (g *generator) snapshotClose() {
@@ -879,6 +879,7 @@ func opWrittenKeys(untypedOp op) [][]byte {
 	case *closeOp:
 	case *compactOp:
 	case *dbRestartOp:
+	case *dbSetCreatorIDOp:
 	case *deleteOp:
 		return [][]byte{t.key}
 	case *deleteRangeOp:
@@ -1894,6 +1894,48 @@ func (o *dbRestartOp) syncObjs() objIDSlice { return o.affectedObjects }
 func (o *dbRestartOp) keys() []*[]byte                     { return nil }
 func (o *dbRestartOp) diagramKeyRanges() []pebble.KeyRange { return nil }
 
+// dbSetCreatorIDOp models a DB.SetCreatorID operation.
+type dbSetCreatorIDOp struct {
+	dbID      objID
+	creatorID uint64
+
+	// affectedObjects is the list of additional objects that are affected by this
+	// operation, and which syncObjs() must return so that we don't reopen the
+	// DB in parallel with other operations to affected objects.
+	affectedObjects []objID
+}
+
+func (o *dbSetCreatorIDOp) run(t *Test, h historyRecorder) {
+	// The creator ID only applies to shared storage. In runs with shared
+	// storage, the DB's creator ID may have been deferred until this op (see
+	// TestOptions.lazyCreatorID); otherwise setting the same ID again is a
+	// no-op.
+	var err error
+	if t.testOpts.sharedStorageEnabled {
+		err = t.withRetries(func() error {
+			return t.getDB(o.dbID).SetCreatorID(o.creatorID)
+		})
+		if err == nil && !t.creatorIDSet[o.dbID.slot()-1] {
+			// The DB was opened without creating objects on shared storage,
+			// which requires a creator ID. Reopen it to begin doing so.
+			t.creatorIDSet[o.dbID.slot()-1] = true
+			if err = t.reopenDB(o.dbID); err != nil {
+				h.history.err.Store(errors.Wrap(err, "dbSetCreatorIDOp"))
+			}
+		}
+	}
+	h.Recordf("%s // %v", o, err)
+}
+
+func (o *dbSetCreatorIDOp) String() string {
+	return fmt.Sprintf("%s.SetCreatorID(%d)", o.dbID, o.creatorID)
+}
+func (o *dbSetCreatorIDOp) receiver() objID      { return o.dbID }
+func (o *dbSetCreatorIDOp) syncObjs() objIDSlice { return o.affectedObjects }
+
+func (o *dbSetCreatorIDOp) keys() []*[]byte                     { return nil }
+func (o *dbSetCreatorIDOp) diagramKeyRanges() []pebble.KeyRange { return nil }
+
 func formatOps(ops []op) string {
 	var buf strings.Builder
 	for _, op := range ops {
@@ -1909,9 +1951,14 @@ type replicateOp struct {
 	start, end   []byte
 }
 
+// runSharedReplicate replicates the span through an IngestAndExcise of the
+// source's shared sstables. It returns false, without recording the op, if
+// the source's lower levels within the span aren't entirely shared (eg,
+// because they were created before the source's creator ID was set). The
+// caller should fall back to replicating by other means.
 func (r *replicateOp) runSharedReplicate(
 	t *Test, h historyRecorder, source, dest *pebble.DB, w *sstable.Writer, sstPath string,
-) {
+) bool {
 	var sharedSSTs []pebble.SharedSSTMeta
 	var err error
 	err = source.ScanInternal(context.TODO(), sstable.CategoryAndQoS{}, r.start, r.end,
@@ -1938,20 +1985,23 @@ func (r *replicateOp) runSharedReplicate(
 		},
 		nil,
 	)
-	if err != nil {
+	if errors.Is(err, pebble.ErrInvalidSkipSharedIteration) {
+		_ = w.Close()
+		return false
+	} else if err != nil {
 		h.Recordf("%s // %v", r, err)
-		return
+		return true
 	}
 
 	err = w.Close()
 	if err != nil {
 		h.Recordf("%s // %v", r, err)
-		return
+		return true
 	}
 	meta, err := w.Raw().Metadata()
 	if err != nil {
 		h.Recordf("%s // %v", r, err)
-		return
+		return true
 	}
 	if len(sharedSSTs) == 0 && meta.Properties.NumEntries == 0 && meta.Properties.NumRangeKeys() == 0 {
 		// IngestAndExcise below will be a no-op. We should do a
@@ -1961,15 +2011,16 @@ func (r *replicateOp) runSharedReplicate(
 		// TODO(bilal): Remove this when we support excises with no matching ingests.
 		if err := dest.RangeKeyDelete(r.start, r.end, t.writeOpts); err != nil {
 			h.Recordf("%s // %v", r, err)
-			return
+			return true
 		}
 		err := dest.DeleteRange(r.start, r.end, t.writeOpts)
 		h.Recordf("%s // %v", r, err)
-		return
+		return true
 	}
 
 	_, err = dest.IngestAndExcise(context.Background(), []string{sstPath}, sharedSSTs, nil /* external */, pebble.KeyRange{Start: r.start, End: r.end})
 	h.Recordf("%s // %v", r, err)
+	return true
 }
 
 func (r *replicateOp) runExternalReplicate(
@@ -2036,19 +2087,28 @@ func (r *replicateOp) runExternalReplicate(
 }
 
 func (r *replicateOp) run(t *Test, h historyRecorder) {
-	// Shared replication only works if shared storage is enabled.
-	useSharedIngest := t.testOpts.useSharedReplicate && t.testOpts.sharedStorageEnabled
+	// Shared replication only works if shared storage is enabled, and if both
+	// DBs have their creator IDs set: the destination cannot attach the
+	// source's shared objects otherwise.
+	useSharedIngest := t.testOpts.useSharedReplicate && t.testOpts.sharedStorageEnabled &&
+		t.creatorIDSet[r.source.slot()-1] && t.creatorIDSet[r.dest.slot()-1]
 	useExternalIngest := t.testOpts.useExternalReplicate && t.testOpts.externalStorageEnabled
 
 	source := t.getDB(r.source)
 	dest := t.getDB(r.dest)
 	sstPath := path.Join(t.tmpDir, fmt.Sprintf("ext-replicate%d.sst", t.idx))
-	f, err := t.opts.FS.Create(sstPath, vfs.WriteCategoryUnspecified)
+	newWriter := func() (*sstable.Writer, error) {
+		f, err := t.opts.FS.Create(sstPath, vfs.WriteCategoryUnspecified)
+		if err != nil {
+			return nil, err
+		}
+		return sstable.NewWriter(objstorageprovider.NewFileWritable(f), t.opts.MakeWriterOptions(0, dest.FormatMajorVersion().MaxTableFormat())), nil
+	}
+	w, err := newWriter()
 	if err != nil {
 		h.Recordf("%s // %v", r, err)
 		return
 	}
-	w := sstable.NewWriter(objstorageprovider.NewFileWritable(f), t.opts.MakeWriterOptions(0, dest.FormatMajorVersion().MaxTableFormat()))
 
 	// NB: In practice we'll either do shared replicate or external replicate,
 	// as ScanInternal does not support both. We arbitrarily choose to prioritize
@@ -2059,8 +2119,15 @@ func (r *replicateOp) run(t *Test, h historyRecorder) {
 		return
 	}
 	if useSharedIngest {
-		r.runSharedReplicate(t, h, source, dest, w, sstPath)
-		return
+		if r.runSharedReplicate(t, h, source, dest, w, sstPath) {
+			return
+		}
+		// The span can't be replicated through shared sstables. Fall back to
+		// replicating its keys, as when shared replication is disabled.
+		if w, err = newWriter(); err != nil {
+			h.Recordf("%s // %v", r, err)
+			return
+		}
 	}
 
 	// First, do a RangeKeyDelete and DeleteRange on the whole span.
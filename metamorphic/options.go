@@ -157,6 +157,9 @@ func parseOptions(
 			case "TestOptions.use_shared_replicate":
 				opts.useSharedReplicate = true
 				return true
+			case "TestOptions.lazy_creator_id":
+				opts.lazyCreatorID = true
+				return true
 			case "TestOptions.use_external_replicate":
 				opts.useExternalReplicate = true
 				return true
@@ -250,6 +253,9 @@ func optionsToString(opts *TestOptions) string {
 	if opts.useSharedReplicate {
 		fmt.Fprintf(&buf, "  use_shared_replicate=%v\n", opts.useSharedReplicate)
 	}
+	if opts.lazyCreatorID {
+		fmt.Fprintf(&buf, "  lazy_creator_id=%v\n", opts.lazyCreatorID)
+	}
 	if opts.useExternalReplicate {
 		fmt.Fprintf(&buf, "  use_external_replicate=%v\n", opts.useExternalReplicate)
 	}
@@ -366,6 +372,13 @@ type TestOptions struct {
 	externalStorageFS      remote.Storage
 	// Enables the use of shared replication in TestOptions.
 	useSharedReplicate bool
+	// Defers setting each DB's creator ID until the first dbSetCreatorIDOp
+	// for that DB, rather than setting it when the DB is opened. Objects can't
+	// be created on shared storage without a creator ID, so until then the DB
+	// is open with CreateOnShared disabled; the op reopens it with the
+	// configured CreateOnShared. Only effective if sharedStorageEnabled is
+	// also true.
+	lazyCreatorID bool
 	// Enables the use of external replication in TestOptions.
 	useExternalReplicate bool
 	// Enable the secondary cache. Only effective if sharedStorageEnabled is
@@ -688,6 +701,32 @@ func RandomOptions(
 	if rng.Intn(2) == 0 {
 		opts.Experimental.DisableIngestAsFlushable = func() bool { return true }
 	}
+	switch rng.Intn(3) {
+	case 0:
+		opts.Experimental.WALCompression = pebble.SnappyCompression
+	case 1:
+		opts.Experimental.WALCompression = pebble.ZstdCompression
+	}
+	if rng.Intn(4) == 0 {
+		opts.Experimental.AdaptiveGroupCommit.TargetLatency = time.Duration(1+rng.Intn(1000)) * time.Microsecond
+		opts.Experimental.AdaptiveGroupCommit.MinConcurrency = 1 + rng.Intn(8)
+	}
+	if rng.Intn(4) == 0 {
+		opts.Experimental.MemTableApplyConcurrency = 2 + rng.Intn(7)
+	}
+	if rng.Intn(4) == 0 {
+		opts.Experimental.TableFormatRewriteBytesPerSecond = int64(1) << (10 + rng.Intn(15))
+	}
+	switch rng.Intn(4) {
+	case 0:
+		opts.Experimental.MemTableKind = pebble.VectorMemTable
+	case 1:
+		// Each hash bucket consumes a fixed amount of the memtable's arena, so
+		// only use the hash bucket memtable with larger memtables.
+		if opts.MemTableSize >= 1<<20 {
+			opts.Experimental.MemTableKind = pebble.HashBucketMemTable(1 + rng.Intn(64))
+		}
+	}
 
 	// We either use no multilevel compactions, multilevel compactions with the
 	// default (zero) additional propensity, or multilevel compactions with an
@@ -800,6 +839,8 @@ func RandomOptions(
 		}
 		// 50% of the time, enable shared replication.
 		testOpts.useSharedReplicate = rng.Intn(2) == 0
+		// 25% of the time, defer setting the creator ID.
+		testOpts.lazyCreatorID = rng.Intn(4) == 0
 	}
 
 	// 50% of time, enable external storage.
@@ -61,6 +61,8 @@ func opArgs(op op) (receiverID *objID, targetID *objID, args []interface{}) {
 		return &t.dbID, nil, []interface{}{&t.vers}
 	case *dbRestartOp:
 		return &t.dbID, nil, nil
+	case *dbSetCreatorIDOp:
+		return &t.dbID, nil, []interface{}{&t.creatorID}
 	case *deleteOp:
 		return &t.writerID, nil, []interface{}{&t.key}
 	case *deleteRangeOp:
@@ -172,6 +174,7 @@ var methods = map[string]*methodInfo{
 	"SeekLT":                    makeMethod(iterSeekLTOp{}, iterTag),
 	"SeekPrefixGE":              makeMethod(iterSeekPrefixGEOp{}, iterTag),
 	"Set":                       makeMethod(setOp{}, dbTag, batchTag),
+	"SetCreatorID":              makeMethod(dbSetCreatorIDOp{}, dbTag),
 	"SetBounds":                 makeMethod(iterSetBoundsOp{}, iterTag),
 	"SetOptions":                makeMethod(iterSetOptionsOp{}, iterTag),
 	"SingleDelete":              makeMethod(singleDeleteOp{}, dbTag, batchTag),
@@ -672,6 +675,16 @@ func computeDerivedFields(ops []op) {
 			}
 			// Sort so the output is deterministic.
 			slices.Sort(v.affectedObjects)
+		case *dbSetCreatorIDOp:
+			// Find all objects that use this db.
+			v.affectedObjects = nil
+			for obj, db := range objToDB {
+				if db == v.dbID {
+					v.affectedObjects = append(v.affectedObjects, obj)
+				}
+			}
+			// Sort so the output is deterministic.
+			slices.Sort(v.affectedObjects)
 		case *ingestOp:
 			v.derivedDBIDs = make([]objID, len(v.batchIDs))
 			for i := range v.batchIDs {
@@ -56,6 +56,9 @@ type Test struct {
 	tmpDir    string
 	// The DBs the test is run on.
 	dbs []*pebble.DB
+	// creatorIDSet[i] is true once the creator ID of dbs[i] has been set. It's
+	// only ever true if shared storage is enabled.
+	creatorIDSet []bool
 	// The slots for the batches, iterators, and snapshots. These are read and
 	// written by the ops to pass state from one op to another.
 	batches      []*pebble.Batch
@@ -182,14 +185,22 @@ func (t *Test) init(
 	}
 
 	t.dbs = make([]*pebble.DB, numInstances)
+	t.creatorIDSet = make([]bool, numInstances)
 	for i := range t.dbs {
 		var db *pebble.DB
 		var err error
 		if len(t.dbs) > 1 {
 			dir = path.Join(t.dir, fmt.Sprintf("db%d", i+1))
 		}
+		opts := t.opts
+		if t.testOpts.sharedStorageEnabled && t.testOpts.lazyCreatorID {
+			// Objects can't be created on shared storage until the creator ID
+			// is set. The DB is reopened with t.opts once it's set.
+			opts = t.opts.Clone()
+			opts.Experimental.CreateOnShared = remote.CreateOnSharedNone
+		}
 		err = t.withRetries(func() error {
-			db, err = pebble.Open(dir, t.opts)
+			db, err = pebble.Open(dir, opts)
 			return err
 		})
 		if err != nil {
@@ -198,13 +209,14 @@ func (t *Test) init(
 		t.dbs[i] = db
 		h.log.Printf("// db%d.Open() %v", i+1, err)
 
-		if t.testOpts.sharedStorageEnabled {
+		if t.testOpts.sharedStorageEnabled && !t.testOpts.lazyCreatorID {
 			err = t.withRetries(func() error {
 				return db.SetCreatorID(uint64(i + 1))
 			})
 			if err != nil {
 				return err
 			}
+			t.creatorIDSet[i] = true
 			h.log.Printf("// db%d.SetCreatorID() %v", i+1, err)
 		}
 	}
@@ -330,6 +342,41 @@ func (t *Test) restartDB(dbID objID) error {
 	return err
 }
 
+// reopenDB closes and reopens the DB with t.opts. Unlike restartDB, it doesn't
+// simulate a crash, and may be used with shared storage.
+func (t *Test) reopenDB(dbID objID) error {
+	t.opts.Cache.Ref()
+	defer t.opts.Cache.Unref()
+	db := t.getDB(dbID)
+	// Flush so that no writes are lost if the WAL is disabled.
+	if err := t.withRetries(func() error { return db.Flush() }); err != nil {
+		return err
+	}
+	if err := db.Close(); err != nil {
+		return err
+	}
+	// Release and reacquire any resources held by custom options, as in
+	// restartDB.
+	for i := range t.testOpts.CustomOpts {
+		if err := t.testOpts.CustomOpts[i].Close(t.opts); err != nil {
+			return err
+		}
+	}
+	return t.withRetries(func() (err error) {
+		for i := range t.testOpts.CustomOpts {
+			if err := t.testOpts.CustomOpts[i].Open(t.opts); err != nil {
+				return err
+			}
+		}
+		dir := t.dir
+		if len(t.dbs) > 1 {
+			dir = path.Join(dir, fmt.Sprintf("db%d", dbID.slot()))
+		}
+		t.dbs[dbID.slot()-1], err = pebble.Open(dir, t.opts)
+		return err
+	})
+}
+
 func (t *Test) saveInMemoryDataInternal() error {
 	if rootFS := vfs.Root(t.opts.FS); rootFS != vfs.Default {
 		// t.opts.FS is an in-memory system; copy it to disk.
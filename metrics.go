@@ -163,8 +163,11 @@ type Metrics struct {
 		ReadCount             int64
 		TombstoneDensityCount int64
 		RewriteCount          int64
-		MultiLevelCount       int64
-		CounterLevelCount     int64
+		// TableFormatRewriteCount is the number of compactions that rewrote
+		// a table written in an older table format.
+		TableFormatRewriteCount int64
+		MultiLevelCount         int64
+		CounterLevelCount       int64
 		// An estimate of the number of bytes that need to be compacted for the LSM
 		// to reach a stable state.
 		EstimatedDebt uint64
@@ -178,6 +181,14 @@ type Metrics struct {
 		// compaction. Such files are compacted in a rewrite compaction
 		// when no other compactions are picked.
 		MarkedFiles int
+		// TableFormatRewritePendingCount and TableFormatRewritePendingSize are
+		// the count and total size of live tables written in a table format
+		// older than the newest format supported by the DB's format major
+		// version. Such tables are rewritten by table-format-rewrite compactions
+		// (see Options.Experimental.TableFormatRewriteBytesPerSecond). Tables
+		// whose statistics have not been loaded yet are not included.
+		TableFormatRewritePendingCount int64
+		TableFormatRewritePendingSize  uint64
 		// Duration records the cumulative duration of all compactions since the
 		// database was opened.
 		Duration time.Duration
@@ -286,6 +297,10 @@ type Metrics struct {
 			ObsoleteSize uint64
 			// ZombieSize is the number of bytes in zombie tables.
 			ZombieSize uint64
+			// ColdTierLiveSize is the number of bytes in live tables that are
+			// stored on the cold tier (see Options.Experimental.ColdTier). It is
+			// included in LiveSize.
+			ColdTierLiveSize uint64
 		}
 	}
 
@@ -325,9 +340,30 @@ type Metrics struct {
 		record.LogWriterMetrics
 	}
 
+	// Commit contains histograms of the latencies, in nanoseconds, of the
+	// stages of committing batches. See BatchCommitStats for the definitions
+	// of the stages.
+	Commit struct {
+		// QueueWaitLatency is the wait for the commit semaphores and the commit
+		// pipeline mutex (SemaphoreWaitDuration + PipelineMutexWaitDuration).
+		QueueWaitLatency prometheus.Histogram
+		// WALWriteLatency is the time spent writing batches to the WAL
+		// (WALWriteDuration).
+		WALWriteLatency prometheus.Histogram
+		// SyncWaitLatency is the wait for publishing and syncing batches that
+		// requested a WAL sync (CommitWaitDuration).
+		SyncWaitLatency prometheus.Histogram
+		// MemTableApplyLatency is the time spent applying batches to the
+		// memtable (MemTableApplyDuration).
+		MemTableApplyLatency prometheus.Histogram
+	}
+
 	CategoryStats []sstable.CategoryStatsAggregate
 
 	SecondaryCacheMetrics SecondaryCacheMetrics
+	// LocalSecondaryCacheMetrics holds metrics for the persistent secondary
+	// cache of local sstables (see Options.Experimental.LocalSecondaryCacheSizeBytes).
+	LocalSecondaryCacheMetrics SecondaryCacheMetrics
 
 	private struct {
 		optionsFileSize  uint64
@@ -345,6 +381,11 @@ var (
 		prometheus.ExponentialBucketsRange(float64(time.Millisecond*5), float64(10*time.Second), 50)...,
 	)
 
+	// CommitLatencyBuckets are prometheus histogram buckets suitable for the
+	// commit latency histograms in Metrics.Commit.
+	CommitLatencyBuckets = prometheus.ExponentialBucketsRange(
+		float64(time.Microsecond), float64(10*time.Second), 100)
+
 	// SecondaryCacheIOBuckets exported to enable exporting from package pebble to
 	// enable exporting metrics with below buckets in CRDB.
 	SecondaryCacheIOBuckets = sharedcache.IOBuckets
@@ -584,7 +625,7 @@ func (m *Metrics) SafeFormat(w redact.SafePrinter, _ rune) {
 		redact.Safe(m.Compact.NumInProgress),
 		humanize.Bytes.Int64(m.Compact.InProgressBytes))
 
-	w.Printf("             default: %d  delete: %d  elision: %d  move: %d  read: %d  tombstone-density: %d  rewrite: %d  copy: %d  multi-level: %d\n",
+	w.Printf("             default: %d  delete: %d  elision: %d  move: %d  read: %d  tombstone-density: %d  rewrite: %d  table-format-rewrite: %d  copy: %d  multi-level: %d\n",
 		redact.Safe(m.Compact.DefaultCount),
 		redact.Safe(m.Compact.DeleteOnlyCount),
 		redact.Safe(m.Compact.ElisionOnlyCount),
@@ -592,6 +633,7 @@ func (m *Metrics) SafeFormat(w redact.SafePrinter, _ rune) {
 		redact.Safe(m.Compact.ReadCount),
 		redact.Safe(m.Compact.TombstoneDensityCount),
 		redact.Safe(m.Compact.RewriteCount),
+		redact.Safe(m.Compact.TableFormatRewriteCount),
 		redact.Safe(m.Compact.CopyCount),
 		redact.Safe(m.Compact.MultiLevelCount))
 
@@ -612,7 +654,12 @@ func (m *Metrics) SafeFormat(w redact.SafePrinter, _ rune) {
 	w.Printf("Virtual tables: %d (%s)\n",
 		redact.Safe(m.NumVirtual()),
 		humanize.Bytes.Uint64(m.VirtualSize()))
-	w.Printf("Local tables size: %s\n", humanize.Bytes.Uint64(m.Table.Local.LiveSize))
+	w.Printf("Local tables size: %s (cold tier: %s)\n",
+		humanize.Bytes.Uint64(m.Table.Local.LiveSize),
+		humanize.Bytes.Uint64(m.Table.Local.ColdTierLiveSize))
+	w.Printf("Tables pending format rewrite: %d (%s)\n",
+		redact.Safe(m.Compact.TableFormatRewritePendingCount),
+		humanize.Bytes.Uint64(m.Compact.TableFormatRewritePendingSize))
 	w.SafeString("Compression types:")
 	if count := m.Table.CompressedCountSnappy; count > 0 {
 		w.Printf(" snappy: %d", redact.Safe(count))
@@ -636,6 +683,16 @@ func (m *Metrics) SafeFormat(w redact.SafePrinter, _ rune) {
 			redact.Safe(hitRate(m.Hits, m.Misses)))
 	}
 	formatCacheMetrics(&m.BlockCache, "Block cache")
+	w.Printf("Block cache priorities:")
+	for p := range m.BlockCache.Priorities {
+		pm := &m.BlockCache.Priorities[p]
+		w.Printf("  %s: %s entries (%s) hits: %s",
+			redact.Safe(CachePriority(p)),
+			humanize.Count.Int64(pm.Count),
+			humanize.Bytes.Int64(pm.Size),
+			humanize.Count.Int64(pm.Hits))
+	}
+	w.Print("\n")
 	formatCacheMetrics(&m.TableCache, "Table cache")
 
 	formatSharedCacheMetrics := func(w redact.SafePrinter, m *SecondaryCacheMetrics, name redact.SafeString) {
@@ -646,6 +703,7 @@ func (m *Metrics) SafeFormat(w redact.SafePrinter, _ rune) {
 			redact.Safe(hitRate(m.ReadsWithFullHit, m.ReadsWithPartialHit+m.ReadsWithNoHit)))
 	}
 	formatSharedCacheMetrics(w, &m.SecondaryCacheMetrics, "Secondary cache")
+	formatSharedCacheMetrics(w, &m.LocalSecondaryCacheMetrics, "Local secondary cache")
 
 	w.Printf("Snapshots: %d  earliest seq num: %d\n",
 		redact.Safe(m.Snapshots.Count),
@@ -143,7 +143,11 @@ func TestMetrics(t *testing.T) {
 			MaxOpenFiles: 10000,
 		}
 		opts.Experimental.EnableValueBlocks = func() bool { return true }
-		opts.Levels = append(opts.Levels, LevelOptions{TargetFileSize: 50})
+		// Columnar tables carry more fixed overhead than row-oriented ones. The
+		// target file size is large enough that compactions still write tables
+		// spanning several keys, which the excise cases below rely on to create
+		// virtual tables.
+		opts.Levels = append(opts.Levels, LevelOptions{TargetFileSize: 200})
 
 		// Prevent foreground flushes and compactions from triggering asynchronous
 		// follow-up compactions. This avoids asynchronously-scheduled work from
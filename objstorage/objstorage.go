@@ -120,6 +120,12 @@ type ObjectMetadata struct {
 	DiskFileNum base.DiskFileNum
 	FileType    base.FileType
 
+	// The fields below are only relevant if the object is on local storage.
+	Local struct {
+		// Tier is the local storage tier on which the object is stored.
+		Tier LocalTier
+	}
+
 	// The fields below are only set if the object is on remote storage.
 	Remote struct {
 		// CreatorID identifies the DB instance that originally created the object.
@@ -187,6 +193,30 @@ func (meta *ObjectMetadata) AssertValid() {
 	}
 }
 
+// LocalTier identifies one of the local filesystems on which a provider can
+// store objects.
+type LocalTier uint8
+
+const (
+	// LocalTierHot is the primary local filesystem of the provider.
+	LocalTierHot LocalTier = iota
+	// LocalTierCold is the secondary, typically slower and cheaper, local
+	// filesystem of the provider. If the provider is not configured with a cold
+	// tier, objects are stored on the hot tier instead.
+	LocalTierCold
+)
+
+// String implements fmt.Stringer.
+func (t LocalTier) String() string {
+	switch t {
+	case LocalTierHot:
+		return "hot"
+	case LocalTierCold:
+		return "cold"
+	}
+	return fmt.Sprintf("LocalTier(%d)", uint8(t))
+}
+
 // CreatorID identifies the DB instance that originally created a shared object.
 // This ID is incorporated in backing object names.
 // Must be non-zero.
@@ -235,6 +265,10 @@ type CreateOptions struct {
 	// WriteCategory is used for the object when it is created on local storage
 	// to collect aggregated write metrics for each write source.
 	WriteCategory vfs.DiskWriteCategory
+
+	// LocalTier is the tier on which the object is created when it is created
+	// on local storage.
+	LocalTier LocalTier
 }
 
 // Provider is a singleton object used to access and manage objects.
@@ -343,6 +377,10 @@ type Provider interface {
 	// Metrics returns metrics about objstorage. Currently, it only returns metrics
 	// about the shared cache.
 	Metrics() sharedcache.Metrics
+
+	// LocalCacheMetrics returns metrics about the on-disk block cache for local
+	// objects.
+	LocalCacheMetrics() sharedcache.Metrics
 }
 
 // RemoteObjectBacking encodes the metadata necessary to incorporate a shared
@@ -30,9 +30,20 @@ type provider struct {
 	st Settings
 
 	fsDir vfs.File
+	// coldTier is the location of the cold tier, if one is configured (see
+	// Settings.Local.ColdTier).
+	coldTier struct {
+		fs      vfs.FS
+		dirName string
+		dir     vfs.File
+	}
 
 	tracer *objiotracing.Tracer
 
+	// localCache is the on-disk block cache for local sstables, if configured
+	// (see Settings.Local.Cache).
+	localCache *sharedcache.Cache
+
 	remote remoteSubsystem
 
 	mu struct {
@@ -101,6 +112,29 @@ type Settings struct {
 		// ReadaheadConfig is used to retrieve the current readahead mode; it is
 		// consulted whenever a read handle is initialized.
 		ReadaheadConfig *ReadaheadConfig
+
+		// ColdTier configures a second local filesystem on which objects are
+		// created when objstorage.CreateOptions.LocalTier is LocalTierCold. The
+		// cold tier is configured if either FS or DirName is set. If FS is nil,
+		// FS is used; if DirName is empty, FSDirName is used.
+		ColdTier struct {
+			FS      vfs.FS
+			DirName string
+		}
+
+		// Cache configures an on-disk block cache for local sstables. It is
+		// intended to be placed on a device that is faster than the one backing
+		// FS (e.g. a local NVMe drive in front of an HDD or network-attached
+		// volume).
+		Cache struct {
+			// FS and DirName specify where the cache files are stored. If FS is
+			// nil, FS is used; if DirName is empty, FSDirName is used.
+			FS      vfs.FS
+			DirName string
+
+			// SizeBytes is the size of the cache. If it is 0, no cache is used.
+			SizeBytes int64
+		}
 	}
 
 	// Fields here are set only if the provider is to support remote objects
@@ -251,6 +285,16 @@ func open(settings Settings) (p *provider, _ error) {
 	p.mu.knownObjects = make(map[base.DiskFileNum]objstorage.ObjectMetadata)
 	p.mu.protectedObjects = make(map[base.DiskFileNum]int)
 
+	if err := p.coldTierOpen(); err != nil {
+		return nil, err
+	}
+	coldDir := p.coldTier.dir
+	defer func() {
+		if p == nil && coldDir != nil {
+			coldDir.Close()
+		}
+	}()
+
 	if objiotracing.Enabled {
 		p.tracer = objiotracing.Open(settings.FS, settings.FSDirName)
 	}
@@ -265,16 +309,29 @@ func open(settings Settings) (p *provider, _ error) {
 		return nil, err
 	}
 
+	// Open the local object cache (if configured).
+	if err := p.localCacheInit(); err != nil {
+		return nil, err
+	}
+
 	return p, nil
 }
 
 // Close is part of the objstorage.Provider interface.
 func (p *provider) Close() error {
 	err := p.sharedClose()
+	if p.localCache != nil {
+		err = firstError(err, p.localCache.Close())
+		p.localCache = nil
+	}
 	if p.fsDir != nil {
 		err = firstError(err, p.fsDir.Close())
 		p.fsDir = nil
 	}
+	if p.coldTier.dir != nil {
+		err = firstError(err, p.coldTier.dir.Close())
+		p.coldTier.dir = nil
+	}
 	if objiotracing.Enabled {
 		if p.tracer != nil {
 			p.tracer.Close()
@@ -301,7 +358,7 @@ func (p *provider) OpenForReading(
 
 	var r objstorage.Readable
 	if !meta.IsRemote() {
-		r, err = p.vfsOpenForReading(ctx, fileType, fileNum, opts)
+		r, err = p.vfsOpenForReading(ctx, meta, opts)
 	} else {
 		r, err = p.remoteOpenForReading(ctx, meta, opts)
 		if err != nil && p.isNotExistError(meta, err) {
@@ -337,7 +394,7 @@ func (p *provider) Create(
 		} else {
 			category = vfs.WriteCategoryUnspecified
 		}
-		w, meta, err = p.vfsCreate(ctx, fileType, fileNum, category)
+		w, meta, err = p.vfsCreate(ctx, fileType, fileNum, opts.LocalTier, category)
 	}
 	if err != nil {
 		err = errors.Wrapf(err, "creating object %s", fileNum)
@@ -364,7 +421,7 @@ func (p *provider) Remove(fileType base.FileType, fileNum base.DiskFileNum) erro
 	}
 
 	if !meta.IsRemote() {
-		err = p.vfsRemove(fileType, fileNum)
+		err = p.vfsRemove(meta)
 	} else {
 		// TODO(radu): implement remote object removal (i.e. deref).
 		err = p.sharedUnref(meta)
@@ -424,22 +481,24 @@ func (p *provider) LinkOrCopyFromLocal(
 	opts objstorage.CreateOptions,
 ) (objstorage.ObjectMetadata, error) {
 	shared := opts.PreferSharedStorage && p.st.Remote.CreateOnShared != remote.CreateOnSharedNone
-	if !shared && srcFS == p.st.FS {
+	tier := p.vfsTier(opts.LocalTier)
+	if dstFS, _ := p.vfsTierLocation(tier); !shared && srcFS == dstFS {
 		// Wrap the normal filesystem with one which wraps newly created files with
 		// vfs.NewSyncingFile.
-		fs := vfs.NewSyncingFS(p.st.FS, vfs.SyncingFileOptions{
+		fs := vfs.NewSyncingFS(dstFS, vfs.SyncingFileOptions{
 			NoSyncOnClose: p.st.NoSyncOnClose,
 			BytesPerSync:  p.st.BytesPerSync,
 		})
-		dstPath := p.vfsPath(dstFileType, dstFileNum)
-		if err := vfs.LinkOrCopy(fs, srcFilePath, dstPath); err != nil {
-			return objstorage.ObjectMetadata{}, err
-		}
-
 		meta := objstorage.ObjectMetadata{
 			DiskFileNum: dstFileNum,
 			FileType:    dstFileType,
 		}
+		meta.Local.Tier = tier
+		dstPath := p.vfsPath(meta)
+		if err := vfs.LinkOrCopy(fs, srcFilePath, dstPath); err != nil {
+			return objstorage.ObjectMetadata{}, err
+		}
+
 		p.addMetadata(meta)
 		return meta, nil
 	}
@@ -504,7 +563,7 @@ func (p *provider) Lookup(
 // Path is part of the objstorage.Provider interface.
 func (p *provider) Path(meta objstorage.ObjectMetadata) string {
 	if !meta.IsRemote() {
-		return p.vfsPath(meta.FileType, meta.DiskFileNum)
+		return p.vfsPath(meta)
 	}
 	return p.remotePath(meta)
 }
@@ -512,7 +571,7 @@ func (p *provider) Path(meta objstorage.ObjectMetadata) string {
 // Size returns the size of the object.
 func (p *provider) Size(meta objstorage.ObjectMetadata) (int64, error) {
 	if !meta.IsRemote() {
-		return p.vfsSize(meta.FileType, meta.DiskFileNum)
+		return p.vfsSize(meta)
 	}
 	return p.remoteSize(meta)
 }
@@ -539,6 +598,14 @@ func (p *provider) Metrics() sharedcache.Metrics {
 	return sharedcache.Metrics{}
 }
 
+// LocalCacheMetrics is part of the objstorage.Provider interface.
+func (p *provider) LocalCacheMetrics() sharedcache.Metrics {
+	if p.localCache != nil {
+		return p.localCache.Metrics()
+	}
+	return sharedcache.Metrics{}
+}
+
 // CheckpointState is part of the objstorage.Provider interface.
 func (p *provider) CheckpointState(
 	fs vfs.FS, dir string, fileType base.FileType, fileNums []base.DiskFileNum,
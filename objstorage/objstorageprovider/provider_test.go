@@ -703,3 +703,119 @@ func TestParallelSync(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalCache(t *testing.T) {
+	ctx := context.Background()
+	fs := vfs.NewMem()
+	cacheFS := vfs.NewMem()
+	st := DefaultSettings(fs, "")
+	st.Local.Cache.FS = cacheFS
+	st.Local.Cache.DirName = "cache"
+	st.Local.Cache.SizeBytes = 16 << 20
+	p, err := Open(st)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, p.Close()) }()
+
+	// The cache files are created on the cache filesystem.
+	ls, err := cacheFS.List("cache")
+	require.NoError(t, err)
+	require.NotEmpty(t, ls)
+	for _, name := range ls {
+		require.True(t, strings.HasPrefix(name, localCacheFilePrefix), name)
+	}
+
+	data := make([]byte, 100<<10)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	w, _, err := p.Create(ctx, base.FileTypeTable, 1, objstorage.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(data))
+	require.NoError(t, w.Finish())
+
+	r, err := p.OpenForReading(ctx, base.FileTypeTable, 1, objstorage.OpenOptions{})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	buf := make([]byte, 1000)
+	require.NoError(t, r.ReadAt(ctx, buf, 40000))
+	require.Equal(t, data[40000:41000], buf)
+	require.Equal(t, int64(1), p.LocalCacheMetrics().ReadsWithNoHit)
+
+	// The cache is populated asynchronously. Once populated, the same data is
+	// read from the cache.
+	require.Eventually(t, func() bool {
+		rh := r.NewReadHandle(objstorage.NoReadBefore)
+		defer func() { require.NoError(t, rh.Close()) }()
+		require.NoError(t, rh.ReadAt(ctx, buf, 40000))
+		require.Equal(t, data[40000:41000], buf)
+		return p.LocalCacheMetrics().ReadsWithFullHit > 0
+	}, 10*time.Second, time.Millisecond)
+	require.Zero(t, p.Metrics().TotalReads)
+}
+
+func TestLocalColdTier(t *testing.T) {
+	ctx := context.Background()
+	fs := vfs.NewMem()
+	coldFS := vfs.NewMem()
+	st := DefaultSettings(fs, "")
+	st.Local.ColdTier.FS = coldFS
+	st.Local.ColdTier.DirName = "cold"
+	p, err := Open(st)
+	require.NoError(t, err)
+
+	create := func(fileNum base.DiskFileNum, tier objstorage.LocalTier, data string) {
+		w, meta, err := p.Create(ctx, base.FileTypeTable, fileNum, objstorage.CreateOptions{LocalTier: tier})
+		require.NoError(t, err)
+		require.Equal(t, tier, meta.Local.Tier)
+		require.NoError(t, w.Write([]byte(data)))
+		require.NoError(t, w.Finish())
+	}
+	create(1, objstorage.LocalTierHot, "hot data")
+	create(2, objstorage.LocalTierCold, "cold data")
+	require.NoError(t, p.Sync())
+
+	exists := func(fs vfs.FS, path string) bool {
+		_, err := fs.Stat(path)
+		return err == nil
+	}
+	require.True(t, exists(fs, "000001.sst"))
+	require.False(t, exists(coldFS, "cold/000001.sst"))
+	require.True(t, exists(coldFS, "cold/000002.sst"))
+	require.False(t, exists(fs, "000002.sst"))
+
+	// Copy the hot object to the cold tier.
+	hotMeta, err := p.Lookup(base.FileTypeTable, 1)
+	require.NoError(t, err)
+	meta, err := p.LinkOrCopyFromLocal(ctx, fs, p.Path(hotMeta), base.FileTypeTable, 3,
+		objstorage.CreateOptions{LocalTier: objstorage.LocalTierCold})
+	require.NoError(t, err)
+	require.Equal(t, objstorage.LocalTierCold, meta.Local.Tier)
+	require.NoError(t, p.Remove(base.FileTypeTable, 1))
+	require.False(t, exists(fs, "000001.sst"))
+	require.NoError(t, p.Sync())
+	require.NoError(t, p.Close())
+
+	// The tier of each object is recovered when the provider is reopened.
+	p, err = Open(st)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, p.Close()) }()
+	for _, tc := range []struct {
+		fileNum base.DiskFileNum
+		data    string
+	}{{2, "cold data"}, {3, "hot data"}} {
+		meta, err := p.Lookup(base.FileTypeTable, tc.fileNum)
+		require.NoError(t, err)
+		require.Equal(t, objstorage.LocalTierCold, meta.Local.Tier)
+		size, err := p.Size(meta)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(tc.data)), size)
+
+		r, err := p.OpenForReading(ctx, base.FileTypeTable, tc.fileNum, objstorage.OpenOptions{})
+		require.NoError(t, err)
+		buf := make([]byte, len(tc.data))
+		require.NoError(t, r.ReadAt(ctx, buf, 0))
+		require.Equal(t, tc.data, string(buf))
+		require.NoError(t, r.Close())
+	}
+}
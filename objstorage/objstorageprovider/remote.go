@@ -7,7 +7,6 @@ package objstorageprovider
 import (
 	"context"
 	"fmt"
-	"runtime"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -106,25 +105,9 @@ func (p *provider) remoteInit() error {
 	}
 
 	if p.st.Remote.CacheSizeBytes > 0 {
-		const defaultBlockSize = 32 * 1024
-		blockSize := p.st.Remote.CacheBlockSize
-		if blockSize == 0 {
-			blockSize = defaultBlockSize
-		}
-
-		const defaultShardingBlockSize = 1024 * 1024
-		shardingBlockSize := p.st.Remote.ShardingBlockSize
-		if shardingBlockSize == 0 {
-			shardingBlockSize = defaultShardingBlockSize
-		}
-
-		numShards := p.st.Remote.CacheShardCount
-		if numShards == 0 {
-			numShards = 2 * runtime.GOMAXPROCS(0)
-		}
-
-		p.remote.cache, err = sharedcache.Open(
-			p.st.FS, p.st.Logger, p.st.FSDirName, blockSize, shardingBlockSize, p.st.Remote.CacheSizeBytes, numShards)
+		p.remote.cache, err = openCache(
+			p.st.FS, p.st.Logger, p.st.FSDirName, sharedcache.DefaultFilePrefix, p.st.Remote.CacheSizeBytes,
+			p.st.Remote.CacheBlockSize, p.st.Remote.ShardingBlockSize, p.st.Remote.CacheShardCount)
 		if err != nil {
 			return errors.Wrapf(err, "pebble: could not open remote object cache")
 		}
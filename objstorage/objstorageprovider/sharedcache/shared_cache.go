@@ -30,7 +30,8 @@ var (
 
 // Cache is a persistent cache backed by a local filesystem. It is intended
 // to cache data that is in slower shared storage (e.g. S3), hence the
-// package name 'sharedcache'.
+// package name 'sharedcache'. It is also used to cache data of local objects
+// that reside on a slower device than the cache.
 type Cache struct {
 	shards       []shard
 	writeWorkers writeWorkers
@@ -114,6 +115,10 @@ const (
 	writeTasksPerWorker = 4
 )
 
+// DefaultFilePrefix is the prefix of the names of the files backing a cache
+// opened with Open.
+const DefaultFilePrefix = "SHARED-CACHE"
+
 // Open opens a cache. If there is no existing cache at fsDir, a new one
 // is created.
 func Open(
@@ -121,6 +126,22 @@ func Open(
 	logger base.Logger,
 	fsDir string,
 	blockSize int,
+	shardingBlockSize int64,
+	sizeBytes int64,
+	numShards int,
+) (*Cache, error) {
+	return OpenWithFilePrefix(
+		fs, logger, fsDir, DefaultFilePrefix, blockSize, shardingBlockSize, sizeBytes, numShards)
+}
+
+// OpenWithFilePrefix is like Open, but names the files backing the cache
+// using the provided prefix. It allows multiple caches to share a directory.
+func OpenWithFilePrefix(
+	fs vfs.FS,
+	logger base.Logger,
+	fsDir string,
+	filePrefix string,
+	blockSize int,
 	// shardingBlockSize is the size of a shard block. The cache is split into contiguous
 	// shardingBlockSize units. The units are distributed across multiple independent shards
 	// of the cache, via a hash(offset) modulo num shards operation. The cache replacement
@@ -144,7 +165,7 @@ func Open(
 	c.shards = make([]shard, numShards)
 	blocksPerShard := sizeBytes / int64(numShards) / int64(blockSize)
 	for i := range c.shards {
-		if err := c.shards[i].init(c, fs, fsDir, i, blocksPerShard, blockSize, shardingBlockSize); err != nil {
+		if err := c.shards[i].init(c, fs, fsDir, filePrefix, i, blocksPerShard, blockSize, shardingBlockSize); err != nil {
 			return nil, err
 		}
 	}
@@ -432,6 +453,7 @@ func (s *shard) init(
 	cache *Cache,
 	fs vfs.FS,
 	fsDir string,
+	filePrefix string,
 	shardIdx int,
 	sizeInBlocks int64,
 	blockSize int,
@@ -446,7 +468,7 @@ func (s *shard) init(
 	}
 	s.bm = makeBlockMath(blockSize)
 	s.shardingBlockSize = shardingBlockSize
-	file, err := fs.OpenReadWrite(fs.PathJoin(fsDir, fmt.Sprintf("SHARED-CACHE-%03d", shardIdx)), vfs.WriteCategoryUnspecified)
+	file, err := fs.OpenReadWrite(fs.PathJoin(fsDir, fmt.Sprintf("%s-%03d", filePrefix, shardIdx)), vfs.WriteCategoryUnspecified)
 	if err != nil {
 		return err
 	}
@@ -6,42 +6,105 @@ package objstorageprovider
 
 import (
 	"context"
+	"runtime"
 
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/objstorage"
+	"github.com/cockroachdb/pebble/objstorage/objstorageprovider/sharedcache"
 	"github.com/cockroachdb/pebble/vfs"
 )
 
-func (p *provider) vfsPath(fileType base.FileType, fileNum base.DiskFileNum) string {
-	return base.MakeFilepath(p.st.FS, p.st.FSDirName, fileType, fileNum)
+// localCacheFilePrefix is the prefix of the names of the files backing the
+// local object cache. It differs from the remote object cache's prefix so that
+// both caches can share a directory.
+const localCacheFilePrefix = "LOCAL-CACHE"
+
+// coldTierOpen opens the directory of the cold tier, if one is configured.
+func (p *provider) coldTierOpen() error {
+	st := &p.st.Local.ColdTier
+	if st.FS == nil && st.DirName == "" {
+		return nil
+	}
+	fs, dirName := st.FS, st.DirName
+	if fs == nil {
+		fs = p.st.FS
+	}
+	if dirName == "" {
+		dirName = p.st.FSDirName
+	}
+	if err := fs.MkdirAll(dirName, 0755); err != nil {
+		return errors.Wrapf(err, "pebble: could not create cold tier directory")
+	}
+	dir, err := fs.OpenDir(dirName)
+	if err != nil {
+		return errors.Wrapf(err, "pebble: could not open cold tier directory")
+	}
+	p.coldTier.fs = fs
+	p.coldTier.dirName = dirName
+	p.coldTier.dir = dir
+	return nil
+}
+
+// vfsTier returns the tier on which an object requested on the given tier is
+// stored, which is the hot tier if no cold tier is configured.
+func (p *provider) vfsTier(tier objstorage.LocalTier) objstorage.LocalTier {
+	if tier == objstorage.LocalTierCold && p.coldTier.fs == nil {
+		return objstorage.LocalTierHot
+	}
+	return tier
+}
+
+// vfsTierLocation returns the filesystem and directory of the given tier.
+func (p *provider) vfsTierLocation(tier objstorage.LocalTier) (vfs.FS, string) {
+	if tier == objstorage.LocalTierCold && p.coldTier.fs != nil {
+		return p.coldTier.fs, p.coldTier.dirName
+	}
+	return p.st.FS, p.st.FSDirName
+}
+
+func (p *provider) vfsPath(meta objstorage.ObjectMetadata) string {
+	fs, dirName := p.vfsTierLocation(meta.Local.Tier)
+	return base.MakeFilepath(fs, dirName, meta.FileType, meta.DiskFileNum)
 }
 
 func (p *provider) vfsOpenForReading(
-	ctx context.Context,
-	fileType base.FileType,
-	fileNum base.DiskFileNum,
-	opts objstorage.OpenOptions,
+	ctx context.Context, meta objstorage.ObjectMetadata, opts objstorage.OpenOptions,
 ) (objstorage.Readable, error) {
-	filename := p.vfsPath(fileType, fileNum)
-	file, err := p.st.FS.Open(filename, vfs.RandomReadsOption)
+	fs, _ := p.vfsTierLocation(meta.Local.Tier)
+	filename := p.vfsPath(meta)
+	file, err := fs.Open(filename, vfs.RandomReadsOption)
 	if err != nil {
 		if opts.MustExist {
-			base.MustExist(p.st.FS, filename, p.st.Logger, err)
+			base.MustExist(fs, filename, p.st.Logger, err)
 		}
 		return nil, err
 	}
-	return newFileReadable(file, p.st.FS, p.st.Local.ReadaheadConfig, filename)
+	r, err := newFileReadable(file, fs, p.st.Local.ReadaheadConfig, filename)
+	if err != nil {
+		return nil, err
+	}
+	if p.localCache != nil && meta.FileType == base.FileTypeTable {
+		r.cache = p.localCache
+		r.fileNum = meta.DiskFileNum
+	}
+	return r, nil
 }
 
 func (p *provider) vfsCreate(
 	_ context.Context,
 	fileType base.FileType,
 	fileNum base.DiskFileNum,
+	tier objstorage.LocalTier,
 	category vfs.DiskWriteCategory,
 ) (objstorage.Writable, objstorage.ObjectMetadata, error) {
-	filename := p.vfsPath(fileType, fileNum)
-	file, err := p.st.FS.Create(filename, category)
+	meta := objstorage.ObjectMetadata{
+		DiskFileNum: fileNum,
+		FileType:    fileType,
+	}
+	meta.Local.Tier = p.vfsTier(tier)
+	fs, _ := p.vfsTierLocation(meta.Local.Tier)
+	file, err := fs.Create(p.vfsPath(meta), category)
 	if err != nil {
 		return nil, objstorage.ObjectMetadata{}, err
 	}
@@ -49,15 +112,12 @@ func (p *provider) vfsCreate(
 		NoSyncOnClose: p.st.NoSyncOnClose,
 		BytesPerSync:  p.st.BytesPerSync,
 	})
-	meta := objstorage.ObjectMetadata{
-		DiskFileNum: fileNum,
-		FileType:    fileType,
-	}
 	return newFileBufferedWritable(file), meta, nil
 }
 
-func (p *provider) vfsRemove(fileType base.FileType, fileNum base.DiskFileNum) error {
-	return p.st.FSCleaner.Clean(p.st.FS, fileType, p.vfsPath(fileType, fileNum))
+func (p *provider) vfsRemove(meta objstorage.ObjectMetadata) error {
+	fs, _ := p.vfsTierLocation(meta.Local.Tier)
+	return p.st.FSCleaner.Clean(fs, meta.FileType, p.vfsPath(meta))
 }
 
 // vfsInit finds any local FS objects.
@@ -81,9 +141,87 @@ func (p *provider) vfsInit() error {
 			p.mu.knownObjects[o.DiskFileNum] = o
 		}
 	}
+
+	if p.coldTier.fs == nil {
+		return nil
+	}
+	listing, err := p.coldTier.fs.List(p.coldTier.dirName)
+	if err != nil {
+		return errors.Wrapf(err, "pebble: could not list cold tier directory")
+	}
+	for _, filename := range listing {
+		fileType, fileNum, ok := base.ParseFilename(p.coldTier.fs, filename)
+		if !ok || fileType != base.FileTypeTable {
+			continue
+		}
+		if _, ok := p.mu.knownObjects[fileNum]; ok {
+			// The cold tier shares its location with the hot tier.
+			continue
+		}
+		o := objstorage.ObjectMetadata{
+			FileType:    fileType,
+			DiskFileNum: fileNum,
+		}
+		o.Local.Tier = objstorage.LocalTierCold
+		p.mu.knownObjects[o.DiskFileNum] = o
+	}
+	return nil
+}
+
+// localCacheInit opens the local object cache, if one is configured.
+func (p *provider) localCacheInit() error {
+	st := &p.st.Local.Cache
+	if st.SizeBytes <= 0 {
+		return nil
+	}
+	fs, dirName := st.FS, st.DirName
+	if fs == nil {
+		fs = p.st.FS
+	}
+	if dirName == "" {
+		dirName = p.st.FSDirName
+	}
+	if err := fs.MkdirAll(dirName, 0755); err != nil {
+		return errors.Wrapf(err, "pebble: could not create local object cache directory")
+	}
+	var err error
+	p.localCache, err = openCache(fs, p.st.Logger, dirName, localCacheFilePrefix, st.SizeBytes, 0, 0, 0)
+	if err != nil {
+		return errors.Wrapf(err, "pebble: could not open local object cache")
+	}
 	return nil
 }
 
+// openCache opens a sharedcache.Cache, substituting defaults for the zero
+// values of blockSize, shardingBlockSize and numShards.
+func openCache(
+	fs vfs.FS,
+	logger base.Logger,
+	dirName string,
+	filePrefix string,
+	sizeBytes int64,
+	blockSize int,
+	shardingBlockSize int64,
+	numShards int,
+) (*sharedcache.Cache, error) {
+	const defaultBlockSize = 32 * 1024
+	if blockSize == 0 {
+		blockSize = defaultBlockSize
+	}
+
+	const defaultShardingBlockSize = 1024 * 1024
+	if shardingBlockSize == 0 {
+		shardingBlockSize = defaultShardingBlockSize
+	}
+
+	if numShards == 0 {
+		numShards = 2 * runtime.GOMAXPROCS(0)
+	}
+
+	return sharedcache.OpenWithFilePrefix(
+		fs, logger, dirName, filePrefix, blockSize, shardingBlockSize, sizeBytes, numShards)
+}
+
 func (p *provider) vfsSync() error {
 	p.mu.Lock()
 	counterVal := p.mu.localObjectsChangeCounter
@@ -96,6 +234,11 @@ func (p *provider) vfsSync() error {
 	if err := p.fsDir.Sync(); err != nil {
 		return err
 	}
+	if p.coldTier.dir != nil {
+		if err := p.coldTier.dir.Sync(); err != nil {
+			return err
+		}
+	}
 
 	p.mu.Lock()
 	if p.mu.localObjectsChangeCounterSynced < counterVal {
@@ -106,9 +249,9 @@ func (p *provider) vfsSync() error {
 	return nil
 }
 
-func (p *provider) vfsSize(fileType base.FileType, fileNum base.DiskFileNum) (int64, error) {
-	filename := p.vfsPath(fileType, fileNum)
-	stat, err := p.st.FS.Stat(filename)
+func (p *provider) vfsSize(meta objstorage.ObjectMetadata) (int64, error) {
+	fs, _ := p.vfsTierLocation(meta.Local.Tier)
+	stat, err := fs.Stat(p.vfsPath(meta))
 	if err != nil {
 		return 0, err
 	}
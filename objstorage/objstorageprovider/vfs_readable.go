@@ -10,8 +10,11 @@ import (
 	"os"
 	"sync"
 
+	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/invariants"
 	"github.com/cockroachdb/pebble/objstorage"
+	"github.com/cockroachdb/pebble/objstorage/objstorageprovider/sharedcache"
+	"github.com/cockroachdb/pebble/objstorage/remote"
 	"github.com/cockroachdb/pebble/vfs"
 )
 
@@ -31,6 +34,13 @@ type fileReadable struct {
 	// sequential reads option (see vfsReadHandle).
 	filename string
 	fs       vfs.FS
+
+	// cache, if set, is the local object cache through which reads are
+	// performed (see Settings.Local.Cache). fileNum identifies the object in
+	// the cache, and objReader reads cache misses from the file.
+	cache     *sharedcache.Cache
+	fileNum   base.DiskFileNum
+	objReader fileObjectReader
 }
 
 var _ objstorage.Readable = (*fileReadable)(nil)
@@ -48,6 +58,7 @@ func newFileReadable(
 		filename:        filename,
 		fs:              fs,
 		readaheadConfig: readaheadConfig,
+		objReader:       fileObjectReader{file: file},
 	}
 	invariants.SetFinalizer(r, func(obj interface{}) {
 		if obj.(*fileReadable).file != nil {
@@ -59,7 +70,10 @@ func newFileReadable(
 }
 
 // ReadAt is part of the objstorage.Readable interface.
-func (r *fileReadable) ReadAt(_ context.Context, p []byte, off int64) error {
+func (r *fileReadable) ReadAt(ctx context.Context, p []byte, off int64) error {
+	if r.cache != nil {
+		return r.cache.ReadAt(ctx, r.fileNum, p, off, &r.objReader, r.size, sharedcache.ReadFlags{})
+	}
 	n, err := r.file.ReadAt(p, off)
 	if invariants.Enabled && err == nil && n != len(p) {
 		panic("short read")
@@ -91,6 +105,9 @@ type vfsReadHandle struct {
 	r             *fileReadable
 	rs            readaheadState
 	readaheadMode ReadaheadMode
+	// forCompaction is set by SetupForCompaction; compaction reads do not add
+	// data to the local object cache.
+	forCompaction bool
 
 	// sequentialFile holds a file descriptor to the same underlying File,
 	// except with fadvise(FADV_SEQUENTIAL) called on it to take advantage of
@@ -135,7 +152,13 @@ func (rh *vfsReadHandle) Close() error {
 }
 
 // ReadAt is part of the objstorage.ReadHandle interface.
-func (rh *vfsReadHandle) ReadAt(_ context.Context, p []byte, offset int64) error {
+func (rh *vfsReadHandle) ReadAt(ctx context.Context, p []byte, offset int64) error {
+	if rh.r.cache != nil {
+		// Reads through the local object cache are served from a faster device,
+		// so we forgo readahead.
+		flags := sharedcache.ReadFlags{ReadOnly: rh.forCompaction}
+		return rh.r.cache.ReadAt(ctx, rh.r.fileNum, p, offset, &rh.r.objReader, rh.r.size, flags)
+	}
 	if rh.sequentialFile != nil {
 		// Use OS-level read-ahead.
 		n, err := rh.sequentialFile.ReadAt(p, offset)
@@ -164,6 +187,10 @@ func (rh *vfsReadHandle) ReadAt(_ context.Context, p []byte, offset int64) error
 
 // SetupForCompaction is part of the objstorage.ReadHandle interface.
 func (rh *vfsReadHandle) SetupForCompaction() {
+	rh.forCompaction = true
+	if rh.r.cache != nil {
+		return
+	}
 	rh.readaheadMode = rh.r.readaheadConfig.Informed()
 	if rh.readaheadMode == FadviseSequential {
 		rh.switchToOSReadahead()
@@ -186,6 +213,29 @@ func (rh *vfsReadHandle) switchToOSReadahead() {
 	}
 }
 
+// fileObjectReader adapts a vfs.File to the remote.ObjectReader interface, so
+// that the local object cache can read cache misses from the file.
+type fileObjectReader struct {
+	file vfs.File
+}
+
+var _ remote.ObjectReader = (*fileObjectReader)(nil)
+
+// ReadAt is part of the remote.ObjectReader interface.
+func (r *fileObjectReader) ReadAt(_ context.Context, p []byte, offset int64) error {
+	n, err := r.file.ReadAt(p, offset)
+	if invariants.Enabled && err == nil && n != len(p) {
+		panic("short read")
+	}
+	return err
+}
+
+// Close is part of the remote.ObjectReader interface. The file is owned (and
+// closed) by the fileReadable.
+func (r *fileObjectReader) Close() error {
+	return nil
+}
+
 // RecordCacheHit is part of the objstorage.ReadHandle interface.
 func (rh *vfsReadHandle) RecordCacheHit(_ context.Context, offset, size int64) {
 	if rh.sequentialFile != nil || rh.readaheadMode == NoReadahead {
@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/pebble/sstable"
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/cockroachdb/pebble/wal"
+	"github.com/cockroachdb/tokenbucket"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -218,11 +219,12 @@ func Open(dirname string, opts *Options) (db *DB, err error) {
 		merge:               opts.Merger.Merge,
 		split:               opts.Comparer.Split,
 		abbreviatedKey:      opts.Comparer.AbbreviatedKey,
-		largeBatchThreshold: (opts.MemTableSize - uint64(memTableEmptySize)) / 2,
+		largeBatchThreshold: (opts.MemTableSize - uint64(memTableEmptySize(opts.Experimental.MemTableKind))) / 2,
 		fileLock:            fileLock,
 		dataDir:             dataDir,
 		closed:              new(atomic.Value),
 		closedCh:            make(chan struct{}),
+		hotRanges:           newHotRangeTracker(opts.Experimental.HotRangeSamplingPeriod, opts.Comparer.Split),
 	}
 	d.mu.versions = &versionSet{}
 	d.diskAvailBytes.Store(math.MaxUint64)
@@ -308,6 +310,13 @@ func Open(dirname string, opts *Options) (db *DB, err error) {
 		BytesPerSync:        opts.BytesPerSync,
 	}
 	providerSettings.Local.ReadaheadConfig = opts.Local.ReadaheadConfig
+	providerSettings.Local.Cache.FS = opts.Experimental.LocalSecondaryCacheFS
+	providerSettings.Local.Cache.DirName = opts.Experimental.LocalSecondaryCacheDir
+	providerSettings.Local.Cache.SizeBytes = opts.Experimental.LocalSecondaryCacheSizeBytes
+	if opts.coldTierConfigured() {
+		providerSettings.Local.ColdTier.FS = opts.Experimental.ColdTier.FS
+		providerSettings.Local.ColdTier.DirName = opts.Experimental.ColdTier.Dir
+	}
 	providerSettings.Remote.StorageFactory = opts.Experimental.RemoteStorage
 	providerSettings.Remote.CreateOnShared = opts.Experimental.CreateOnShared
 	providerSettings.Remote.CreateOnSharedLocator = opts.Experimental.CreateOnSharedLocator
@@ -370,9 +379,13 @@ func Open(dirname string, opts *Options) (db *DB, err error) {
 		MinSyncInterval:      opts.WALMinSyncInterval,
 		FsyncLatency:         d.mu.log.metrics.fsyncLatency,
 		QueueSemChan:         d.commit.logSyncQSem,
+		Compression:          d.walCompression,
 		Logger:               opts.Logger,
 		EventListener:        walEventListenerAdaptor{l: opts.EventListener},
 	}
+	if opts.Experimental.AdaptiveGroupCommit.TargetLatency > 0 {
+		walOpts.MinSyncInterval = d.walMinSyncInterval
+	}
 	if opts.WALFailover != nil {
 		walOpts.Secondary = opts.WALFailover.Secondary
 		walOpts.FailoverOptions = opts.WALFailover.FailoverOptions
@@ -380,6 +393,7 @@ func Open(dirname string, opts *Options) (db *DB, err error) {
 			Buckets: FsyncLatencyBuckets,
 		})
 	}
+	walOpts.Streams = opts.WALStreams
 	walDirs := append(walOpts.Dirs(), opts.WALRecoveryDirs...)
 	wals, err := wal.Scan(walDirs...)
 	if err != nil {
@@ -399,13 +413,6 @@ func Open(dirname string, opts *Options) (db *DB, err error) {
 
 	d.cleanupManager = openCleanupManager(opts, d.objProvider, d.onObsoleteTableDelete, d.getDeletionPacerInfo)
 
-	if manifestExists && !opts.DisableConsistencyCheck {
-		curVersion := d.mu.versions.currentVersion()
-		if err := checkConsistency(curVersion, dirname, d.objProvider); err != nil {
-			return nil, err
-		}
-	}
-
 	tableCacheSize := TableCacheSize(opts.MaxOpenFiles)
 	d.tableCache = newTableCacheContainer(
 		opts.TableCache, d.cacheID, d.objProvider, d.opts, tableCacheSize,
@@ -478,9 +485,10 @@ func Open(dirname string, opts *Options) (db *DB, err error) {
 	}
 
 	// Validate the most-recent OPTIONS file, if there is one.
+	var previousOptions string
 	if previousOptionsFilename != "" {
 		path := opts.FS.PathJoin(dirname, previousOptionsFilename)
-		previousOptions, err := readOptionsFile(opts, path)
+		previousOptions, err = readOptionsFile(opts, path)
 		if err != nil {
 			return nil, err
 		}
@@ -489,6 +497,18 @@ func Open(dirname string, opts *Options) (db *DB, err error) {
 		}
 	}
 
+	if manifestExists && !opts.DisableConsistencyCheck {
+		curVersion := d.mu.versions.currentVersion()
+		if err := checkConsistency(curVersion, dirname, d.objProvider); err != nil {
+			// The tier of a table isn't recorded in the MANIFEST, so the tables
+			// stored on a cold tier that is no longer configured are missing.
+			if !opts.coldTierConfigured() && previousOptionsColdTier(previousOptions) {
+				err = errors.Wrap(err, "pebble: cold tier not configured, but the DB was previously opened with one")
+			}
+			return nil, err
+		}
+	}
+
 	// Replay any newer log files than the ones named in the manifest.
 	var replayWALs wal.Logs
 	for i, w := range wals {
@@ -612,13 +632,23 @@ func Open(dirname string, opts *Options) (db *DB, err error) {
 
 	d.mu.tableStats.cond.L = &d.mu.Mutex
 	d.mu.tableValidation.cond.L = &d.mu.Mutex
+	d.mu.scrub.cond.L = &d.mu.Mutex
 	if !d.opts.ReadOnly {
 		d.maybeCollectTableStatsLocked()
 	}
 	d.calculateDiskAvailableBytes()
 
+	if rate := d.opts.Experimental.TableFormatRewriteBytesPerSecond; rate > 0 {
+		// Allow a burst of up to one second's worth of rewrites.
+		d.mu.compact.tableFormatRewrite.tokens.Init(
+			tokenbucket.TokensPerSecond(rate), tokenbucket.Tokens(rate))
+	}
+
 	d.maybeScheduleFlush()
 	d.maybeScheduleCompaction()
+	if d.opts.Experimental.Scrub.BytesPerSecond > 0 {
+		go d.scrubLoop()
+	}
 
 	// Note: this is a no-op if invariants are disabled or race is enabled.
 	//
@@ -682,6 +712,13 @@ func prepareAndOpenDirs(
 			}
 			f.Close()
 		}
+		for _, stream := range opts.WALStreams {
+			f, err := mkdirAllAndSyncParents(stream.FS, stream.Dirname)
+			if err != nil {
+				return "", nil, err
+			}
+			f.Close()
+		}
 	}
 
 	dataDir, err = opts.FS.OpenDir(dirname)
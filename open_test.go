@@ -331,7 +331,7 @@ func TestNewDBFilenames(t *testing.T) {
 			"LOCK",
 			"MANIFEST-000001",
 			"OPTIONS-000003",
-			"marker.format-version.000005.018",
+			"marker.format-version.000007.020",
 			"marker.manifest.000001.MANIFEST-000001",
 		},
 	}
@@ -1140,6 +1140,128 @@ func TestOpenWALReplayMemtableGrowth(t *testing.T) {
 	db.Close()
 }
 
+func TestOpenWALReplayCompressed(t *testing.T) {
+	// writeWAL writes compressible values to a new DB and returns the FS and the
+	// size of its WALs. The DB is closed without flushing the memtable, so the
+	// values must be recovered from the WAL.
+	writeWAL := func(fmv FormatMajorVersion, compression Compression) (vfs.FS, int64) {
+		mem := vfs.NewMem()
+		opts := &Options{FS: mem, FormatMajorVersion: fmv}
+		opts.Experimental.WALCompression = compression
+		d, err := Open("", opts)
+		require.NoError(t, err)
+		// The WAL created by Open predates the ratchet to fmv. Flush to switch
+		// to a new WAL.
+		require.NoError(t, d.Flush())
+		for i := 0; i < 100; i++ {
+			key := []byte(fmt.Sprintf("key%03d", i))
+			require.NoError(t, d.Set(key, bytes.Repeat(key, 100), Sync))
+		}
+		require.NoError(t, d.Close())
+
+		ls, err := mem.List("")
+		require.NoError(t, err)
+		var size int64
+		for _, name := range ls {
+			if strings.HasSuffix(name, ".log") {
+				fi, err := mem.Stat(name)
+				require.NoError(t, err)
+				size += fi.Size()
+			}
+		}
+		return mem, size
+	}
+
+	// WAL compression is ignored before FormatWALCompression.
+	_, uncompressedSize := writeWAL(FormatFlushableIngestExcises, SnappyCompression)
+	for _, compression := range []Compression{SnappyCompression, ZstdCompression} {
+		t.Run(compression.String(), func(t *testing.T) {
+			mem, size := writeWAL(FormatWALCompression, compression)
+			require.Less(t, size, uncompressedSize/2)
+
+			d, err := Open("", &Options{FS: mem, ReadOnly: true})
+			require.NoError(t, err)
+			defer func() { require.NoError(t, d.Close()) }()
+			for i := 0; i < 100; i++ {
+				key := []byte(fmt.Sprintf("key%03d", i))
+				v, closer, err := d.Get(key)
+				require.NoError(t, err)
+				require.Equal(t, bytes.Repeat(key, 100), v)
+				require.NoError(t, closer.Close())
+			}
+		})
+	}
+}
+
+func TestOpenWALStreams(t *testing.T) {
+	mem := vfs.NewCrashableMem()
+	streams := []wal.Dir{
+		{FS: mem, Dirname: "wal1"},
+		{FS: mem, Dirname: "wal2"},
+	}
+	opts := &Options{FS: mem, WALStreams: streams}
+	d, err := Open("", opts)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key%03d", i))
+		require.NoError(t, d.Set(key, key, Sync))
+	}
+	// Simulate a crash. All the synced writes must be recovered, although they
+	// were spread across the streams.
+	crashFS := mem.CrashClone(vfs.CrashCloneCfg{UnsyncedDataPercent: 0})
+	require.NoError(t, d.Close())
+
+	for _, dirname := range []string{"", "wal1", "wal2"} {
+		ls, err := crashFS.List(dirname)
+		require.NoError(t, err)
+		var found bool
+		for _, name := range ls {
+			if _, _, ok := wal.ParseLogFilename(name); ok {
+				fi, err := crashFS.Stat(crashFS.PathJoin(dirname, name))
+				require.NoError(t, err)
+				found = found || fi.Size() > 0
+			}
+		}
+		require.Truef(t, found, "no WAL written to %q", dirname)
+	}
+
+	checkKeys := func(d *DB) {
+		for i := 0; i < 100; i++ {
+			key := []byte(fmt.Sprintf("key%03d", i))
+			v, closer, err := d.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, key, v)
+			require.NoError(t, closer.Close())
+		}
+	}
+	streams = []wal.Dir{
+		{FS: crashFS, Dirname: "wal1"},
+		{FS: crashFS, Dirname: "wal2"},
+	}
+
+	// The stream directories are required for recovery.
+	_, err = Open("", &Options{FS: crashFS, ReadOnly: true})
+	require.True(t, errors.As(err, new(ErrMissingWALRecoveryDir)), "%v", err)
+
+	d, err = Open("", &Options{FS: crashFS, ReadOnly: true, WALRecoveryDirs: streams})
+	require.NoError(t, err)
+	checkKeys(d)
+	require.NoError(t, d.Close())
+
+	d, err = Open("", &Options{FS: crashFS, WALStreams: streams})
+	require.NoError(t, err)
+	checkKeys(d)
+	require.NoError(t, d.Close())
+
+	// WALStreams cannot be combined with WALFailover.
+	_, err = Open("", &Options{
+		FS:          crashFS,
+		WALStreams:  streams,
+		WALFailover: &WALFailoverOptions{Secondary: wal.Dir{FS: crashFS, Dirname: "secondary"}},
+	})
+	require.Error(t, err)
+}
+
 func TestPeek(t *testing.T) {
 	// The file paths are UNIX-oriented. To avoid duplicating the test fixtures
 	// just for Windows, just skip the tests on Windows.
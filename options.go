@@ -21,11 +21,13 @@ import (
 	"github.com/cockroachdb/pebble/internal/humanize"
 	"github.com/cockroachdb/pebble/internal/keyspan"
 	"github.com/cockroachdb/pebble/internal/manifest"
+	"github.com/cockroachdb/pebble/objstorage"
 	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
 	"github.com/cockroachdb/pebble/objstorage/remote"
 	"github.com/cockroachdb/pebble/rangekey"
 	"github.com/cockroachdb/pebble/sstable"
 	"github.com/cockroachdb/pebble/sstable/block"
+	"github.com/cockroachdb/pebble/sstable/colblk"
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/cockroachdb/pebble/wal"
 )
@@ -72,6 +74,9 @@ type ShortAttributeExtractor = base.ShortAttributeExtractor
 // UserKeyPrefixBound exports the sstable.UserKeyPrefixBound type.
 type UserKeyPrefixBound = sstable.UserKeyPrefixBound
 
+// ValueSchema exports the colblk.ValueSchema type.
+type ValueSchema = colblk.ValueSchema
+
 // IterKeyType configures which types of keys an iterator should surface.
 type IterKeyType int8
 
@@ -186,9 +191,31 @@ type IterOptions struct {
 	// CategoryAndQoS is used for categorized iterator stats. This should not be
 	// changed by calling SetOptions.
 	sstable.CategoryAndQoS
+	// CachePriority is the block cache priority of the data blocks loaded by
+	// the iterator. Scans that are not expected to be repeated should use
+	// CacheLowPriority. Data blocks of sstables overlapping Options.PinnedSpans
+	// are cached with CacheHighPriority regardless. This should not be changed
+	// by calling SetOptions.
+	CachePriority CachePriority
+	// BypassCache configures the iterator to read the blocks it loads into a
+	// small private buffer pool, retaining at most 256 KB, instead of inserting
+	// them into the block cache. Blocks that are already cached are still read
+	// from the cache. Like setting CachePriority to CacheLowPriority, this
+	// prevents full-table scans (eg, backups and exports) from evicting the
+	// working set from the block cache. This should not be changed by calling
+	// SetOptions.
+	BypassCache bool
 
 	DebugRangeKeyStack bool
 
+	// Projection, if non-nil, configures the iterator to only decode the
+	// listed columns of values decomposed by Options.Experimental.ValueSchema.
+	// The fields of columns that are not listed are zero in the values
+	// surfaced by the iterator. Values that are not decomposed, including
+	// values in memtables and in sstables without columnar blocks, are
+	// surfaced whole, so readers must tolerate both.
+	Projection []int
+
 	// Internal options.
 
 	logger Logger
@@ -481,6 +508,13 @@ type Options struct {
 	// The default cache size is 8 MB.
 	Cache *cache.Cache
 
+	// PinnedSpans are user key spans whose data blocks are cached with
+	// CacheHighPriority, so that they are retained preferentially over other
+	// data blocks (see CachePriority). The priority is applied at sstable
+	// granularity: all data blocks of an sstable that overlaps a pinned span
+	// are cached with high priority.
+	PinnedSpans []KeyRange
+
 	// LoadBlockSema, if set, is used to limit the number of blocks that can be
 	// loaded (i.e. read from the filesystem) in parallel. Each load acquires one
 	// unit from the semaphore for the duration of the read.
@@ -599,6 +633,14 @@ type Options struct {
 		// gets multiplied with a constant of 1 << 16 to yield 1 << 20 (1MB).
 		ReadSamplingMultiplier int64
 
+		// HotRangeSamplingPeriod, if positive, enables tracking of the sstables
+		// and key prefixes that receive the most reads. One in every
+		// HotRangeSamplingPeriod Get operations is sampled, and iterator reads
+		// are sampled at the rate controlled by ReadSamplingMultiplier. The
+		// sampled statistics are exposed through DB.HotRanges. The default is
+		// 0, which disables tracking.
+		HotRangeSamplingPeriod int
+
 		// NumDeletionsThreshold defines the minimum number of point tombstones
 		// that must be present in a single data block for that block to be
 		// considered tombstone-dense for the purposes of triggering a
@@ -721,6 +763,17 @@ type Options struct {
 		// in value blocks.
 		RequiredInPlaceValueBound UserKeyPrefixBound
 
+		// ValueSchema, if it has columns, decomposes values stored in-place
+		// in sstables with columnar data blocks into typed columns. Iterators
+		// configured with IterOptions.Projection only decode the projected
+		// columns. The schema's name is recorded in the properties of the
+		// tables written with it, and opening a table written with a
+		// different schema, or with no schema configured, fails with an
+		// error. Tables written before a schema was configured have no value
+		// columns and may be read with any schema. Only table formats with
+		// columnar blocks (TableFormatPebblev5 and higher) make use of it.
+		ValueSchema ValueSchema
+
 		// DisableIngestAsFlushable disables lazy ingestion of sstables through
 		// a WAL write and memtable rotation. Only effectual if the format
 		// major version is at least `FormatFlushableIngest`.
@@ -746,6 +799,111 @@ type Options struct {
 		// on shared storage in bytes. If it is 0, no cache is used.
 		SecondaryCacheSizeBytes int64
 
+		// LocalSecondaryCacheSizeBytes is the size in bytes of an on-disk block
+		// cache for sstables stored on the local filesystem (FS). It is intended
+		// to be placed on a device that is faster than the one backing FS, such
+		// as a small NVMe drive in front of an HDD or network-attached volume.
+		// If it is 0, no cache is used.
+		//
+		// The cache is stored in LocalSecondaryCacheDir on
+		// LocalSecondaryCacheFS. If LocalSecondaryCacheFS is nil, FS is used, and
+		// if LocalSecondaryCacheDir is empty, the DB directory is used.
+		LocalSecondaryCacheSizeBytes int64
+		LocalSecondaryCacheFS        vfs.FS
+		LocalSecondaryCacheDir       string
+
+		// ColdTier configures tiered placement of local sstables. If
+		// ColdTier.StartLevel is positive, sstables written to levels at or
+		// below StartLevel are stored in ColdTier.Dir on ColdTier.FS instead of
+		// in the DB directory on FS. Compactions moving a table across the
+		// StartLevel boundary copy it to the other tier. Ingested tables are
+		// placed on the hot tier until they are compacted.
+		//
+		// If ColdTier.FS is nil, FS is used, and if ColdTier.Dir is empty, the
+		// DB directory is used. At least one of the two must be set for the cold
+		// tier to be enabled. The tier of each table is not recorded, so a DB
+		// with tables on the cold tier must be opened with the same ColdTier.FS
+		// and ColdTier.Dir; otherwise, Open fails. To move the tables off the
+		// cold tier, keep them configured with a StartLevel of zero: the tables
+		// remain readable, and are moved to the hot tier as they're compacted.
+		ColdTier struct {
+			FS         vfs.FS
+			Dir        string
+			StartLevel int
+		}
+
+		// Scrub configures the background scrubbing of sstables (see
+		// DB.Scrub). If Scrub.BytesPerSecond is positive, a background
+		// goroutine reads every live sstable at that rate, verifying block
+		// checksums and key ordering, and waits Scrub.Interval between the end
+		// of one pass and the start of the next. Corruption is reported through
+		// EventListener.DataCorruption. The default Interval is 24 hours.
+		//
+		// If Scrub.MarkCorruptFiles is true, tables found to be corrupt are
+		// marked for compaction so that they are rewritten. A rewrite can only
+		// succeed if the corruption is not present in the data read by the
+		// compaction, e.g. if it is confined to a local cached copy of a table
+		// on shared storage, or if it was caused by a transient read error.
+		Scrub struct {
+			BytesPerSecond   int64
+			Interval         time.Duration
+			MarkCorruptFiles bool
+		}
+
+		// TableFormatRewriteBytesPerSecond configures the background rewriting
+		// of sstables written in an older table format. If it is positive and
+		// the DB's format major version is at least FormatColumnarBlocks, tables
+		// in L1 and below that were written in an older table format are
+		// rewritten, one at a time and into the same level, in the newest table
+		// format at approximately this many bytes per second. These
+		// table-format-rewrite compactions are only scheduled when no other
+		// automatic compaction is picked. Progress is reported by
+		// Metrics.Compact.TableFormatRewritePendingCount and
+		// TableFormatRewritePendingSize. The default of zero disables rewrites.
+		TableFormatRewriteBytesPerSecond int64
+
+		// WALCompression is the compression applied to the chunks of newly
+		// created write-ahead logs. Only SnappyCompression and ZstdCompression
+		// enable compression; the zero value (DefaultCompression) and
+		// NoCompression leave the WAL uncompressed. Compression is only applied
+		// once the DB's format major version is at least FormatWALCompression,
+		// since earlier versions of Pebble cannot read compressed WALs.
+		WALCompression Compression
+
+		// AdaptiveGroupCommit configures adaptive batching of WAL syncs. When
+		// AdaptiveGroupCommit.TargetLatency is positive and at least
+		// AdaptiveGroupCommit.MinConcurrency commits are waiting for the next
+		// WAL sync, the sync is delayed by up to TargetLatency after the
+		// previous sync so that more commits can accumulate and share it. With
+		// fewer waiting commits the WAL is synced as soon as possible (subject
+		// to WALMinSyncInterval). This trades commit latency for fewer syncs in
+		// fsync-heavy workloads with many concurrent writers. The default
+		// MinConcurrency is 4.
+		AdaptiveGroupCommit struct {
+			TargetLatency  time.Duration
+			MinConcurrency int
+		}
+
+		// MemTableKind selects the data structure holding the point keys of
+		// memtables, from the kinds built into Pebble. The default is
+		// SkiplistMemTable. See VectorMemTable for a memtable suited to bulk
+		// loading, and HashBucketMemTable for a memtable suited to point
+		// lookups.
+		MemTableKind MemTableKind
+
+		// MemTableApplyConcurrency is the maximum number of goroutines used to
+		// apply a single batch to the memtable. Concurrent commits always apply
+		// their batches to the memtable concurrently, outside of the commit
+		// pipeline's mutex; this option additionally splits a large batch into
+		// chunks of entries that are inserted concurrently, which benefits
+		// workloads committing large batches from few goroutines, such as bulk
+		// loads. Sequence numbers are still published in order by the commit
+		// pipeline once a batch has been fully applied. The default of 1
+		// applies each batch from the committing goroutine. Concurrent
+		// application requires a memtable that supports concurrent inserts,
+		// which every MemTableKind does.
+		MemTableApplyConcurrency int
+
 		// NB: DO NOT crash on SingleDeleteInvariantViolationCallback or
 		// IneffectualSingleDeleteCallback, since these can be false positives
 		// even if SingleDel has been used correctly.
@@ -1060,6 +1218,20 @@ type Options struct {
 	// unavailability.
 	WALFailover *WALFailoverOptions
 
+	// WALStreams may be set to write each write-ahead log as parallel streams,
+	// one in WALDir and one in each of the WALStreams directories. Batches are
+	// assigned to the streams round-robin, and each stream is written and
+	// synced independently, which allows WAL throughput to scale with the
+	// number of devices when the directories are on separate devices. Recovery
+	// interleaves the streams in the same round-robin order. WALStreams cannot
+	// be combined with WALFailover.
+	//
+	// The WALs written to the WALStreams directories are required for
+	// recovery, so if WALStreams is changed, the previous directories must be
+	// included in WALRecoveryDirs. A directory with a nil FS uses Options.FS;
+	// directories parsed from an OPTIONS file always do.
+	WALStreams []wal.Dir
+
 	// WALRecoveryDirs is a list of additional directories that should be
 	// scanned for the existence of additional write-ahead logs. WALRecoveryDirs
 	// is expected to be used when starting Pebble with a new WALDir or a new
@@ -1291,6 +1463,11 @@ func (o *Options) EnsureDefaults() *Options {
 	if o.WALFailover != nil {
 		o.WALFailover.FailoverOptions.EnsureDefaults()
 	}
+	for i := range o.WALStreams {
+		if o.WALStreams[i].FS == nil {
+			o.WALStreams[i].FS = o.FS
+		}
+	}
 	if o.Experimental.LevelMultiplier <= 0 {
 		o.Experimental.LevelMultiplier = defaultLevelMultiplier
 	}
@@ -1315,6 +1492,18 @@ func (o *Options) EnsureDefaults() *Options {
 	if o.Experimental.CPUWorkPermissionGranter == nil {
 		o.Experimental.CPUWorkPermissionGranter = defaultCPUWorkGranter{}
 	}
+	if o.Experimental.Scrub.Interval <= 0 {
+		o.Experimental.Scrub.Interval = 24 * time.Hour
+	}
+	if o.Experimental.AdaptiveGroupCommit.MinConcurrency <= 0 {
+		o.Experimental.AdaptiveGroupCommit.MinConcurrency = 4
+	}
+	if o.Experimental.MemTableKind == nil {
+		o.Experimental.MemTableKind = SkiplistMemTable
+	}
+	if o.Experimental.MemTableApplyConcurrency <= 0 {
+		o.Experimental.MemTableApplyConcurrency = 1
+	}
 	if o.Experimental.MultiLevelCompactionHeuristic == nil {
 		o.Experimental.MultiLevelCompactionHeuristic = WriteAmpHeuristic{}
 	}
@@ -1374,6 +1563,55 @@ func (o *Options) Level(level int) LevelOptions {
 	return l
 }
 
+// coldTierConfigured returns true if the location of the cold tier is
+// configured (see Options.Experimental.ColdTier), in which case the tables
+// stored on it are readable.
+func (o *Options) coldTierConfigured() bool {
+	ct := &o.Experimental.ColdTier
+	return ct.FS != nil || ct.Dir != ""
+}
+
+// coldTierEnabled returns true if local sstables in some levels are placed on
+// the cold tier (see Options.Experimental.ColdTier).
+func (o *Options) coldTierEnabled() bool {
+	return o.Experimental.ColdTier.StartLevel > 0 && o.coldTierConfigured()
+}
+
+// previousOptionsColdTier returns true if the provided contents of an OPTIONS
+// file configure the cold tier, as far as it can be told: the cold tier FS is
+// not recorded.
+func previousOptionsColdTier(previousOptions string) bool {
+	var configured bool
+	_ = parseOptions(previousOptions, func(section, key, value string) error {
+		switch section + "." + key {
+		case "Options.cold_tier_dir":
+			configured = configured || value != ""
+		case "Options.cold_tier_start_level":
+			n, err := strconv.Atoi(value)
+			configured = configured || (err == nil && n > 0)
+		}
+		return nil
+	})
+	return configured
+}
+
+// localTier returns the local storage tier on which sstables written to the
+// given level are created.
+func (o *Options) localTier(level int) objstorage.LocalTier {
+	if o.coldTierEnabled() && level >= o.Experimental.ColdTier.StartLevel {
+		return objstorage.LocalTierCold
+	}
+	return objstorage.LocalTierHot
+}
+
+// localTierFS returns the filesystem backing the given local storage tier.
+func (o *Options) localTierFS(tier objstorage.LocalTier) vfs.FS {
+	if tier == objstorage.LocalTierCold && o.Experimental.ColdTier.FS != nil {
+		return o.Experimental.ColdTier.FS
+	}
+	return o.FS
+}
+
 // Clone creates a shallow-copy of the supplied options.
 func (o *Options) Clone() *Options {
 	n := &Options{}
@@ -1427,6 +1665,12 @@ func (o *Options) String() string {
 	fmt.Fprintf(&buf, "  max_concurrent_downloads=%d\n", o.MaxConcurrentDownloads())
 	fmt.Fprintf(&buf, "  max_manifest_file_size=%d\n", o.MaxManifestFileSize)
 	fmt.Fprintf(&buf, "  max_open_files=%d\n", o.MaxOpenFiles)
+	if o.Experimental.MemTableApplyConcurrency > 1 {
+		fmt.Fprintf(&buf, "  mem_table_apply_concurrency=%d\n", o.Experimental.MemTableApplyConcurrency)
+	}
+	if o.Experimental.MemTableKind != nil && o.Experimental.MemTableKind.Name() != SkiplistMemTable.Name() {
+		fmt.Fprintf(&buf, "  mem_table_kind=%s\n", o.Experimental.MemTableKind.Name())
+	}
 	fmt.Fprintf(&buf, "  mem_table_size=%d\n", o.MemTableSize)
 	fmt.Fprintf(&buf, "  mem_table_stop_writes_threshold=%d\n", o.MemTableStopWritesThreshold)
 	fmt.Fprintf(&buf, "  min_deletion_rate=%d\n", o.TargetByteDeletionRate)
@@ -1442,6 +1686,15 @@ func (o *Options) String() string {
 	// We no longer care about strict_wal_tail, but set it to true in case an
 	// older version reads the options.
 	fmt.Fprintf(&buf, "  strict_wal_tail=%t\n", true)
+	fmt.Fprintf(&buf, "  scrub_bytes_per_second=%d\n", o.Experimental.Scrub.BytesPerSecond)
+	fmt.Fprintf(&buf, "  scrub_interval=%s\n", o.Experimental.Scrub.Interval)
+	fmt.Fprintf(&buf, "  scrub_mark_corrupt_files=%t\n", o.Experimental.Scrub.MarkCorruptFiles)
+	if o.Experimental.TableFormatRewriteBytesPerSecond > 0 {
+		fmt.Fprintf(&buf, "  table_format_rewrite_bytes_per_second=%d\n", o.Experimental.TableFormatRewriteBytesPerSecond)
+	}
+	fmt.Fprintf(&buf, "  wal_compression=%s\n", o.Experimental.WALCompression)
+	fmt.Fprintf(&buf, "  adaptive_group_commit_target_latency=%s\n", o.Experimental.AdaptiveGroupCommit.TargetLatency)
+	fmt.Fprintf(&buf, "  adaptive_group_commit_min_concurrency=%d\n", o.Experimental.AdaptiveGroupCommit.MinConcurrency)
 	fmt.Fprintf(&buf, "  table_cache_shards=%d\n", o.Experimental.TableCacheShards)
 	fmt.Fprintf(&buf, "  validate_on_ingest=%t\n", o.Experimental.ValidateOnIngest)
 	fmt.Fprintf(&buf, "  wal_dir=%s\n", o.WALDir)
@@ -1449,6 +1702,10 @@ func (o *Options) String() string {
 	fmt.Fprintf(&buf, "  max_writer_concurrency=%d\n", o.Experimental.MaxWriterConcurrency)
 	fmt.Fprintf(&buf, "  force_writer_parallelism=%t\n", o.Experimental.ForceWriterParallelism)
 	fmt.Fprintf(&buf, "  secondary_cache_size_bytes=%d\n", o.Experimental.SecondaryCacheSizeBytes)
+	fmt.Fprintf(&buf, "  local_secondary_cache_size_bytes=%d\n", o.Experimental.LocalSecondaryCacheSizeBytes)
+	fmt.Fprintf(&buf, "  local_secondary_cache_dir=%s\n", o.Experimental.LocalSecondaryCacheDir)
+	fmt.Fprintf(&buf, "  cold_tier_dir=%s\n", o.Experimental.ColdTier.Dir)
+	fmt.Fprintf(&buf, "  cold_tier_start_level=%d\n", o.Experimental.ColdTier.StartLevel)
 	fmt.Fprintf(&buf, "  create_on_shared=%d\n", o.Experimental.CreateOnShared)
 
 	// Private options.
@@ -1479,6 +1736,13 @@ func (o *Options) String() string {
 		fmt.Fprintf(&buf, "  unhealthy_operation_latency_threshold=%s\n", unhealthyThreshold)
 		fmt.Fprintf(&buf, "  elevated_write_stall_threshold_lag=%s\n", o.WALFailover.FailoverOptions.ElevatedWriteStallThresholdLag)
 	}
+	if len(o.WALStreams) > 0 {
+		fmt.Fprintf(&buf, "\n")
+		fmt.Fprintf(&buf, "[WAL Streams]\n")
+		for _, d := range o.WALStreams {
+			fmt.Fprintf(&buf, "  dir=%s\n", d.Dirname)
+		}
+	}
 
 	for i := range o.Levels {
 		l := &o.Levels[i]
@@ -1694,6 +1958,10 @@ func (o *Options) Parse(s string, hooks *ParseHooks) error {
 				o.MaxManifestFileSize, err = strconv.ParseInt(value, 10, 64)
 			case "max_open_files":
 				o.MaxOpenFiles, err = strconv.Atoi(value)
+			case "mem_table_apply_concurrency":
+				o.Experimental.MemTableApplyConcurrency, err = strconv.Atoi(value)
+			case "mem_table_kind":
+				o.Experimental.MemTableKind, err = ParseMemTableKind(value)
 			case "mem_table_size":
 				o.MemTableSize, err = strconv.ParseUint(value, 10, 64)
 			case "mem_table_stop_writes_threshold":
@@ -1763,6 +2031,31 @@ func (o *Options) Parse(s string, hooks *ParseHooks) error {
 				err = parseErr
 			case "tombstone_dense_compaction_threshold":
 				o.Experimental.TombstoneDenseCompactionThreshold, err = strconv.ParseFloat(value, 64)
+			case "scrub_bytes_per_second":
+				o.Experimental.Scrub.BytesPerSecond, err = strconv.ParseInt(value, 10, 64)
+			case "scrub_interval":
+				o.Experimental.Scrub.Interval, err = time.ParseDuration(value)
+			case "scrub_mark_corrupt_files":
+				o.Experimental.Scrub.MarkCorruptFiles, err = strconv.ParseBool(value)
+			case "adaptive_group_commit_target_latency":
+				o.Experimental.AdaptiveGroupCommit.TargetLatency, err = time.ParseDuration(value)
+			case "adaptive_group_commit_min_concurrency":
+				o.Experimental.AdaptiveGroupCommit.MinConcurrency, err = strconv.Atoi(value)
+			case "table_format_rewrite_bytes_per_second":
+				o.Experimental.TableFormatRewriteBytesPerSecond, err = strconv.ParseInt(value, 10, 64)
+			case "wal_compression":
+				switch value {
+				case "Default":
+					o.Experimental.WALCompression = DefaultCompression
+				case "NoCompression":
+					o.Experimental.WALCompression = NoCompression
+				case "Snappy":
+					o.Experimental.WALCompression = SnappyCompression
+				case "ZSTD":
+					o.Experimental.WALCompression = ZstdCompression
+				default:
+					return errors.Errorf("pebble: unknown compression: %q", errors.Safe(value))
+				}
 			case "table_cache_shards":
 				o.Experimental.TableCacheShards, err = strconv.Atoi(value)
 			case "table_format":
@@ -1786,6 +2079,14 @@ func (o *Options) Parse(s string, hooks *ParseHooks) error {
 				o.Experimental.ForceWriterParallelism, err = strconv.ParseBool(value)
 			case "secondary_cache_size_bytes":
 				o.Experimental.SecondaryCacheSizeBytes, err = strconv.ParseInt(value, 10, 64)
+			case "local_secondary_cache_size_bytes":
+				o.Experimental.LocalSecondaryCacheSizeBytes, err = strconv.ParseInt(value, 10, 64)
+			case "local_secondary_cache_dir":
+				o.Experimental.LocalSecondaryCacheDir = value
+			case "cold_tier_dir":
+				o.Experimental.ColdTier.Dir = value
+			case "cold_tier_start_level":
+				o.Experimental.ColdTier.StartLevel, err = strconv.Atoi(value)
 			case "create_on_shared":
 				var createOnSharedInt int64
 				createOnSharedInt, err = strconv.ParseInt(value, 10, 64)
@@ -1832,6 +2133,19 @@ func (o *Options) Parse(s string, hooks *ParseHooks) error {
 			}
 			return err
 
+		case section == "WAL Streams":
+			switch key {
+			case "dir":
+				o.WALStreams = append(o.WALStreams, wal.Dir{Dirname: value})
+			default:
+				if hooks != nil && hooks.SkipUnknown != nil && hooks.SkipUnknown(section+"."+key, value) {
+					return nil
+				}
+				return errors.Errorf("pebble: unknown option: %s.%s",
+					errors.Safe(section), errors.Safe(key))
+			}
+			return nil
+
 		case strings.HasPrefix(section, "Level "):
 			var index int
 			if n, err := fmt.Sscanf(section, `Level "%d"`, &index); err != nil {
@@ -1934,13 +2248,18 @@ func (o *Options) CheckCompatibility(previousOptions string) error {
 				return errors.Errorf("pebble: merger name from file %q != merger name from options %q",
 					errors.Safe(value), errors.Safe(o.Merger.Name))
 			}
-		case "Options.wal_dir", "WAL Failover.secondary_dir":
+		case "Options.wal_dir", "WAL Failover.secondary_dir", "WAL Streams.dir":
 			switch {
 			case o.WALDir == value:
 				return nil
 			case o.WALFailover != nil && o.WALFailover.Secondary.Dirname == value:
 				return nil
 			default:
+				for _, d := range o.WALStreams {
+					if d.Dirname == value {
+						return nil
+					}
+				}
 				for _, d := range o.WALRecoveryDirs {
 					if d.Dirname == value {
 						return nil
@@ -1973,6 +2292,12 @@ func (o *Options) Validate() error {
 		fmt.Fprintf(&buf, "MemTableSize (%s) must be < %s\n",
 			humanize.Bytes.Uint64(uint64(o.MemTableSize)), humanize.Bytes.Uint64(maxMemTableSize))
 	}
+	if o.Experimental.MemTableKind != nil &&
+		o.MemTableSize <= uint64(memTableEmptySize(o.Experimental.MemTableKind)) {
+		fmt.Fprintf(&buf, "MemTableSize (%s) must be > the size of an empty %s memtable (%s)\n",
+			humanize.Bytes.Uint64(o.MemTableSize), o.Experimental.MemTableKind.Name(),
+			humanize.Bytes.Uint64(uint64(memTableEmptySize(o.Experimental.MemTableKind))))
+	}
 	if o.MemTableStopWritesThreshold < 2 {
 		fmt.Fprintf(&buf, "MemTableStopWritesThreshold (%d) must be >= 2\n",
 			o.MemTableStopWritesThreshold)
@@ -1985,6 +2310,15 @@ func (o *Options) Validate() error {
 		fmt.Fprintf(&buf, "FormatMajorVersion (%d) when CreateOnShared is set must be at least %d\n",
 			o.FormatMajorVersion, FormatMinForSharedObjects)
 	}
+	if ct := o.Experimental.ColdTier.StartLevel; ct < 0 || ct >= numLevels {
+		fmt.Fprintf(&buf, "ColdTier.StartLevel (%d) must be between 0 and %d\n", ct, numLevels-1)
+	}
+	if o.WALFailover != nil && len(o.WALStreams) > 0 {
+		fmt.Fprintf(&buf, "WALFailover and WALStreams cannot both be set\n")
+	}
+	if err := o.Experimental.ValueSchema.Validate(); err != nil {
+		fmt.Fprintf(&buf, "ValueSchema is invalid: %s\n", err)
+	}
 	if o.TableCache != nil && o.Cache != o.TableCache.cache {
 		fmt.Fprintf(&buf, "underlying cache in the TableCache and the Cache dont match\n")
 	}
@@ -2004,6 +2338,7 @@ func (o *Options) MakeReaderOptions() sstable.ReaderOptions {
 		readerOpts.Merger = o.Merger
 		readerOpts.Filters = o.Filters
 		readerOpts.LoggerAndTracer = o.LoggerAndTracer
+		readerOpts.ValueSchema = o.Experimental.ValueSchema
 	}
 	return readerOpts
 }
@@ -2020,6 +2355,9 @@ func (o *Options) MakeWriterOptions(level int, format sstable.TableFormat) sstab
 		}
 		writerOpts.BlockPropertyCollectors = o.BlockPropertyCollectors
 	}
+	if format.BlockColumnar() {
+		writerOpts.ValueSchema = o.Experimental.ValueSchema
+	}
 	if format >= sstable.TableFormatPebblev3 {
 		writerOpts.ShortAttributeExtractor = o.Experimental.ShortAttributeExtractor
 		writerOpts.RequiredInPlaceValueBound = o.Experimental.RequiredInPlaceValueBound
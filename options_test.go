@@ -105,6 +105,12 @@ func TestOptionsString(t *testing.T) {
   deletion_size_ratio_threshold=0.500000
   tombstone_dense_compaction_threshold=0.050000
   strict_wal_tail=true
+  scrub_bytes_per_second=0
+  scrub_interval=24h0m0s
+  scrub_mark_corrupt_files=false
+  wal_compression=Default
+  adaptive_group_commit_target_latency=0s
+  adaptive_group_commit_min_concurrency=4
   table_cache_shards=8
   validate_on_ingest=false
   wal_dir=
@@ -112,6 +118,10 @@ func TestOptionsString(t *testing.T) {
   max_writer_concurrency=0
   force_writer_parallelism=false
   secondary_cache_size_bytes=0
+  local_secondary_cache_size_bytes=0
+  local_secondary_cache_dir=
+  cold_tier_dir=
+  cold_tier_start_level=0
   create_on_shared=0
 
 [Level "0"]
@@ -295,6 +305,9 @@ func TestOptionsParse(t *testing.T) {
 			opts.Experimental.MaxWriterConcurrency = 1
 			opts.Experimental.ForceWriterParallelism = true
 			opts.Experimental.SecondaryCacheSizeBytes = 1024
+			opts.Experimental.MemTableKind = HashBucketMemTable(16)
+			opts.Experimental.MemTableApplyConcurrency = 4
+			opts.Experimental.TableFormatRewriteBytesPerSecond = 1 << 20
 			opts.EnsureDefaults()
 			str := opts.String()
 
@@ -311,6 +324,25 @@ func TestOptionsParse(t *testing.T) {
 	}
 }
 
+func TestOptionsParseWALStreams(t *testing.T) {
+	var opts Options
+	require.NoError(t, opts.Parse("[WAL Streams]\n  dir=wal1\n  dir=wal2\n", nil))
+	require.Len(t, opts.WALStreams, 2)
+	for _, d := range opts.WALStreams {
+		require.Nil(t, d.FS)
+	}
+
+	// Parsed stream directories use the FS the options are opened with.
+	mem := vfs.NewMem()
+	opts.FS = mem
+	opts.EnsureDefaults()
+	require.Equal(t, "wal1", opts.WALStreams[0].Dirname)
+	require.Equal(t, "wal2", opts.WALStreams[1].Dirname)
+	for _, d := range opts.WALStreams {
+		require.Equal(t, mem, d.FS)
+	}
+}
+
 func TestOptionsValidate(t *testing.T) {
 	testCases := []struct {
 		options  string
@@ -337,6 +369,13 @@ func TestOptionsValidate(t *testing.T) {
 			`MemTableSize \(4\.0GB\) must be < [2|4]\.0GB`,
 		},
 		{`
+[Options]
+  mem_table_kind=hash_bucket(64)
+  mem_table_size=4096
+`,
+			`MemTableSize \(4\.0KB\) must be > the size of an empty hash_bucket\(64\) memtable \(24KB\)`,
+		},
+		{`
 [Options]
   mem_table_stop_writes_threshold=1
 `,
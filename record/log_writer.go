@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/crc"
+	sstableblock "github.com/cockroachdb/pebble/sstable/block"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -432,6 +433,12 @@ type LogWriter struct {
 	// is set to errClosedWriter to inform accidental future calls to
 	// SyncRecord*.
 	err error
+	// compression is the compression used for the chunks of records, if
+	// compressionEnabled is true. compressBuf is the buffer chunks are
+	// compressed into.
+	compression        sstableblock.Compression
+	compressionEnabled bool
+	compressBuf        []byte
 	// block is the current block being written. Protected by flusher.Mutex.
 	block *block
 	free  struct {
@@ -496,6 +503,13 @@ type LogWriterConfig struct {
 	// package) precede the lower layer locks (in the record package). These
 	// callbacks are serialized since they are invoked from the flushLoop.
 	ExternalSyncQueueCallback ExternalSyncQueueCallback
+
+	// Compression is the compression used for the chunks of records. Only
+	// SnappyCompression and ZstdCompression enable compression. A chunk is
+	// written compressed only if compression reduces its size. Records with
+	// compressed chunks can only be read by versions of the Reader which
+	// support compressed chunks.
+	Compression sstableblock.Compression
 }
 
 // ExternalSyncQueueCallback is to be run when a PendingSync has been
@@ -548,6 +562,12 @@ func NewLogWriter(
 		r.flusher.pendingSyncs = &r.pendingSyncsBackingQ
 	}
 
+	switch logWriterConfig.Compression {
+	case sstableblock.SnappyCompression, sstableblock.ZstdCompression:
+		r.compression = logWriterConfig.Compression
+		r.compressionEnabled = true
+	}
+
 	r.free.blocks = make([]*block, 0, initialAllocatedBlocksCap)
 	r.block = blockPool.Get().(*block)
 	r.flusher.ready.init(&r.flusher.Mutex, r.flusher.pendingSyncs)
@@ -925,7 +945,11 @@ func (w *LogWriter) SyncRecordGeneralized(p []byte, ps PendingSync) (logSize int
 	// MANIFEST is currently written using Writer, it is good to support the same
 	// semantics with LogWriter.
 	for i := 0; i == 0 || len(p) > 0; i++ {
-		p = w.emitFragment(i, p)
+		if w.compressionEnabled {
+			p = w.emitCompressedFragment(i, p)
+		} else {
+			p = w.emitFragment(i, p)
+		}
 	}
 
 	if ps.syncRequested() {
@@ -947,6 +971,23 @@ func (w *LogWriter) SyncRecordGeneralized(p []byte, ps PendingSync) (logSize int
 	return offset, nil
 }
 
+// RequestSync requests that all records written to the LogWriter so far are
+// synced, without writing a new record. It is used when a WAL is written as
+// several parallel LogWriters, and a sync of one of them requires syncing the
+// others too.
+// External synchronisation provided by commitPipeline.mu.
+func (w *LogWriter) RequestSync(ps PendingSync) error {
+	if w.err != nil {
+		return w.err
+	}
+	if ps.syncRequested() {
+		f := &w.flusher
+		f.pendingSyncs.push(ps)
+		f.ready.Signal()
+	}
+	return nil
+}
+
 // Size returns the current size of the file.
 // External synchronisation provided by commitPipeline.mu.
 func (w *LogWriter) Size() int64 {
@@ -988,9 +1029,61 @@ func (w *LogWriter) emitFragment(n int, p []byte) (remainingP []byte) {
 	binary.LittleEndian.PutUint32(b.buf[i+7:i+11], w.logNum)
 
 	r := copy(b.buf[i+recyclableHeaderSize:], p)
-	j := i + int32(recyclableHeaderSize+r)
+	w.finishChunk(i, i+int32(recyclableHeaderSize+r))
+	return p[r:]
+}
+
+// minCompressedFragmentLen is the minimum length of a fragment of a record for
+// which compression is attempted.
+const minCompressedFragmentLen = 64
+
+// emitCompressedFragment is like emitFragment, but writes the fragment of the
+// record in a compressed chunk if compression reduces its size. Since the
+// fragment is compressed before it is written, it is limited to the space left
+// in the current block.
+func (w *LogWriter) emitCompressedFragment(n int, p []byte) (remainingP []byte) {
+	b := w.block
+	i := b.written.Load()
+	avail := int(blockSize-i) - recyclableHeaderSize - 1
+	if avail < minCompressedFragmentLen || len(p) < minCompressedFragmentLen {
+		return w.emitFragment(n, p)
+	}
+	fragment := p[:min(len(p), avail)]
+	algo, compressed := sstableblock.Compress(w.compression, fragment, w.compressBuf[:cap(w.compressBuf)])
+	if cap(compressed) > cap(w.compressBuf) {
+		w.compressBuf = compressed[:0]
+	}
+	if algo == sstableblock.NoCompressionIndicator || len(compressed) >= len(fragment) {
+		return w.emitFragment(n, p)
+	}
+
+	first := n == 0
+	last := len(fragment) == len(p)
+	switch {
+	case first && last:
+		b.buf[i+6] = compressedFullChunkType
+	case first:
+		b.buf[i+6] = compressedFirstChunkType
+	case last:
+		b.buf[i+6] = compressedLastChunkType
+	default:
+		b.buf[i+6] = compressedMiddleChunkType
+	}
+	binary.LittleEndian.PutUint32(b.buf[i+7:i+11], w.logNum)
+	b.buf[i+recyclableHeaderSize] = byte(algo)
+	r := copy(b.buf[i+recyclableHeaderSize+1:], compressed)
+	w.finishChunk(i, i+int32(recyclableHeaderSize+1+r))
+	return p[len(fragment):]
+}
+
+// finishChunk completes the chunk in the current block at [i, j), whose type,
+// log number and payload have been written, by writing its checksum and
+// length. If there is no room left in the block for another chunk, the block
+// is queued for flushing.
+func (w *LogWriter) finishChunk(i, j int32) {
+	b := w.block
 	binary.LittleEndian.PutUint32(b.buf[i+0:i+4], crc.New(b.buf[i+6:j]).Value())
-	binary.LittleEndian.PutUint16(b.buf[i+4:i+6], uint16(r))
+	binary.LittleEndian.PutUint16(b.buf[i+4:i+6], uint16(j-i-recyclableHeaderSize))
 	b.written.Store(j)
 
 	if blockSize-b.written.Load() < recyclableHeaderSize {
@@ -999,7 +1092,6 @@ func (w *LogWriter) emitFragment(n int, p []byte) (remainingP []byte) {
 		clear(b.buf[b.written.Load():])
 		w.queueBlock()
 	}
-	return p[r:]
 }
 
 // Metrics must typically be called after Close, since the callee will no
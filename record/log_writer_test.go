@@ -7,6 +7,7 @@ package record
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
 	"sort"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/humanize"
+	sstableblock "github.com/cockroachdb/pebble/sstable/block"
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/cockroachdb/pebble/vfs/errorfs"
 	"github.com/cockroachdb/pebble/vfs/vfstest"
@@ -676,6 +678,28 @@ func TestSyncRecordGeneralized(t *testing.T) {
 	require.Equal(t, f.syncPos.Load(), f.writePos.Load())
 }
 
+func TestLogWriterRequestSync(t *testing.T) {
+	f := &syncFile{}
+	cbChan := make(chan PendingSyncIndex, 1)
+	w := NewLogWriter(f, 0, LogWriterConfig{
+		WALFsyncLatency: prometheus.NewHistogram(prometheus.HistogramOpts{}),
+		ExternalSyncQueueCallback: func(doneSync PendingSyncIndex, err error) {
+			require.NoError(t, err)
+			cbChan <- doneSync
+		},
+	})
+	// Write a record without requesting a sync, and then request a sync
+	// without writing a record. The sync must cover the earlier record.
+	offset, err := w.SyncRecordGeneralized([]byte("hello"), &PendingSyncIndex{Index: NoSyncIndex})
+	require.NoError(t, err)
+	require.NoError(t, w.RequestSync(&PendingSyncIndex{Index: 5}))
+	require.Equal(t, int64(5), (<-cbChan).Index)
+	require.Equal(t, offset, f.writePos.Load())
+	require.Equal(t, offset, f.syncPos.Load())
+	require.NoError(t, w.Close())
+	require.Error(t, w.RequestSync(&PendingSyncIndex{Index: 6}))
+}
+
 type syncFileWithError struct {
 	syncFile
 	err error
@@ -733,6 +757,71 @@ func TestSyncRecordGeneralizedWithCloseError(t *testing.T) {
 	require.Less(t, f.syncPos.Load(), f.writePos.Load())
 }
 
+// TestLogWriterCompression verifies that records written with compressed
+// chunks are read back intact.
+func TestLogWriterCompression(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+	// Compressible records of various lengths, including records spanning
+	// several blocks, interleaved with incompressible records.
+	var records [][]byte
+	for i := 0; i < 200; i++ {
+		n := rng.Intn(3 * blockSize)
+		if i%10 == 0 {
+			n = rng.Intn(minCompressedFragmentLen)
+		}
+		r := make([]byte, n)
+		if i%3 == 0 {
+			rng.Read(r)
+		} else {
+			for j := range r {
+				r[j] = "abcdefgh"[rng.Intn(2)]
+			}
+		}
+		records = append(records, r)
+	}
+
+	write := func(compression sstableblock.Compression) []byte {
+		var buf bytes.Buffer
+		w := NewLogWriter(&buf, 5, LogWriterConfig{
+			WALFsyncLatency: prometheus.NewHistogram(prometheus.HistogramOpts{}),
+			Compression:     compression,
+		})
+		for _, r := range records {
+			_, err := w.WriteRecord(r)
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+	uncompressed := write(sstableblock.NoCompression)
+	for _, compression := range []sstableblock.Compression{
+		sstableblock.SnappyCompression, sstableblock.ZstdCompression,
+	} {
+		t.Run(compression.String(), func(t *testing.T) {
+			data := write(compression)
+			require.Less(t, len(data), len(uncompressed))
+
+			r := NewReader(bytes.NewReader(data), 5)
+			for i := range records {
+				rr, err := r.Next()
+				require.NoError(t, err)
+				got, err := io.ReadAll(rr)
+				require.NoError(t, err)
+				require.Equal(t, records[i], got, "record %d", i)
+			}
+			_, err := r.Next()
+			require.Equal(t, io.EOF, err)
+
+			// A reader for another log number treats the compressed chunks
+			// as those of a previous incarnation of a recycled log.
+			_, err = NewReader(bytes.NewReader(data), 4).Next()
+			require.Equal(t, io.EOF, err)
+		})
+	}
+}
+
 // BenchmarkQueueWALBlocks exercises queueing within the LogWriter. It can be
 // useful to measure allocations involved when flushing is slow enough to
 // accumulate a large backlog fo queued blocks.
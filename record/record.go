@@ -94,6 +94,20 @@
 // (i.e. full, first, middle, last). The CRC is computed over the type, log
 // number, and payload.
 //
+// Records written by a LogWriter configured with a compression may contain
+// compressed chunks, which use the recyclable chunk format with 4 further chunk
+// types (again mapping to full, first, middle and last). The payload of a
+// compressed chunk is a compression indicator byte (see
+// block.CompressionIndicator) followed by the compressed bytes of the
+// fragment of the record held by the chunk:
+//
+//	+----------+-----------+-----------+----------------+-----------------+--- ... ---+
+//	| CRC (4B) | Size (2B) | Type (1B) | Log number (4B)| Compression (1B)| Payload   |
+//	+----------+-----------+-----------+----------------+-----------------+--- ... ---+
+//
+// Each chunk is compressed independently, so a record may mix compressed and
+// uncompressed chunks, and a reader decompresses a record one chunk at a time.
+//
 // The wire format allows for limited recovery in the face of data corruption:
 // on a format error (such as a checksum mismatch), the reader moves to the
 // next block and looks for the next full or first chunk.
@@ -111,6 +125,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/crc"
+	sstableblock "github.com/cockroachdb/pebble/sstable/block"
 )
 
 // These constants are part of the wire format and should not be changed.
@@ -124,6 +139,11 @@ const (
 	recyclableFirstChunkType  = 6
 	recyclableMiddleChunkType = 7
 	recyclableLastChunkType   = 8
+
+	compressedFullChunkType   = 9
+	compressedFirstChunkType  = 10
+	compressedMiddleChunkType = 11
+	compressedLastChunkType   = 12
 )
 
 const (
@@ -178,6 +198,10 @@ type Reader struct {
 	recovering bool
 	// last is whether the current chunk is the last chunk of the record.
 	last bool
+	// decoded is the unread portion of the decompressed payload of the current
+	// chunk, if it is a compressed chunk. It is backed by decodeBuf.
+	decoded   []byte
+	decodeBuf []byte
 	// err is any accumulated error.
 	err error
 	// buf is the buffer.
@@ -224,7 +248,8 @@ func (r *Reader) nextChunk(wantFirst bool) error {
 			}
 
 			headerSize := legacyHeaderSize
-			if chunkType >= recyclableFullChunkType && chunkType <= recyclableLastChunkType {
+			compressed := false
+			if chunkType >= recyclableFullChunkType && chunkType <= compressedLastChunkType {
 				headerSize = recyclableHeaderSize
 				if r.end+headerSize > r.n {
 					return ErrInvalidChunk
@@ -242,7 +267,12 @@ func (r *Reader) nextChunk(wantFirst bool) error {
 					return ErrInvalidChunk
 				}
 
-				chunkType -= (recyclableFullChunkType - 1)
+				if chunkType >= compressedFullChunkType {
+					compressed = true
+					chunkType -= (compressedFullChunkType - 1)
+				} else {
+					chunkType -= (recyclableFullChunkType - 1)
+				}
 			}
 
 			r.begin = r.end + headerSize
@@ -267,6 +297,15 @@ func (r *Reader) nextChunk(wantFirst bool) error {
 					continue
 				}
 			}
+			if compressed {
+				if err := r.decompressChunk(); err != nil {
+					if r.recovering {
+						r.recover()
+						continue
+					}
+					return err
+				}
+			}
 			r.last = chunkType == fullChunkType || chunkType == lastChunkType
 			r.recovering = false
 			return nil
@@ -292,6 +331,31 @@ func (r *Reader) nextChunk(wantFirst bool) error {
 	}
 }
 
+// decompressChunk decompresses the payload of the current chunk, a compressed
+// chunk, into r.decoded, and marks the payload as read.
+func (r *Reader) decompressChunk() error {
+	payload := r.buf[r.begin:r.end]
+	r.begin = r.end
+	if len(payload) == 0 {
+		return ErrInvalidChunk
+	}
+	algo := sstableblock.CompressionIndicator(payload[0])
+	decodedLen, prefixLen, err := sstableblock.DecompressedLen(algo, payload[1:])
+	// A chunk is never larger than a block before it is compressed.
+	if err != nil || decodedLen > blockSize || algo == sstableblock.NoCompressionIndicator {
+		return ErrInvalidChunk
+	}
+	if cap(r.decodeBuf) < decodedLen {
+		r.decodeBuf = make([]byte, blockSize)
+	}
+	r.decoded = r.decodeBuf[:decodedLen]
+	if err := sstableblock.DecompressInto(algo, payload[1+prefixLen:], r.decoded); err != nil {
+		r.decoded = nil
+		return ErrInvalidChunk
+	}
+	return nil
+}
+
 // Next returns a reader for the next record. It returns io.EOF if there are no
 // more records. The reader returned becomes stale after the next Next call,
 // and should no longer be used.
@@ -301,6 +365,7 @@ func (r *Reader) Next() (io.Reader, error) {
 		return nil, r.err
 	}
 	r.begin = r.end
+	r.decoded = nil
 	r.err = r.nextChunk(true)
 	if r.err != nil {
 		return nil, r.err
@@ -330,6 +395,7 @@ func (r *Reader) recover() {
 	r.err = nil
 	// Discard the rest of the current block.
 	r.begin, r.end, r.last = r.n, r.n, false
+	r.decoded = nil
 	// Invalidate any outstanding singleReader.
 	r.seq++
 }
@@ -371,6 +437,7 @@ func (r *Reader) seekRecord(offset int64) error {
 
 	// Clear the state of the internal reader.
 	r.begin, r.end, r.n = 0, 0, 0
+	r.decoded = nil
 	r.blockNum, r.recovering, r.last = -1, false, false
 	if r.err = r.nextChunk(false); r.err != nil {
 		return r.err
@@ -396,7 +463,7 @@ func (x singleReader) Read(p []byte) (int, error) {
 	if r.err != nil {
 		return 0, r.err
 	}
-	for r.begin == r.end {
+	for r.begin == r.end && len(r.decoded) == 0 {
 		if r.last {
 			return 0, io.EOF
 		}
@@ -404,6 +471,11 @@ func (x singleReader) Read(p []byte) (int, error) {
 			return 0, r.err
 		}
 	}
+	if len(r.decoded) > 0 {
+		n := copy(p, r.decoded)
+		r.decoded = r.decoded[n:]
+		return n, nil
+	}
 	n := copy(p, r.buf[r.begin:r.end])
 	r.begin += n
 	return n, nil
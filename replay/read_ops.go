@@ -0,0 +1,267 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/HdrHistogram/hdrhistogram-go"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/oserror"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+// readOpsFilename is the name of the file within a workload directory that
+// holds the workload's captured read operations.
+const readOpsFilename = "READOPS"
+
+// ReadOpKind describes the kind of a captured read operation.
+type ReadOpKind uint8
+
+const (
+	// ReadOpGet is a point lookup through DB.Get.
+	ReadOpGet ReadOpKind = iota
+	// ReadOpSeekGE is an iterator positioned by SeekGE and stepped forward.
+	ReadOpSeekGE
+	// ReadOpSeekPrefixGE is an iterator positioned by SeekPrefixGE and
+	// stepped forward.
+	ReadOpSeekPrefixGE
+	// ReadOpSeekLT is an iterator positioned by SeekLT and stepped backward.
+	ReadOpSeekLT
+	// ReadOpFirst is an iterator positioned by First and stepped forward.
+	ReadOpFirst
+	// ReadOpLast is an iterator positioned by Last and stepped backward.
+	ReadOpLast
+	numReadOpKinds
+)
+
+var readOpKindNames = [numReadOpKinds]string{
+	ReadOpGet:          "get",
+	ReadOpSeekGE:       "seek-ge",
+	ReadOpSeekPrefixGE: "seek-prefix-ge",
+	ReadOpSeekLT:       "seek-lt",
+	ReadOpFirst:        "first",
+	ReadOpLast:         "last",
+}
+
+// String implements fmt.Stringer.
+func (k ReadOpKind) String() string {
+	if k >= numReadOpKinds {
+		return "unknown"
+	}
+	return readOpKindNames[k]
+}
+
+// ReadOp describes a read operation captured by a WorkloadCollector. A ReadOp
+// other than a ReadOpGet describes the lifetime of an iterator: the iterator
+// is created with the bounds, positioned according to the kind and then
+// stepped in the direction implied by the kind.
+type ReadOp struct {
+	Kind ReadOpKind
+	// Key is the key looked up by a ReadOpGet, or the seek key of a
+	// ReadOpSeek{GE,PrefixGE,LT}.
+	Key []byte
+	// LowerBound and UpperBound are the bounds of the iterator, if any.
+	LowerBound []byte
+	UpperBound []byte
+	// Steps is the number of calls to Next or Prev made after positioning the
+	// iterator.
+	Steps int
+}
+
+// capturedReadOp is a read operation captured by a WorkloadCollector, along
+// with the workload step that preceded it.
+type capturedReadOp struct {
+	// step is the number of flushes and ingestions the collector observed
+	// before the operation was recorded. A Runner replays the operation only
+	// once it has applied as many flush and ingest steps of the workload.
+	step uint64
+	op   ReadOp
+}
+
+// encode appends the encoding of the operation, captured after the provided
+// number of workload steps, to buf.
+func (op *ReadOp) encode(buf []byte, step uint64) []byte {
+	buf = append(buf, byte(op.Kind))
+	buf = binary.AppendUvarint(buf, step)
+	buf = binary.AppendUvarint(buf, uint64(len(op.Key)))
+	buf = append(buf, op.Key...)
+	buf = binary.AppendUvarint(buf, uint64(len(op.LowerBound)))
+	buf = append(buf, op.LowerBound...)
+	buf = binary.AppendUvarint(buf, uint64(len(op.UpperBound)))
+	buf = append(buf, op.UpperBound...)
+	return binary.AppendUvarint(buf, uint64(op.Steps))
+}
+
+// readOpsDecoder decodes read operations encoded by ReadOp.encode.
+type readOpsDecoder struct {
+	r *bufio.Reader
+}
+
+// next decodes the next read operation. It returns io.EOF once all operations
+// have been decoded. A truncated final operation, which may be left behind if
+// the capturing process exited abruptly, is treated as the end of the
+// operations.
+func (d *readOpsDecoder) next() (capturedReadOp, error) {
+	var c capturedReadOp
+	kind, err := d.r.ReadByte()
+	if err != nil {
+		return c, err
+	}
+	if ReadOpKind(kind) >= numReadOpKinds {
+		return c, errors.Newf("unknown read op kind %d", kind)
+	}
+	c.op.Kind = ReadOpKind(kind)
+	if c.step, err = binary.ReadUvarint(d.r); err != nil {
+		return c, truncatedAsEOF(err)
+	}
+	for _, dst := range []*[]byte{&c.op.Key, &c.op.LowerBound, &c.op.UpperBound} {
+		n, err := binary.ReadUvarint(d.r)
+		if err != nil {
+			return c, truncatedAsEOF(err)
+		}
+		if n > 0 {
+			*dst = make([]byte, n)
+			if _, err := io.ReadFull(d.r, *dst); err != nil {
+				return c, truncatedAsEOF(err)
+			}
+		}
+	}
+	steps, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return c, truncatedAsEOF(err)
+	}
+	c.op.Steps = int(steps)
+	return c, nil
+}
+
+func truncatedAsEOF(err error) error {
+	if err == io.ErrUnexpectedEOF {
+		return io.EOF
+	}
+	return err
+}
+
+// loadReadOps loads the read operations captured within the workload at the
+// provided path. It returns no operations if the workload has no captured
+// reads.
+func loadReadOps(fs vfs.FS, path string) ([]capturedReadOp, error) {
+	f, err := fs.Open(fs.PathJoin(path, readOpsFilename))
+	if oserror.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	d := readOpsDecoder{r: bufio.NewReader(f)}
+	var ops []capturedReadOp
+	for {
+		op, err := d.next()
+		if err == io.EOF {
+			return ops, nil
+		} else if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+}
+
+const (
+	minReadLatency = 10 * time.Nanosecond
+	maxReadLatency = 10 * time.Second
+)
+
+func newReadLatencyHistogram() *hdrhistogram.Histogram {
+	return hdrhistogram.New(minReadLatency.Nanoseconds(), maxReadLatency.Nanoseconds(), 2)
+}
+
+// readOpsReplayer replays captured read operations against a database from
+// multiple goroutines, recording the latency of each operation.
+type readOpsReplayer struct {
+	d   *pebble.DB
+	ops []capturedReadOp
+	// waitForStep blocks until the provided number of workload steps have
+	// been applied to the database. It returns false if the workload was
+	// exhausted before then.
+	waitForStep func(ctx context.Context, step uint64) (bool, error)
+	// next is the index of the next operation to replay.
+	next atomic.Int64
+}
+
+// run replays read operations until none remain, the workload is exhausted
+// before the step preceding the next operation, or the context is canceled.
+// It returns histograms of the operations' latencies indexed by ReadOpKind. It
+// may be called concurrently.
+func (r *readOpsReplayer) run(
+	ctx context.Context,
+) (hists [numReadOpKinds]*hdrhistogram.Histogram, err error) {
+	for i := range hists {
+		hists[i] = newReadLatencyHistogram()
+	}
+	for ctx.Err() == nil {
+		i := r.next.Add(1) - 1
+		if i >= int64(len(r.ops)) {
+			return hists, nil
+		}
+		op := &r.ops[i].op
+		if ok, err := r.waitForStep(ctx, r.ops[i].step); err != nil || !ok {
+			return hists, err
+		}
+		start := time.Now()
+		if err := r.replay(op); err != nil {
+			return hists, err
+		}
+		_ = hists[op.Kind].RecordValue(time.Since(start).Nanoseconds())
+	}
+	return hists, ctx.Err()
+}
+
+// replay performs a single read operation.
+func (r *readOpsReplayer) replay(op *ReadOp) error {
+	if op.Kind == ReadOpGet {
+		_, closer, err := r.d.Get(op.Key)
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		return closer.Close()
+	}
+	iter, err := r.d.NewIter(&pebble.IterOptions{
+		LowerBound: op.LowerBound,
+		UpperBound: op.UpperBound,
+	})
+	if err != nil {
+		return err
+	}
+	var valid bool
+	step := iter.Next
+	switch op.Kind {
+	case ReadOpSeekGE:
+		valid = iter.SeekGE(op.Key)
+	case ReadOpSeekPrefixGE:
+		valid = iter.SeekPrefixGE(op.Key)
+	case ReadOpSeekLT:
+		valid = iter.SeekLT(op.Key)
+		step = iter.Prev
+	case ReadOpFirst:
+		valid = iter.First()
+	case ReadOpLast:
+		valid = iter.Last()
+		step = iter.Prev
+	default:
+		return errors.Newf("unknown read op kind %s", op.Kind)
+	}
+	for i := 0; valid && i < op.Steps; i++ {
+		valid = step()
+	}
+	return iter.Close()
+}
@@ -22,6 +22,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/HdrHistogram/hdrhistogram-go"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/internal/base"
@@ -136,6 +137,10 @@ type Metrics struct {
 	WriteStalls         map[string]int
 	WriteStallsDuration map[string]time.Duration
 	WriteThroughput     SampledMetric
+	// ReadLatency holds histograms of the latencies in nanoseconds of the
+	// replayed read operations, by kind. It's empty if no read operations
+	// were replayed.
+	ReadLatency map[ReadOpKind]*hdrhistogram.Histogram
 }
 
 // Plot holds an ascii plot and its name.
@@ -243,6 +248,23 @@ func (m *Metrics) WriteBenchmarkString(name string, w io.Writer) error {
 		})
 	}
 
+	// Latencies of the replayed read operations, if any.
+	for kind := ReadOpKind(0); kind < numReadOpKinds; kind++ {
+		h, ok := m.ReadLatency[kind]
+		if !ok || h.TotalCount() == 0 {
+			continue
+		}
+		groups = append(groups, benchmarkSection{
+			label: fmt.Sprintf("ReadLatency/%s", kind),
+			values: []benchfmt.Value{
+				{Value: h.Mean(), Unit: "ns/op"},
+				{Value: float64(h.ValueAtQuantile(50)), Unit: "p50-ns"},
+				{Value: float64(h.ValueAtQuantile(99)), Unit: "p99-ns"},
+				{Value: float64(h.TotalCount()), Unit: "reads"},
+			},
+		})
+	}
+
 	bw := benchfmt.NewWriter(w)
 	for _, grp := range groups {
 		err := bw.Write(&benchfmt.Result{
@@ -266,6 +288,18 @@ type Runner struct {
 	Pacer         Pacer
 	Opts          *pebble.Options
 	MaxWriteBytes uint64
+	// ReadConcurrency is the number of goroutines that replay the read
+	// operations captured within the workload, concurrently with the
+	// workload's writes. Each captured read operation is replayed once, after
+	// the flushes and ingestions that preceded it during collection have been
+	// applied. Read operations captured after the last applied step, for
+	// example because of MaxWriteBytes, are not replayed. If zero, captured
+	// read operations are not replayed.
+	//
+	// Pebble can't observe an application's reads, so a workload only holds
+	// the read operations that the application reported to the
+	// WorkloadCollector through RecordRead.
+	ReadConcurrency int
 
 	// Internal state.
 
@@ -292,12 +326,26 @@ type Runner struct {
 		workloadDuration time.Duration
 		writeBytes       atomic.Uint64
 		writeThroughput  SampledMetric
+		readLatency      struct {
+			sync.Mutex
+			hists [numReadOpKinds]*hdrhistogram.Histogram
+		}
 	}
 	writeStallMetrics struct {
 		sync.Mutex
 		countByReason    map[string]int
 		durationByReason map[string]time.Duration
 	}
+	// appliedMu holds the number of flush and ingest workload steps applied,
+	// waking goroutines replaying reads that wait for a step. See
+	// waitForAppliedStep.
+	appliedMu struct {
+		sync.Mutex
+		ch    chan struct{}
+		steps uint64
+		// exhausted is set once no more steps will be applied.
+		exhausted bool
+	}
 	// compactionMu holds state for tracking the number of compactions
 	// started and completed and waking waiting goroutines when a new compaction
 	// completes. See nextCompactionCompletes.
@@ -334,6 +382,12 @@ func (r *Runner) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	var readOps []capturedReadOp
+	if r.ReadConcurrency > 0 {
+		if readOps, err = loadReadOps(r.WorkloadFS, r.WorkloadPath); err != nil {
+			return err
+		}
+	}
 
 	// Set up a staging dir for files that will be ingested.
 	r.stagingDir = r.Opts.FS.PathJoin(r.RunDir, "staging")
@@ -374,9 +428,72 @@ func (r *Runner) Run(ctx context.Context) error {
 	r.errgroup.Go(func() error { return r.prepareWorkloadSteps(ctx) })
 	r.errgroup.Go(func() error { return r.applyWorkloadSteps(ctx) })
 	r.errgroup.Go(func() error { return r.refreshMetrics(ctx) })
+	if r.ReadConcurrency > 0 {
+		for i := range r.metrics.readLatency.hists {
+			r.metrics.readLatency.hists[i] = newReadLatencyHistogram()
+		}
+		replayer := &readOpsReplayer{d: r.d, ops: readOps, waitForStep: r.waitForAppliedStep}
+		for i := 0; i < r.ReadConcurrency; i++ {
+			r.errgroup.Go(func() error { return r.replayReads(ctx, replayer) })
+		}
+	}
 	return nil
 }
 
+// replayReads runs in its own goroutine, replaying captured read operations
+// and accumulating their latencies.
+func (r *Runner) replayReads(ctx context.Context, replayer *readOpsReplayer) error {
+	hists, err := replayer.run(ctx)
+	r.metrics.readLatency.Lock()
+	defer r.metrics.readLatency.Unlock()
+	for kind, h := range hists {
+		r.metrics.readLatency.hists[kind].Merge(h)
+	}
+	return err
+}
+
+// waitForAppliedStep blocks until at least the provided number of flush and
+// ingest workload steps have been applied. It returns false if the workload
+// is exhausted first.
+func (r *Runner) waitForAppliedStep(ctx context.Context, step uint64) (bool, error) {
+	r.appliedMu.Lock()
+	for r.appliedMu.steps < step {
+		if r.appliedMu.exhausted {
+			r.appliedMu.Unlock()
+			return false, nil
+		}
+		if r.appliedMu.ch == nil {
+			r.appliedMu.ch = make(chan struct{})
+		}
+		ch := r.appliedMu.ch
+		r.appliedMu.Unlock()
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-ch:
+		}
+		r.appliedMu.Lock()
+	}
+	r.appliedMu.Unlock()
+	return true, nil
+}
+
+// stepApplied records the application of a flush or ingest workload step, or
+// the exhaustion of the workload, waking any goroutines waiting for a step.
+func (r *Runner) stepApplied(exhausted bool) {
+	r.appliedMu.Lock()
+	defer r.appliedMu.Unlock()
+	if exhausted {
+		r.appliedMu.exhausted = true
+	} else {
+		r.appliedMu.steps++
+	}
+	if r.appliedMu.ch != nil {
+		close(r.appliedMu.ch)
+		r.appliedMu.ch = nil
+	}
+}
+
 // refreshMetrics runs in its own goroutine, collecting metrics from the Pebble
 // instance whenever a) a workload step completes, or b) a compaction completes.
 // The Pacer implementations that pace based on read-amplification rely on these
@@ -551,6 +668,16 @@ func (r *Runner) Wait() (Metrics, error) {
 		m.WriteStallsDuration[reason] = duration
 	}
 	r.writeStallMetrics.Unlock()
+	r.metrics.readLatency.Lock()
+	for kind, h := range r.metrics.readLatency.hists {
+		if h != nil && h.TotalCount() > 0 {
+			if m.ReadLatency == nil {
+				m.ReadLatency = make(map[ReadOpKind]*hdrhistogram.Histogram)
+			}
+			m.ReadLatency[ReadOpKind(kind)] = h
+		}
+	}
+	r.metrics.readLatency.Unlock()
 	m.CompactionCounts.Total = pm.Compact.Count
 	m.CompactionCounts.Default = pm.Compact.DefaultCount
 	m.CompactionCounts.DeleteOnly = pm.Compact.DeleteOnlyCount
@@ -648,6 +775,18 @@ func (r *Runner) eventListener() pebble.EventListener {
 				r.compactionMu.ch = nil
 			}
 		},
+		FlushEnd: func(_ pebble.FlushInfo) {
+			// Wake anyone waiting for a compaction to complete without
+			// counting a compaction. A flush that isn't followed by a
+			// compaction may be the last event before the database quiesces,
+			// and refreshMetrics must observe its completion.
+			r.compactionMu.Lock()
+			defer r.compactionMu.Unlock()
+			if r.compactionMu.ch != nil {
+				close(r.compactionMu.ch)
+				r.compactionMu.ch = nil
+			}
+		},
 	}
 	l.EnsureDefaults(nil)
 	return l
@@ -665,6 +804,7 @@ func (r *Runner) applyWorkloadSteps(ctx context.Context) error {
 		case step, ok = <-r.steps:
 			if !ok {
 				// Exhausted the workload. Exit.
+				r.stepApplied(true /* exhausted */)
 				close(r.stepsApplied)
 				return nil
 			}
@@ -683,12 +823,14 @@ func (r *Runner) applyWorkloadSteps(ctx context.Context) error {
 				return err
 			}
 			r.metrics.writeBytes.Store(step.cumulativeWriteBytes)
+			r.stepApplied(false /* exhausted */)
 			r.stepsApplied <- step
 		case ingestStepKind:
 			if err := r.d.Ingest(context.Background(), step.tablesToIngest); err != nil {
 				return err
 			}
 			r.metrics.writeBytes.Store(step.cumulativeWriteBytes)
+			r.stepApplied(false /* exhausted */)
 			r.stepsApplied <- step
 		case compactionStepKind:
 			// No-op.
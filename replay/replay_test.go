@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -586,3 +587,120 @@ func buildHeavyWorkload(t *testing.T) vfs.FS {
 	defer d.Close()
 	return destFS
 }
+
+// TestReplayReads captures a workload's writes along with a sample of its
+// reads, and replays both.
+func TestReplayReads(t *testing.T) {
+	o := &pebble.Options{
+		Comparer:           testkeys.Comparer,
+		FS:                 vfs.NewMem(),
+		FormatMajorVersion: pebble.FormatNewest,
+	}
+	wc := NewWorkloadCollector("")
+	wc.Attach(o)
+	d, err := pebble.Open("", o)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	// Reads recorded before the collector is started are not captured.
+	wc.SetReadSampleRate(1)
+	wc.RecordRead(ReadOp{Kind: ReadOpGet, Key: []byte("a")})
+
+	workloadFS := vfs.NewMem()
+	require.NoError(t, workloadFS.MkdirAll("workload", os.ModePerm))
+	wc.Start(workloadFS, "workload")
+
+	ks := testkeys.Alpha(2)
+	key := make([]byte, ks.MaxLen())
+	// Each read records the number of flushes that preceded it.
+	var expected []capturedReadOp
+	record := func(step uint64, op ReadOp) {
+		wc.RecordRead(op)
+		op.Key = slices.Clone(op.Key)
+		expected = append(expected, capturedReadOp{step: step, op: op})
+	}
+	for i := int64(0); i < 10; i++ {
+		b := d.NewBatch()
+		for j := int64(0); j < 50; j++ {
+			n := testkeys.WriteKey(key, ks, i*50+j)
+			require.NoError(t, b.Set(key[:n], []byte("value"), pebble.NoSync))
+			record(uint64(i), ReadOp{Kind: ReadOpGet, Key: key[:n]})
+		}
+		require.NoError(t, b.Commit(pebble.NoSync))
+		require.NoError(t, d.Flush())
+		record(uint64(i+1), ReadOp{Kind: ReadOpSeekGE, Key: key[:1], UpperBound: []byte("m"), Steps: 10})
+		record(uint64(i+1), ReadOp{Kind: ReadOpSeekLT, Key: key[:1], LowerBound: []byte("b"), Steps: 5})
+		record(uint64(i+1), ReadOp{Kind: ReadOpLast, Steps: 3})
+	}
+	wc.WaitAndStop()
+
+	ops, err := loadReadOps(workloadFS, "workload")
+	require.NoError(t, err)
+	require.Equal(t, expected, ops)
+
+	// A truncated final operation is ignored.
+	f, err := workloadFS.OpenReadWrite(workloadFS.PathJoin("workload", readOpsFilename), vfs.WriteCategoryUnspecified)
+	require.NoError(t, err)
+	fi, err := f.Stat()
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{byte(ReadOpGet), 10, 5, 'a'}, fi.Size())
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	ops, err = loadReadOps(workloadFS, "workload")
+	require.NoError(t, err)
+	require.Equal(t, expected, ops)
+
+	replay := func(maxWriteBytes uint64) Metrics {
+		fs := vfs.NewMem()
+		require.NoError(t, fs.MkdirAll("run", os.ModePerm))
+		r := Runner{
+			RunDir:        "run",
+			WorkloadFS:    workloadFS,
+			WorkloadPath:  "workload",
+			Pacer:         Unpaced{},
+			MaxWriteBytes: maxWriteBytes,
+			Opts: &pebble.Options{
+				Comparer:           testkeys.Comparer,
+				FS:                 fs,
+				FormatMajorVersion: pebble.FormatNewest,
+			},
+			ReadConcurrency: 4,
+		}
+		require.NoError(t, r.Run(context.Background()))
+		defer r.Close()
+		m, err := r.Wait()
+		require.NoError(t, err)
+		return m
+	}
+	readCounts := func(m Metrics) map[ReadOpKind]int64 {
+		counts := make(map[ReadOpKind]int64)
+		for kind, h := range m.ReadLatency {
+			counts[kind] = h.TotalCount()
+		}
+		return counts
+	}
+
+	// Replaying only the first flush replays only the reads that preceded
+	// the second flush.
+	require.Equal(t, map[ReadOpKind]int64{
+		ReadOpGet:    100,
+		ReadOpSeekGE: 1,
+		ReadOpSeekLT: 1,
+		ReadOpLast:   1,
+	}, readCounts(replay(1)))
+
+	m := replay(0)
+	require.Equal(t, map[ReadOpKind]int64{
+		ReadOpGet:    500,
+		ReadOpSeekGE: 10,
+		ReadOpSeekLT: 10,
+		ReadOpLast:   10,
+	}, readCounts(m))
+
+	var buf bytes.Buffer
+	require.NoError(t, m.WriteBenchmarkString("reads", &buf))
+	for _, kind := range []ReadOpKind{ReadOpGet, ReadOpSeekGE, ReadOpSeekLT, ReadOpLast} {
+		require.Contains(t, buf.String(), fmt.Sprintf("BenchmarkReplay/reads/ReadLatency/%s 1 ", kind))
+	}
+	require.NotContains(t, buf.String(), "ReadLatency/first")
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/cockroachdb/pebble/vfs/errorfs"
+)
+
+// Variant describes a configuration of Options against which a Sweep replays
+// its workload.
+type Variant struct {
+	// Name identifies the variant within the Sweep's report. It's also used
+	// as the name of the variant's run directory, so it must be unique within
+	// the Sweep.
+	Name string
+	// Configure modifies the Options with which the variant replays the
+	// workload, for example adjusting Experimental.LevelMultiplier,
+	// MaxConcurrentCompactions or L0CompactionThreshold. If nil, the variant
+	// uses the Options returned by Sweep.NewOptions unmodified.
+	Configure func(*pebble.Options) error
+}
+
+// Sweep replays the same captured workload against each of a set of Options
+// variants, each within its own isolated filesystem or directory, so that the
+// variants' performance may be compared.
+type Sweep struct {
+	WorkloadFS   vfs.FS
+	WorkloadPath string
+	// CheckpointDir, if non-empty, is the path on WorkloadFS of a checkpoint
+	// of the database taken at the beginning of the workload's collection.
+	// Each variant's database is initialized from a copy of the checkpoint.
+	CheckpointDir string
+	// RunFS and RunDir configure where each variant's database is
+	// constructed. If RunFS is nil, each variant runs within its own
+	// vfs.MemFS. Otherwise each variant runs within a subdirectory of RunDir
+	// named after the variant, which is removed once the variant completes.
+	RunFS  vfs.FS
+	RunDir string
+	// Pacer, MaxWriteBytes and ReadConcurrency configure the Runner that
+	// replays each variant. See the corresponding Runner fields.
+	Pacer           Pacer
+	MaxWriteBytes   uint64
+	ReadConcurrency int
+	// NewOptions returns the Options that each variant's Configure func
+	// modifies. It's called once per variant and must return a distinct
+	// Options each time. If nil, variants begin with the zero Options.
+	NewOptions func() *pebble.Options
+	// NewInjector, if non-nil, returns an injector that wraps each variant's
+	// filesystem, for example to inject latency through
+	// errorfs.RandomLatency. It's called once per variant so that stateful
+	// injectors are not shared between variants.
+	NewInjector func() errorfs.Injector
+	Variants    []Variant
+}
+
+// SweepResult holds the metrics collected while replaying a workload against
+// a single variant of a Sweep.
+type SweepResult struct {
+	Variant string
+	Metrics Metrics
+}
+
+// Run replays the workload against each of the sweep's variants in turn,
+// returning the metrics collected for each. Variants are replayed one at a
+// time so that they don't compete for resources.
+func (s *Sweep) Run(ctx context.Context) (SweepResults, error) {
+	names := make(map[string]bool, len(s.Variants))
+	for _, v := range s.Variants {
+		if v.Name == "" {
+			return nil, errors.New("replay: sweep variant has no name")
+		} else if names[v.Name] {
+			return nil, errors.Newf("replay: duplicate sweep variant %q", v.Name)
+		}
+		names[v.Name] = true
+	}
+
+	results := make(SweepResults, 0, len(s.Variants))
+	for _, v := range s.Variants {
+		m, err := s.runVariant(ctx, v)
+		if err != nil {
+			return results, errors.Wrapf(err, "replaying variant %q", v.Name)
+		}
+		results = append(results, SweepResult{Variant: v.Name, Metrics: m})
+	}
+	return results, nil
+}
+
+// runVariant replays the workload against a single variant.
+func (s *Sweep) runVariant(ctx context.Context, v Variant) (Metrics, error) {
+	opts := &pebble.Options{}
+	if s.NewOptions != nil {
+		opts = s.NewOptions()
+	}
+	if v.Configure != nil {
+		if err := v.Configure(opts); err != nil {
+			return Metrics{}, err
+		}
+	}
+
+	var fs vfs.FS
+	var runDir string
+	if s.RunFS == nil {
+		fs = vfs.NewMem()
+		runDir = v.Name
+	} else {
+		fs = s.RunFS
+		runDir = fs.PathJoin(s.RunDir, v.Name)
+		defer func() { _ = fs.RemoveAll(runDir) }()
+	}
+	if err := fs.MkdirAll(runDir, os.ModePerm); err != nil {
+		return Metrics{}, err
+	}
+	if s.CheckpointDir != "" {
+		ok, err := vfs.Clone(s.WorkloadFS, fs, s.CheckpointDir, runDir, vfs.CloneTryLink)
+		if err != nil {
+			return Metrics{}, err
+		} else if !ok {
+			return Metrics{}, errors.Newf("no checkpoint %q exists", s.CheckpointDir)
+		}
+	}
+	if s.NewInjector != nil {
+		fs = errorfs.Wrap(fs, s.NewInjector())
+	}
+	opts.FS = fs
+
+	r := &Runner{
+		RunDir:          runDir,
+		WorkloadFS:      s.WorkloadFS,
+		WorkloadPath:    s.WorkloadPath,
+		Pacer:           s.Pacer,
+		Opts:            opts,
+		MaxWriteBytes:   s.MaxWriteBytes,
+		ReadConcurrency: s.ReadConcurrency,
+	}
+	if err := r.Run(ctx); err != nil {
+		return Metrics{}, err
+	}
+	m, err := r.Wait()
+	return m, errors.CombineErrors(err, r.Close())
+}
+
+// SweepResults holds the results of a Sweep, in the order of its variants.
+type SweepResults []SweepResult
+
+// WriteReport writes a table comparing the write amplification, read
+// amplification and write stalls of each of the sweep's variants. Write
+// amplification and stall time are also expressed relative to the first
+// variant, which is treated as the baseline.
+func (rs SweepResults) WriteReport(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "variant\tw-amp\tr-amp(mean)\tr-amp(max)\tstalls\tstall-time\tworkload\tquiesce\n")
+	var baseWriteAmp float64
+	var baseStallTime time.Duration
+	for i, r := range rs {
+		m := &r.Metrics
+		var stalls int
+		var stallTime time.Duration
+		for reason, n := range m.WriteStalls {
+			stalls += n
+			stallTime += m.WriteStallsDuration[reason]
+		}
+		if i == 0 {
+			baseWriteAmp, baseStallTime = m.TotalWriteAmp, stallTime
+		}
+		fmt.Fprintf(tw, "%s\t%.2f%s\t%.2f\t%d\t%d\t%s%s\t%s\t%s\n",
+			r.Variant,
+			m.TotalWriteAmp, relativeChange(i, m.TotalWriteAmp, baseWriteAmp),
+			m.ReadAmp.Mean(), m.ReadAmp.Max(),
+			stalls, stallTime.Round(time.Millisecond),
+			relativeChange(i, stallTime.Seconds(), baseStallTime.Seconds()),
+			m.WorkloadDuration.Round(time.Millisecond),
+			m.QuiesceDuration.Round(time.Millisecond))
+	}
+	return tw.Flush()
+}
+
+// relativeChange formats the change of v relative to the baseline value base,
+// for the i-th result of a sweep. The baseline itself and values relative to
+// a zero baseline are not annotated.
+func relativeChange(i int, v, base float64) string {
+	if i == 0 || base == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%+.1f%%)", 100*(v-base)/base)
+}
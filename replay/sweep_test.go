@@ -0,0 +1,136 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/internal/testkeys"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/cockroachdb/pebble/vfs/errorfs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweep(t *testing.T) {
+	// Capture a small workload.
+	o := &pebble.Options{
+		Comparer:           testkeys.Comparer,
+		FS:                 vfs.NewMem(),
+		FormatMajorVersion: pebble.FormatNewest,
+	}
+	wc := NewWorkloadCollector("")
+	wc.Attach(o)
+	d, err := pebble.Open("", o)
+	require.NoError(t, err)
+	workloadFS := vfs.NewMem()
+	require.NoError(t, workloadFS.MkdirAll("workload", os.ModePerm))
+	wc.Start(workloadFS, "workload")
+	ks := testkeys.Alpha(2)
+	key := make([]byte, ks.MaxLen())
+	for i := int64(0); i < 20; i++ {
+		b := d.NewBatch()
+		for j := int64(0); j < 30; j++ {
+			n := testkeys.WriteKey(key, ks, (i*30+j)%ks.Count())
+			require.NoError(t, b.Set(key[:n], []byte("value"), pebble.NoSync))
+		}
+		require.NoError(t, b.Commit(pebble.NoSync))
+		require.NoError(t, d.Flush())
+	}
+	wc.WaitAndStop()
+	require.NoError(t, d.Close())
+
+	var injected atomic.Int64
+	newOptions := func() *pebble.Options {
+		return &pebble.Options{
+			Comparer:           testkeys.Comparer,
+			FormatMajorVersion: pebble.FormatNewest,
+		}
+	}
+	variants := []Variant{
+		{Name: "baseline"},
+		{Name: "multiplier=2", Configure: func(o *pebble.Options) error {
+			o.Experimental.LevelMultiplier = 2
+			o.LBaseMaxBytes = 1
+			return nil
+		}},
+		{Name: "l0-threshold=8", Configure: func(o *pebble.Options) error {
+			o.L0CompactionThreshold = 8
+			return nil
+		}},
+	}
+
+	t.Run("mem", func(t *testing.T) {
+		s := Sweep{
+			WorkloadFS:   workloadFS,
+			WorkloadPath: "workload",
+			Pacer:        Unpaced{},
+			NewOptions:   newOptions,
+			NewInjector: func() errorfs.Injector {
+				latency := errorfs.RandomLatency(nil, time.Microsecond, 1, time.Millisecond)
+				return errorfs.InjectorFunc(func(op errorfs.Op) error {
+					injected.Add(1)
+					return latency.MaybeError(op)
+				})
+			},
+			Variants: variants,
+		}
+		results, err := s.Run(context.Background())
+		require.NoError(t, err)
+		require.Len(t, results, len(variants))
+		for i, r := range results {
+			require.Equal(t, variants[i].Name, r.Variant)
+			require.NotNil(t, r.Metrics.Final)
+		}
+		require.Greater(t, injected.Load(), int64(0))
+
+		var buf bytes.Buffer
+		require.NoError(t, results.WriteReport(&buf))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, len(variants)+1)
+		require.True(t, strings.HasPrefix(lines[0], "variant"))
+		for i, v := range variants {
+			require.True(t, strings.HasPrefix(lines[i+1], v.Name+" "), lines[i+1])
+		}
+	})
+
+	t.Run("dir", func(t *testing.T) {
+		runFS := vfs.NewMem()
+		require.NoError(t, runFS.MkdirAll("runs", os.ModePerm))
+		s := Sweep{
+			WorkloadFS:   workloadFS,
+			WorkloadPath: "workload",
+			RunFS:        runFS,
+			RunDir:       "runs",
+			Pacer:        Unpaced{},
+			NewOptions:   newOptions,
+			Variants:     variants[:2],
+		}
+		results, err := s.Run(context.Background())
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		// Each variant's run directory is removed once it completes.
+		ls, err := runFS.List("runs")
+		require.NoError(t, err)
+		require.Empty(t, ls)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		s := Sweep{
+			WorkloadFS:   workloadFS,
+			WorkloadPath: "workload",
+			Pacer:        Unpaced{},
+			Variants:     []Variant{{Name: "a"}, {Name: "a"}},
+		}
+		_, err := s.Run(context.Background())
+		require.ErrorContains(t, err, `duplicate sweep variant "a"`)
+	})
+}
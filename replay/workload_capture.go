@@ -7,6 +7,7 @@ package replay
 import (
 	"fmt"
 	"io"
+	"math/rand"
 	"sync"
 	"sync/atomic"
 
@@ -32,9 +33,17 @@ type manifestDetails struct {
 	destFile vfs.File
 }
 
+// readOpsFlushThreshold is the size of the encoded read operations that are
+// buffered before the copying goroutine is woken to write them out.
+const readOpsFlushThreshold = 64 << 10
+
 // WorkloadCollector is designed to capture workloads by handling manifest
 // files, flushed SSTs and ingested SSTs. The collector hooks into the
 // pebble.EventListener and pebble.Cleaner in order keep track of file states.
+//
+// The collector may also capture a sample of the workload's read operations,
+// which are reported by the application through RecordRead since they aren't
+// observable through the pebble.Options hooks.
 type WorkloadCollector struct {
 	mu struct {
 		sync.Mutex
@@ -51,6 +60,14 @@ type WorkloadCollector struct {
 		// appended, so it does not need to hold mu while accessing the structs'
 		// fields.
 		manifests []*manifestDetails
+		// pendingReads holds encoded read operations that haven't yet been
+		// written to the workload's read operations file.
+		pendingReads []byte
+		// workloadSteps counts the flushes and ingestions observed since the
+		// collector was started. Each captured read operation records the
+		// count at the time it was recorded, so that it's replayed after the
+		// corresponding workload step.
+		workloadSteps uint64
 
 		// The following condition variable and counts are used in tests to
 		// synchronize with the copying goroutine.
@@ -77,11 +94,20 @@ type WorkloadCollector struct {
 		// cleaner stores the cleaner to use when files become obsolete and need to
 		// be cleaned.
 		cleaner base.Cleaner
+		// readSampleRate is the fraction of the read operations passed to
+		// RecordRead that are captured.
+		readSampleRate float64
 	}
 	copier struct {
 		sync.Cond
 		stop bool
 		done chan struct{}
+		// readsFile is the file to which read operations are appended, and
+		// readsOffset is the offset at which the next write occurs. Only the
+		// copyFiles goroutine accesses these fields.
+		readsFile   vfs.File
+		readsOffset int64
+		readsBuf    []byte
 	}
 }
 
@@ -165,6 +191,9 @@ func (w *WorkloadCollector) onTableIngest(info pebble.TableIngestInfo) {
 	}
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	if info.Err == nil && len(info.Tables) > 0 {
+		w.mu.workloadSteps++
+	}
 	for _, table := range info.Tables {
 		w.enqueueCopyLocked(base.PhysicalTableDiskFileNum(table.FileNum))
 	}
@@ -179,6 +208,11 @@ func (w *WorkloadCollector) onFlushEnd(info pebble.FlushInfo) {
 	}
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	// A flush of ingested tables completes an ingestion that was already
+	// counted when the tables were ingested.
+	if info.Err == nil && len(info.Output) > 0 && !info.Ingest {
+		w.mu.workloadSteps++
+	}
 	for _, table := range info.Output {
 		w.enqueueCopyLocked(base.PhysicalTableDiskFileNum(table.FileNum))
 	}
@@ -215,7 +249,7 @@ func (w *WorkloadCollector) copyFiles() {
 		// The following performs the workload capture. It waits on a condition
 		// variable (fileListener) to let it know when new files are available to be
 		// collected.
-		if len(w.mu.pendingSSTables) == 0 {
+		if len(w.mu.pendingSSTables) == 0 && len(w.mu.pendingReads) < readOpsFlushThreshold {
 			w.copier.Wait()
 		}
 		// Grab the manifests to copy.
@@ -223,6 +257,8 @@ func (w *WorkloadCollector) copyFiles() {
 		pendingManifests := w.mu.manifests[index:]
 		var pending []string
 		pending, w.mu.pendingSSTables = w.mu.pendingSSTables, nil
+		var pendingReads []byte
+		pendingReads, w.mu.pendingReads = w.mu.pendingReads, w.copier.readsBuf[:0]
 		func() {
 			// Note the unusual lock order; Temporarily unlock the
 			// mutex, but re-acquire it before returning.
@@ -234,7 +270,10 @@ func (w *WorkloadCollector) copyFiles() {
 			// Copy the SSTables provided in pending. copySSTables takes
 			// ownership of the pending slice.
 			w.copySSTables(pending)
+			// Append the captured read operations.
+			w.writeReads(pendingReads)
 		}()
+		w.copier.readsBuf = pendingReads
 
 		// This helps in tests; Tests can wait on the copyCond condition
 		// variable until the necessary bits have been copied.
@@ -242,6 +281,16 @@ func (w *WorkloadCollector) copyFiles() {
 		w.mu.copyCond.Broadcast()
 	}
 
+	// Write out any read operations recorded since the last iteration.
+	w.writeReads(w.mu.pendingReads)
+	w.mu.pendingReads = nil
+	if w.copier.readsFile != nil {
+		if err := w.copier.readsFile.Close(); err != nil {
+			panic(err)
+		}
+		w.copier.readsFile = nil
+	}
+
 	for idx := range w.mu.manifests {
 		if f := w.mu.manifests[idx].sourceFile; f != nil {
 			if err := f.Close(); err != nil {
@@ -345,6 +394,61 @@ func (w *WorkloadCollector) copySSTables(pending []string) {
 	}
 }
 
+// writeReads appends the provided encoded read operations to the workload's
+// read operations file, creating it if necessary.
+func (w *WorkloadCollector) writeReads(reads []byte) {
+	if len(reads) == 0 {
+		return
+	}
+	if w.copier.readsFile == nil {
+		// Open the file for writing at its end, so that the reads captured
+		// by an earlier collection into the same directory are retained.
+		var err error
+		w.copier.readsFile, err = w.config.destFS.OpenReadWrite(w.destFilepath(readOpsFilename), vfs.WriteCategoryUnspecified)
+		if err != nil {
+			panic(err)
+		}
+		fi, err := w.copier.readsFile.Stat()
+		if err != nil {
+			panic(err)
+		}
+		w.copier.readsOffset = fi.Size()
+	}
+	n, err := w.copier.readsFile.WriteAt(reads, w.copier.readsOffset)
+	if err != nil {
+		panic(err)
+	}
+	w.copier.readsOffset += int64(n)
+}
+
+// SetReadSampleRate configures the fraction of the read operations passed to
+// RecordRead that are captured. It defaults to zero, capturing no reads. It
+// must not be called while the collector is running.
+func (w *WorkloadCollector) SetReadSampleRate(rate float64) {
+	w.config.readSampleRate = rate
+}
+
+// RecordRead records a read operation performed by the application, capturing
+// it with the probability configured through SetReadSampleRate. Captured read
+// operations are replayed concurrently with the workload's writes by a Runner
+// with a nonzero ReadConcurrency, each once the flushes and ingestions that
+// preceded it have been replayed. RecordRead may be called concurrently and
+// does not retain the operation's byte slices.
+func (w *WorkloadCollector) RecordRead(op ReadOp) {
+	if !w.IsRunning() {
+		return
+	}
+	if rate := w.config.readSampleRate; rate <= 0 || (rate < 1 && rand.Float64() >= rate) {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.mu.pendingReads = op.encode(w.mu.pendingReads, w.mu.workloadSteps)
+	if len(w.mu.pendingReads) >= readOpsFlushThreshold {
+		w.copier.Broadcast()
+	}
+}
+
 // Start begins collecting a workload. All flushed and ingested sstables, plus
 // corresponding manifests are copied to the provided destination path on the
 // provided FS.
@@ -359,6 +463,7 @@ func (w *WorkloadCollector) Start(destFS vfs.FS, destPath string) {
 	}
 	w.config.destFS = destFS
 	w.config.destDir = destPath
+	w.mu.workloadSteps = 0
 
 	// Initialize the tracked manifests to the database's current manifest, if
 	// the database has already started. Every database Open creates a new
@@ -0,0 +1,229 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"context"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/manifest"
+	"github.com/cockroachdb/pebble/sstable"
+)
+
+// RewriteOptions configures a call to RewriteTables. Fields left at their
+// zero value retain the setting that the DB uses when writing a table to the
+// table's level (see Options.MakeWriterOptions).
+type RewriteOptions struct {
+	// Compression is the compression algorithm used for the rewritten tables.
+	Compression Compression
+	// BlockSize is the target uncompressed size in bytes of each data block
+	// of the rewritten tables.
+	BlockSize int
+	// FilterPolicy is the filter policy used for the rewritten tables.
+	FilterPolicy FilterPolicy
+	// TableFormat is the format of the rewritten tables. It must be supported
+	// by the DB's current format major version. Note that if
+	// Options.Experimental.TableFormatRewriteBytesPerSecond is set, tables
+	// rewritten in a format older than the newest supported format will
+	// eventually be rewritten again in the background.
+	TableFormat sstable.TableFormat
+	// Concurrency is the maximum number of rewrite compactions that the call
+	// runs at a time. Rewrite compactions also count against
+	// Options.MaxConcurrentCompactions. If zero, tables are rewritten one at a
+	// time.
+	Concurrency int
+}
+
+// apply overrides the writer options with the options that are set.
+func (o *RewriteOptions) apply(writerOpts *sstable.WriterOptions) {
+	if o.Compression != DefaultCompression {
+		writerOpts.Compression = resolveDefaultCompression(o.Compression)
+	}
+	if o.BlockSize > 0 {
+		writerOpts.BlockSize = o.BlockSize
+	}
+	if o.FilterPolicy != nil {
+		writerOpts.FilterPolicy = o.FilterPolicy
+	}
+}
+
+// RewriteTables rewrites the sstables that overlap the given span, without
+// changing their contents or their levels, using the writer options adjusted
+// by opts. It is used to bring existing tables in line with a change to the
+// compression, block size, filter policy or table format of a level.
+//
+// Only the tables that overlap the span when RewriteTables is called are
+// rewritten. A table that is compacted by another compaction while the call
+// is in progress is not rewritten again, since its replacement was written
+// by that compaction. Tables that are moved to a different level are still
+// rewritten.
+//
+// The method returns once all the tables have been rewritten, the context is
+// canceled, or an error is hit. Rewrite compactions that are running when the
+// context is canceled run to completion.
+func (d *DB) RewriteTables(ctx context.Context, span KeyRange, opts RewriteOptions) error {
+	if err := d.closed.Load(); err != nil {
+		panic(err)
+	}
+	if d.opts.ReadOnly {
+		return ErrReadOnly
+	}
+	if opts.TableFormat != sstable.TableFormatUnspecified {
+		fmv := d.FormatMajorVersion()
+		if opts.TableFormat < fmv.MinTableFormat() || opts.TableFormat > fmv.MaxTableFormat() {
+			return errors.Errorf("pebble: table format %s is not supported at format major version %s",
+				opts.TableFormat, fmv)
+		}
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+
+	// Wake up the loop below if the context is canceled.
+	stop := context.AfterFunc(ctx, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.mu.compact.cond.Broadcast()
+	})
+	defer stop()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	task := newRewriteTask(d.cmp, d.mu.versions.currentVersion(), span, opts)
+	d.mu.compact.rewrites = append(d.mu.compact.rewrites, task)
+	defer func() {
+		d.mu.compact.rewrites = slices.DeleteFunc(d.mu.compact.rewrites, func(t *rewriteTask) bool {
+			return t == task
+		})
+	}()
+	for {
+		// Collect the results of the rewrite compactions that completed.
+		for i := 0; i < len(task.running); {
+			select {
+			case err := <-task.running[i]:
+				if err != nil && !errors.Is(err, ErrCancelledCompaction) {
+					return err
+				}
+				task.running = slices.Delete(task.running, i, i+1)
+			default:
+				i++
+			}
+		}
+		task.prune(d.mu.versions.currentVersion())
+		if len(task.pending) == 0 && len(task.running) == 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.closed.Load() != nil {
+			return ErrClosed
+		}
+		d.maybeScheduleCompaction()
+		d.mu.compact.cond.Wait()
+	}
+}
+
+// rewriteTask tracks the tables that remain to be rewritten by a call to
+// RewriteTables.
+type rewriteTask struct {
+	bounds base.UserKeyBounds
+	opts   RewriteOptions
+	// pending holds the tables that have not been rewritten yet, including
+	// those that are being rewritten.
+	pending map[base.FileNum]struct{}
+	// running holds a channel for each of the task's in-progress rewrite
+	// compactions, on which the compaction reports its result.
+	running []chan error
+}
+
+func newRewriteTask(cmp base.Compare, v *version, span KeyRange, opts RewriteOptions) *rewriteTask {
+	t := &rewriteTask{
+		bounds:  base.UserKeyBoundsEndExclusive(span.Start, span.End),
+		opts:    opts,
+		pending: make(map[base.FileNum]struct{}),
+	}
+	for level := range v.Levels {
+		overlaps := v.Overlaps(level, t.bounds)
+		iter := overlaps.Iter()
+		for f := iter.First(); f != nil; f = iter.Next() {
+			if f.Overlaps(cmp, &t.bounds) {
+				t.pending[f.FileNum] = struct{}{}
+			}
+		}
+	}
+	return t
+}
+
+// prune removes the pending tables that are no longer present in the given
+// version.
+func (t *rewriteTask) prune(v *version) {
+	if len(t.pending) == 0 {
+		return
+	}
+	present := make(map[base.FileNum]struct{}, len(t.pending))
+	for level := range v.Levels {
+		overlaps := v.Overlaps(level, t.bounds)
+		iter := overlaps.Iter()
+		for f := iter.First(); f != nil; f = iter.Next() {
+			if _, ok := t.pending[f.FileNum]; ok {
+				present[f.FileNum] = struct{}{}
+			}
+		}
+	}
+	t.pending = present
+}
+
+// tryScheduleRewriteCompaction tries to start a rewrite compaction for one of
+// the pending tables of a rewrite task.
+//
+// Returns true if a compaction was started.
+//
+// Requires d.mu to be held.
+func (d *DB) tryScheduleRewriteCompaction(env compactionEnv) bool {
+	vers := d.mu.versions.currentVersion()
+	for _, task := range d.mu.compact.rewrites {
+		if len(task.running) >= task.opts.Concurrency || len(task.pending) == 0 {
+			continue
+		}
+		for level := range vers.Levels {
+			overlaps := vers.Overlaps(level, task.bounds)
+			iter := overlaps.Iter()
+			for f := iter.First(); f != nil; f = iter.Next() {
+				if _, ok := task.pending[f.FileNum]; !ok || f.IsCompacting() {
+					continue
+				}
+				if d.tryLaunchRewriteCompaction(vers, env, task, level, f) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// tryLaunchRewriteCompaction attempts to launch a rewrite compaction for the
+// given table. Returns false if the table's rewrite conflicts with another
+// compaction.
+//
+// Requires d.mu to be held.
+func (d *DB) tryLaunchRewriteCompaction(
+	vers *version, env compactionEnv, task *rewriteTask, level int, f *manifest.FileMetadata,
+) bool {
+	pc := pickDownloadCompaction(vers, d.opts, env, d.mu.versions.picker.getBaseLevel(), compactionKindRewrite, level, f)
+	if pc == nil {
+		return false
+	}
+	doneCh := make(chan error, 1)
+	task.running = append(task.running, doneCh)
+	c := newCompaction(pc, d.opts, d.timeNow(), d.objProvider)
+	c.rewriteOptions = &task.opts
+	d.mu.compact.compactingCount++
+	d.addInProgressCompaction(c)
+	go d.compact(c, doneCh)
+	return true
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteTables(t *testing.T) {
+	opts := &Options{
+		FS:                          vfs.NewMem(),
+		FormatMajorVersion:          FormatNewest,
+		DisableAutomaticCompactions: true,
+		Logger:                      testLogger{t},
+	}
+	opts.Levels = make([]LevelOptions, 1)
+	opts.Levels[0].Compression = func() Compression { return SnappyCompression }
+	d, err := Open("", opts)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	// Flush three non-overlapping tables, one per key prefix.
+	const keysPerTable = 20
+	for _, prefix := range []string{"a", "b", "c"} {
+		for i := 0; i < keysPerTable; i++ {
+			k := fmt.Sprintf("%s%02d", prefix, i)
+			require.NoError(t, d.Set([]byte(k), []byte("value-"+k), nil))
+		}
+		require.NoError(t, d.Flush())
+	}
+
+	type tableInfo struct {
+		fileNum     base.FileNum
+		compression string
+		dataBlocks  uint64
+	}
+	tables := func() []tableInfo {
+		ssts, err := d.SSTables(WithProperties())
+		require.NoError(t, err)
+		require.Len(t, ssts[0], 3)
+		var infos []tableInfo
+		for _, f := range ssts[0] {
+			infos = append(infos, tableInfo{
+				fileNum:     f.FileNum,
+				compression: f.Properties.CompressionName,
+				dataBlocks:  f.Properties.NumDataBlocks,
+			})
+		}
+		return infos
+	}
+	before := tables()
+	for _, ti := range before {
+		require.Equal(t, "Snappy", ti.compression)
+	}
+
+	// A table format that the format major version doesn't support is
+	// rejected.
+	span := KeyRange{Start: []byte("b"), End: []byte("z")}
+	require.Error(t, d.RewriteTables(context.Background(), span, RewriteOptions{
+		TableFormat: sstable.TableFormatLevelDB,
+	}))
+
+	// A canceled context returns before any table is rewritten.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, d.RewriteTables(ctx, span, RewriteOptions{}), context.Canceled)
+	require.Equal(t, before, tables())
+
+	// Rewrite the tables that overlap [b, z) without compression and with
+	// one key per data block.
+	require.NoError(t, d.RewriteTables(context.Background(), span, RewriteOptions{
+		Compression: NoCompression,
+		BlockSize:   1,
+	}))
+	after := tables()
+	for _, ti := range after {
+		if ti.fileNum == before[0].fileNum {
+			require.Equal(t, before[0], ti)
+			continue
+		}
+		require.Equal(t, "NoCompression", ti.compression)
+		require.Equal(t, uint64(keysPerTable), ti.dataBlocks)
+	}
+	require.Equal(t, int64(2), d.Metrics().Compact.RewriteCount)
+
+	// The contents of the DB are unchanged.
+	iter, err := d.NewIter(nil)
+	require.NoError(t, err)
+	var n int
+	for valid := iter.First(); valid; valid = iter.Next() {
+		require.Equal(t, "value-"+string(iter.Key()), string(iter.Value()))
+		n++
+	}
+	require.NoError(t, iter.Close())
+	require.Equal(t, 3*keysPerTable, n)
+}
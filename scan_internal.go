@@ -433,6 +433,9 @@ type scanInternalIterator struct {
 	seqNum          base.SeqNum
 	iterLevels      []IteratorLevel
 	mergingIter     *mergingIter
+	// bufferPool holds the buffers into which blocks are read when
+	// opts.BypassCache is set.
+	bufferPool sstable.BufferPool
 
 	// boundsBuf holds two buffers used to store the lower and upper bounds.
 	// Whenever the InternalIterator's bounds change, the new bounds are copied
@@ -900,13 +903,18 @@ func (i *scanInternalIterator) constructPointIter(
 	rangeDelLevels = rangeDelLevels[:numLevelIters]
 	i.opts.IterOptions.snapshotForHideObsoletePoints = i.seqNum
 	i.opts.IterOptions.CategoryAndQoS = categoryAndQoS
+	var internalOpts internalIterOpts
+	if i.opts.BypassCache {
+		i.bufferPool.SetMaxRetainedBytes(bypassCacheBufferPoolBytes)
+		internalOpts.bufferPool = &i.bufferPool
+	}
 	addLevelIterForFiles := func(files manifest.LevelIterator, level manifest.Layer) {
 		li := &levels[levelsIndex]
 		rli := &rangeDelLevels[levelsIndex]
 
 		li.init(
 			i.ctx, i.opts.IterOptions, i.comparer, i.newIters, files, level,
-			internalIterOpts{})
+			internalOpts)
 		mlevels[mlevelsIndex].iter = li
 		rli.Init(i.ctx, keyspan.SpanIterOptions{RangeKeyFilters: i.opts.RangeKeyFilters},
 			i.comparer.Compare, tableNewRangeDelIter(i.newIters), files, level,
@@ -1124,6 +1132,7 @@ func (i *scanInternalIterator) close() error {
 	if err := i.iter.Close(); err != nil {
 		return err
 	}
+	i.bufferPool.Release()
 	if i.readState != nil {
 		i.readState.unref()
 	}
@@ -0,0 +1,287 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/tokenbucket"
+)
+
+// ScrubStats contains statistics about a scrub of the DB.
+type ScrubStats struct {
+	// Tables is the number of tables scrubbed.
+	Tables int
+	// Bytes is the approximate number of bytes read from the tables.
+	Bytes uint64
+	// CorruptTables is the number of tables found to be corrupt.
+	CorruptTables int
+}
+
+// Scrub reads every live sstable, verifying that the checksum of every block
+// matches the block's contents, as stored, and that the point keys of each
+// table are ordered and within the table's bounds. Silent corruption of data
+// which is rarely read is otherwise only discovered when a read hits it.
+//
+// Corruption is reported through EventListener.DataCorruption and counted in
+// the returned stats; it does not cause Scrub to return an error. If
+// Options.Experimental.Scrub.MarkCorruptFiles is set, corrupt tables are marked
+// for compaction.
+//
+// Tables are read at Options.Experimental.Scrub.BytesPerSecond, or as fast as
+// possible if it is not positive. At most one scrub runs at a time, including
+// the background scrub configured by Options.Experimental.Scrub: a concurrent
+// call waits for the running scrub to complete. If the DB is closed while
+// Scrub runs, it returns ErrClosed.
+func (d *DB) Scrub(ctx context.Context) (ScrubStats, error) {
+	if err := d.closed.Load(); err != nil {
+		panic(err)
+	}
+	return d.scrub(ctx)
+}
+
+// scrubLoop runs in a background goroutine, scrubbing the DB every
+// Options.Experimental.Scrub.Interval until the DB is closed.
+func (d *DB) scrubLoop() {
+	for {
+		if _, err := d.scrub(context.Background()); err != nil {
+			if errors.Is(err, ErrClosed) {
+				return
+			}
+			d.opts.EventListener.BackgroundError(err)
+		}
+		t := time.NewTimer(d.opts.Experimental.Scrub.Interval)
+		select {
+		case <-d.closedCh:
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// scrubFile is a table to be scrubbed, and the level it was found at.
+type scrubFile struct {
+	level int
+	meta  *fileMetadata
+}
+
+// scrubResult is the result of verifying the blocks of a table's backing
+// object.
+type scrubResult struct {
+	blocks []sstable.CorruptBlock
+	err    error
+}
+
+func (d *DB) scrub(ctx context.Context) (ScrubStats, error) {
+	d.mu.Lock()
+	for d.mu.scrub.scrubbing {
+		d.mu.scrub.cond.Wait()
+	}
+	if d.closed.Load() != nil {
+		d.mu.Unlock()
+		return ScrubStats{}, ErrClosed
+	}
+	d.mu.scrub.scrubbing = true
+	jobID := d.newJobIDLocked()
+	var files []scrubFile
+	current := d.mu.versions.currentVersion()
+	for level := range current.Levels {
+		iter := current.Levels[level].Iter()
+		for f := iter.First(); f != nil; f = iter.Next() {
+			files = append(files, scrubFile{level: level, meta: f})
+		}
+	}
+	d.mu.Unlock()
+
+	// Close waits for the scrub to complete: stop reading as soon as the DB is
+	// closed.
+	defer func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.mu.scrub.scrubbing = false
+		d.mu.scrub.cond.Broadcast()
+	}()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-d.closedCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var stats ScrubStats
+	var tb tokenbucket.TokenBucket
+	rate := d.opts.Experimental.Scrub.BytesPerSecond
+	if rate > 0 {
+		// Allow a burst of up to one second's worth of reads.
+		tb.Init(tokenbucket.TokensPerSecond(rate), tokenbucket.Tokens(rate))
+	}
+	pace := func(n uint64) error {
+		stats.Bytes += n
+		if rate <= 0 {
+			return nil
+		}
+		return tb.WaitCtx(ctx, tokenbucket.Tokens(n))
+	}
+
+	// Virtual tables share their backing object, whose blocks are only
+	// verified once.
+	checked := make(map[base.DiskFileNum]scrubResult)
+	canceled := func() error {
+		err := ctx.Err()
+		if err != nil && d.closed.Load() != nil {
+			return ErrClosed
+		}
+		return err
+	}
+	for _, f := range files {
+		if err := canceled(); err != nil {
+			return stats, err
+		}
+		live, info, err := d.scrubTable(ctx, jobID, f, pace, checked)
+		if err != nil {
+			if ctx.Err() != nil {
+				// The error is due to the scrub being canceled, which is
+				// reported when the loop checks the context.
+				continue
+			}
+			// The table could not be read for a reason other than corruption,
+			// which may be transient. Move on to the next table.
+			d.opts.EventListener.BackgroundError(err)
+			continue
+		}
+		if !live {
+			continue
+		}
+		stats.Tables++
+		if info == nil {
+			continue
+		}
+		stats.CorruptTables++
+		d.opts.EventListener.DataCorruption(*info)
+		if d.opts.Experimental.Scrub.MarkCorruptFiles && !d.opts.ReadOnly {
+			d.markCorruptFile(info.Meta)
+		}
+	}
+	return stats, canceled()
+}
+
+// scrubTable scrubs a single table, if it is still live, and returns a
+// description of the corruption found in the table, if any.
+func (d *DB) scrubTable(
+	ctx context.Context,
+	jobID JobID,
+	f scrubFile,
+	pace func(n uint64) error,
+	checked map[base.DiskFileNum]scrubResult,
+) (live bool, _ *DataCorruptionInfo, _ error) {
+	// Reference the current version so that the table is not deleted while it
+	// is read. The table may have been moved to a lower level, or compacted,
+	// since the list of files was collected.
+	rs := d.loadReadState()
+	defer rs.unref()
+	level := -1
+	for l := f.level; l < numLevels; l++ {
+		if rs.current.Contains(l, f.meta) {
+			level = l
+			break
+		}
+	}
+	if level < 0 {
+		return false, nil, nil
+	}
+
+	m := f.meta
+	backing := m.FileBacking.DiskFileNum
+	res, ok := checked[backing]
+	if !ok {
+		if m.Virtual {
+			res.err = d.tableCache.withVirtualReader(m.VirtualMeta(), func(r sstable.VirtualReader) error {
+				var err error
+				res.blocks, err = r.FindCorruptBlocksOnBacking(ctx, pace)
+				return err
+			})
+		} else {
+			res.err = d.tableCache.withReader(m.PhysicalMeta(), func(r *sstable.Reader) error {
+				var err error
+				res.blocks, err = r.FindCorruptBlocks(ctx, pace)
+				return err
+			})
+		}
+		checked[backing] = res
+	}
+	if res.err != nil && !IsCorruptionError(res.err) {
+		return true, nil, res.err
+	}
+
+	details := res.err
+	if len(res.blocks) > 0 {
+		details = res.blocks[0].Err
+	}
+	if details == nil {
+		// The blocks are intact; check the keys they contain.
+		if err := pace(m.Size); err != nil {
+			return true, nil, err
+		}
+		err := checkTableKeyOrder(ctx, d.cmp, d.opts.Comparer.FormatKey, d.newIters, m)
+		if err != nil && !IsCorruptionError(err) {
+			return true, nil, err
+		}
+		details = err
+	}
+	if details == nil {
+		return true, nil, nil
+	}
+	info := d.makeDataCorruptionInfo(jobID, level, m, res.blocks, details)
+	return true, &info, nil
+}
+
+// makeDataCorruptionInfo returns a DataCorruptionInfo describing corruption
+// found in the given table.
+func (d *DB) makeDataCorruptionInfo(
+	jobID JobID, level int, m *fileMetadata, blocks []sstable.CorruptBlock, details error,
+) DataCorruptionInfo {
+	info := DataCorruptionInfo{
+		JobID:   int(jobID),
+		Level:   level,
+		Meta:    m,
+		Blocks:  blocks,
+		Details: details,
+	}
+	if objMeta, err := d.objProvider.Lookup(fileTypeTable, m.FileBacking.DiskFileNum); err == nil {
+		info.Path = d.objProvider.Path(objMeta)
+		info.IsRemote = objMeta.IsRemote()
+	}
+	return info
+}
+
+// markCorruptFile marks a corrupt table for compaction, so that it is
+// rewritten.
+func (d *DB) markCorruptFile(m *fileMetadata) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	err := d.markFilesLocked(func(v *version) (bool, [numLevels][]*fileMetadata, error) {
+		var files [numLevels][]*fileMetadata
+		for l := range v.Levels {
+			if v.Contains(l, m) {
+				files[l] = append(files[l], m)
+				return true, files, nil
+			}
+		}
+		return false, files, nil
+	})
+	if err != nil {
+		d.opts.EventListener.BackgroundError(err)
+		return
+	}
+	d.maybeScheduleCompaction()
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package pebble
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/stretchr/testify/require"
+)
+
+// openScrubTestDB opens a DB with a single table in L0, and returns the DB and
+// the table's metadata.
+func openScrubTestDB(t *testing.T, opts *Options) (*DB, *fileMetadata) {
+	opts.FS = vfs.NewMem()
+	opts.DisableAutomaticCompactions = true
+	d, err := Open("", opts)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, d.Set([]byte(fmt.Sprintf("key%03d", i)), []byte("value"), nil))
+	}
+	require.NoError(t, d.Flush())
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	iter := d.mu.versions.currentVersion().Levels[0].Iter()
+	m := iter.First()
+	require.NotNil(t, m)
+	return d, m
+}
+
+// corruptScrubTestTable flips a byte in the first data block of the table.
+func corruptScrubTestTable(t *testing.T, d *DB, m *fileMetadata) {
+	objMeta, err := d.objProvider.Lookup(fileTypeTable, m.FileBacking.DiskFileNum)
+	require.NoError(t, err)
+	f, err := d.opts.FS.OpenReadWrite(d.objProvider.Path(objMeta), vfs.WriteCategoryUnspecified)
+	require.NoError(t, err)
+	b := make([]byte, 1)
+	_, err = f.ReadAt(b, 5)
+	require.NoError(t, err)
+	b[0] ^= 0xff
+	_, err = f.WriteAt(b, 5)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func TestScrub(t *testing.T) {
+	var infos []DataCorruptionInfo
+	opts := &Options{
+		EventListener: &EventListener{
+			DataCorruption: func(info DataCorruptionInfo) {
+				infos = append(infos, info)
+			},
+		},
+	}
+	opts.Experimental.Scrub.MarkCorruptFiles = true
+	d, m := openScrubTestDB(t, opts)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	stats, err := d.Scrub(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Tables)
+	require.Equal(t, 0, stats.CorruptTables)
+	require.Positive(t, stats.Bytes)
+	require.Empty(t, infos)
+
+	// The scrub reads the blocks from storage even if they are in the block
+	// cache.
+	corruptScrubTestTable(t, d, m)
+	stats, err = d.Scrub(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.CorruptTables)
+	require.Len(t, infos, 1)
+	info := infos[0]
+	require.Equal(t, m.FileNum, info.Meta.FileNum)
+	require.Equal(t, 0, info.Level)
+	require.Len(t, info.Blocks, 1)
+	require.Equal(t, "data", info.Blocks[0].Name)
+	require.Equal(t, uint64(0), info.Blocks[0].Handle.Offset)
+	require.True(t, IsCorruptionError(info.Details))
+	require.Contains(t, info.String(), "data block [0, ")
+
+	d.mu.Lock()
+	require.True(t, m.MarkedForCompaction)
+	require.Equal(t, 1, d.mu.versions.currentVersion().Stats.MarkedForCompaction)
+	d.mu.Unlock()
+}
+
+func TestScrubKeyOrder(t *testing.T) {
+	d, m := openScrubTestDB(t, &Options{})
+	defer func() { require.NoError(t, d.Close()) }()
+
+	// The check reads the data blocks of the table without adding them to the
+	// block cache. The first data block is at the start of the table.
+	ctx := context.Background()
+	require.NoError(t, checkTableKeyOrder(ctx, d.cmp, d.opts.Comparer.FormatKey, d.newIters, m))
+	h := d.opts.Cache.Get(d.cacheID, m.FileBacking.DiskFileNum, 0 /* offset */)
+	require.Nil(t, h.Get())
+	h.Release()
+
+	// A table whose keys are not within the bounds recorded in its metadata
+	// is corrupt.
+	narrowed := &fileMetadata{
+		FileNum:     m.FileNum,
+		FileBacking: m.FileBacking,
+		Size:        m.Size,
+	}
+	narrowed.ExtendPointKeyBounds(d.cmp,
+		base.MakeInternalKey([]byte("key010"), base.SeqNumMax, base.InternalKeyKindSet),
+		base.MakeInternalKey([]byte("key050"), 0, base.InternalKeyKindSet))
+	narrowed.InitPhysicalBacking()
+	err := checkTableKeyOrder(ctx, d.cmp, d.opts.Comparer.FormatKey, d.newIters, narrowed)
+	require.True(t, IsCorruptionError(err))
+	require.Contains(t, err.Error(), "below the smallest point key")
+}
+
+func TestScrubBackground(t *testing.T) {
+	ch := make(chan DataCorruptionInfo, 10)
+	opts := &Options{
+		EventListener: &EventListener{
+			DataCorruption: func(info DataCorruptionInfo) { ch <- info },
+		},
+	}
+	opts.Experimental.Scrub.BytesPerSecond = 1 << 30
+	opts.Experimental.Scrub.Interval = time.Millisecond
+	d, m := openScrubTestDB(t, opts)
+	defer func() { require.NoError(t, d.Close()) }()
+
+	corruptScrubTestTable(t, d, m)
+	select {
+	case info := <-ch:
+		require.Equal(t, m.FileNum, info.Meta.FileNum)
+	case <-time.After(10 * time.Second):
+		t.Fatal("corruption not reported by the background scrub")
+	}
+}
+
+func TestScrubClose(t *testing.T) {
+	opts := &Options{}
+	// Reading the table at one byte per second blocks the scrub until the DB
+	// is closed.
+	opts.Experimental.Scrub.BytesPerSecond = 1
+	opts.Experimental.Scrub.Interval = time.Hour
+	d, _ := openScrubTestDB(t, opts)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := d.Scrub(context.Background())
+		errCh <- err
+	}()
+	require.Eventually(t, func() bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return d.mu.scrub.scrubbing
+	}, 10*time.Second, time.Millisecond)
+	require.NoError(t, d.Close())
+	require.ErrorIs(t, <-errCh, ErrClosed)
+}
@@ -251,6 +251,13 @@ type IterTransforms struct {
 	HideObsoletePoints bool
 	SyntheticPrefix    SyntheticPrefix
 	SyntheticSuffix    SyntheticSuffix
+	// Projection, if non-nil, holds the indexes of the value columns to decode
+	// when reading values decomposed by a colblk.ValueSchema. The fields of
+	// columns that are not projected are zero when the value is reconstituted.
+	// A non-nil, empty projection decodes none of the value columns. Values
+	// that were not decomposed (including all values in row-oriented blocks)
+	// are returned whole.
+	Projection []int
 }
 
 // NoTransforms is the default value for IterTransforms.
@@ -37,15 +37,19 @@ func (b Value) Get() []byte {
 }
 
 // MakeHandle constructs a BufferHandle from the Value. If the Value is not
-// backed by a buffer pool, MakeHandle inserts the value into the block cache,
-// returning a handle to the now resident value.
+// backed by a buffer pool, MakeHandle inserts the value into the block cache
+// with the provided priority, returning a handle to the now resident value.
 func (b Value) MakeHandle(
-	c *cache.Cache, cacheID cache.ID, fileNum base.DiskFileNum, offset uint64,
+	c *cache.Cache,
+	cacheID cache.ID,
+	fileNum base.DiskFileNum,
+	offset uint64,
+	priority cache.Priority,
 ) BufferHandle {
 	if b.buf.Valid() {
 		return BufferHandle{b: b.buf}
 	}
-	return BufferHandle{h: c.Set(cacheID, fileNum, offset, b.v)}
+	return BufferHandle{h: c.SetWithPriority(cacheID, fileNum, offset, b.v, priority)}
 }
 
 // Release releases the handle.
@@ -103,12 +107,20 @@ func (bh BufferHandle) Release() {
 //
 // A BufferPool should only be used for short-lived allocations with
 // well-understood working set sizes to avoid excessive memory consumption.
+// Alternatively, SetMaxRetainedBytes bounds the memory the pool retains.
 //
 // BufferPool is not thread-safe.
 type BufferPool struct {
 	// pool contains all the buffers held by the pool, including buffers that
-	// are in-use. For every i < len(pool): pool[i].v is non-nil.
+	// are in-use. For every i < len(pool): pool[i].v is non-nil, unless the
+	// buffer was freed on release to respect maxRetainedBytes, in which case
+	// pool[i].b is also nil.
 	pool []AllocedBuffer
+	// retainedBytes is the total size of the buffers held by the pool.
+	retainedBytes int
+	// maxRetainedBytes, if positive, is the size beyond which released buffers
+	// are freed rather than retained for reuse.
+	maxRetainedBytes int
 }
 
 // AllocedBuffer is an allocated memory buffer.
@@ -139,6 +151,15 @@ func (p *BufferPool) InitPreallocated(pool []AllocedBuffer) {
 	}
 }
 
+// SetMaxRetainedBytes bounds the total size of the buffers held by the pool.
+// The buffers in use may exceed the bound, but when a buffer is released while
+// the pool holds more than maxRetainedBytes, the buffer is freed rather than
+// retained for reuse. A non-positive value removes the bound. The bound is
+// reset by Init and Release.
+func (p *BufferPool) SetMaxRetainedBytes(maxRetainedBytes int) {
+	p.maxRetainedBytes = maxRetainedBytes
+}
+
 // Release releases all buffers held by the pool and resets the pool to an
 // uninitialized state.
 func (p *BufferPool) Release() {
@@ -146,7 +167,9 @@ func (p *BufferPool) Release() {
 		if p.pool[i].b != nil {
 			panic(errors.AssertionFailedf("Release called on a BufferPool with in-use buffers"))
 		}
-		cache.Free(p.pool[i].v)
+		if p.pool[i].v != nil {
+			cache.Free(p.pool[i].v)
+		}
 	}
 	*p = BufferPool{}
 }
@@ -158,30 +181,47 @@ func (p *BufferPool) Release() {
 // buffers allocated and M is the initialSize passed to Init.
 func (p *BufferPool) Alloc(n int) Buf {
 	unusableBufferIdx := -1
+	freedBufferIdx := -1
 	for i := 0; i < len(p.pool); i++ {
 		if p.pool[i].b == nil {
-			if len(p.pool[i].v.Buf()) >= n {
+			if p.pool[i].v == nil {
+				freedBufferIdx = i
+			} else if len(p.pool[i].v.Buf()) >= n {
 				p.pool[i].b = p.pool[i].v.Buf()[:n]
 				return Buf{p: p, i: i}
+			} else {
+				unusableBufferIdx = i
 			}
-			unusableBufferIdx = i
 		}
 	}
 
+	// If there's a slot whose buffer was freed on release, allocate the new
+	// buffer into it.
+	if freedBufferIdx >= 0 {
+		i := freedBufferIdx
+		p.pool[i].v = cache.Alloc(n)
+		p.pool[i].b = p.pool[i].v.Buf()
+		p.retainedBytes += n
+		return Buf{p: p, i: i}
+	}
+
 	// If we would need to grow the size of the pool to allocate another buffer,
 	// but there was a slot available occupied by a buffer that's just too
 	// small, replace the too-small buffer.
 	if len(p.pool) == cap(p.pool) && unusableBufferIdx >= 0 {
 		i := unusableBufferIdx
+		p.retainedBytes -= len(p.pool[i].v.Buf())
 		cache.Free(p.pool[i].v)
 		p.pool[i].v = cache.Alloc(n)
 		p.pool[i].b = p.pool[i].v.Buf()
+		p.retainedBytes += n
 		return Buf{p: p, i: i}
 	}
 
 	// Allocate a new buffer.
 	v := cache.Alloc(n)
 	p.pool = append(p.pool, AllocedBuffer{v: v, b: v.Buf()[:n]})
+	p.retainedBytes += n
 	return Buf{p: p, i: len(p.pool) - 1}
 }
 
@@ -208,5 +248,11 @@ func (b *Buf) Release() {
 	// is no longer in use and a future call to BufferPool.Alloc may reuse this
 	// buffer.
 	b.p.pool[b.i].b = nil
+	// If the pool holds more than its bound, free the buffer instead.
+	if b.p.maxRetainedBytes > 0 && b.p.retainedBytes > b.p.maxRetainedBytes {
+		b.p.retainedBytes -= len(b.p.pool[b.i].v.Buf())
+		cache.Free(b.p.pool[b.i].v)
+		b.p.pool[b.i].v = nil
+	}
 	b.p = nil
 }
@@ -22,6 +22,10 @@ func writeBufferPool(w io.Writer, bp *BufferPool) {
 			fmt.Fprint(w, "[    ]")
 			continue
 		}
+		if bp.pool[i].v == nil {
+			fmt.Fprint(w, "[free]")
+			continue
+		}
 		sz := len(bp.pool[i].v.Buf())
 		if bp.pool[i].b == nil {
 			fmt.Fprintf(w, "[%4d]", sz)
@@ -53,6 +57,11 @@ func TestBufferPool(t *testing.T) {
 			var initialSize int
 			td.ScanArgs(t, "size", &initialSize)
 			bp.Init(initialSize)
+			if td.HasArg("max-retained") {
+				var maxRetained int
+				td.ScanArgs(t, "max-retained", &maxRetained)
+				bp.SetMaxRetainedBytes(maxRetained)
+			}
 			writeBufferPool(&buf, &bp)
 			return buf.String()
 		case "alloc":
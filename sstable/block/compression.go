@@ -248,6 +248,17 @@ func CompressAndChecksum(
 	return pb
 }
 
+// Compress compresses b using dstBuf as the desired destination, returning
+// the compression indicator and the compressed bytes. Unlike
+// CompressAndChecksum, the compressed bytes are returned even if they are not
+// smaller than b. It is used to compress data stored outside of sstable
+// blocks, such as the chunks of WAL records.
+func Compress(
+	compression Compression, b []byte, dstBuf []byte,
+) (indicator CompressionIndicator, compressed []byte) {
+	return compress(compression, b, dstBuf)
+}
+
 // compress compresses a sstable block, using dstBuf as the desired destination.
 func compress(
 	compression Compression, b []byte, dstBuf []byte,
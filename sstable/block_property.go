@@ -424,6 +424,23 @@ func decodeBlockInterval(buf []byte) (BlockInterval, error) {
 	return i, nil
 }
 
+// UnionBlockIntervals decodes the two properties collected by a
+// BlockIntervalCollector and appends the encoding of their union to acc[:0].
+// It may be used to aggregate the minimum and maximum of the values mapped by
+// an IntervalMapper over many blocks or tables.
+func UnionBlockIntervals(acc, prop []byte) ([]byte, error) {
+	a, err := decodeBlockInterval(acc)
+	if err != nil {
+		return nil, err
+	}
+	b, err := decodeBlockInterval(prop)
+	if err != nil {
+		return nil, err
+	}
+	a.UnionWith(b)
+	return encodeBlockInterval(a, acc[:0]), nil
+}
+
 // BlockIntervalSuffixReplacer provides methods to conduct just in time
 // adjustments of a passed in block prop interval before filtering.
 type BlockIntervalSuffixReplacer interface {
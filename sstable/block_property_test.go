@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/datadriven"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/cache"
 	"github.com/cockroachdb/pebble/internal/keyspan"
 	"github.com/cockroachdb/pebble/internal/rangekey"
 	"github.com/cockroachdb/pebble/internal/testkeys"
@@ -1319,7 +1320,7 @@ func runBlockPropsCmd(r *Reader) string {
 		// block that bhp points to, along with its block properties.
 		if twoLevelIndex {
 			subIndex, err := r.readBlock(
-				context.Background(), bhp.Handle, nil, nil, nil, nil, nil)
+				context.Background(), bhp.Handle, nil, nil, nil, nil, nil, cache.NormalPriority)
 			if err != nil {
 				return err.Error()
 			}
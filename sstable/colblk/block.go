@@ -235,10 +235,12 @@ func (e *blockEncoder) data() []byte {
 // encode writes w's columns to the block.
 func (e *blockEncoder) encode(rows int, w ColumnWriter) {
 	for i := 0; i < w.NumColumns(); i++ {
-		e.buf[e.headerOffset] = byte(w.DataType(i))
 		binary.LittleEndian.PutUint32(e.buf[e.headerOffset+1:], e.pageOffset)
-		e.headerOffset += columnHeaderSize
 		e.pageOffset = w.Finish(i, rows, e.pageOffset, e.buf)
+		// NB: The data type is retrieved after finishing the column, because
+		// some writers choose the column's physical representation in Finish.
+		e.buf[e.headerOffset] = byte(w.DataType(i))
+		e.headerOffset += columnHeaderSize
 	}
 }
 
@@ -363,6 +365,54 @@ func (r *BlockReader) Uints(col int) UnsafeUints {
 	return DecodeColumn(r, col, int(r.header.Rows), DataTypeUint, DecodeUnsafeUints)
 }
 
+// DictionaryBytes retrieves the col'th column as a dictionary-encoded column of
+// byte slices. The column must be of type DataTypeDictionaryBytes.
+func (r *BlockReader) DictionaryBytes(col int) DictionaryBytes {
+	return DecodeColumn(r, col, int(r.header.Rows), DataTypeDictionaryBytes, DecodeDictionaryBytes)
+}
+
+// RunLengthUints retrieves the col'th column as a run-length encoded column of
+// uints. The column must be of type DataTypeRunLengthUint.
+func (r *BlockReader) RunLengthUints(col int) RunLengthUints {
+	return DecodeColumn(r, col, int(r.header.Rows), DataTypeRunLengthUint, DecodeRunLengthUints)
+}
+
+// RunLengthBitmap retrieves the col'th column as a run-length encoded column
+// of bools. The column must be of type DataTypeRunLengthBool.
+func (r *BlockReader) RunLengthBitmap(col int) RunLengthBitmap {
+	return DecodeColumn(r, col, int(r.header.Rows), DataTypeRunLengthBool, DecodeRunLengthBitmap)
+}
+
+// AdaptiveBytes retrieves the col'th column as a column of byte slices written
+// by an AdaptiveBytesBuilder. The column must be of type DataTypeBytes or
+// DataTypeDictionaryBytes.
+func (r *BlockReader) AdaptiveBytes(col int) AdaptiveBytes {
+	if r.DataType(col) == DataTypeDictionaryBytes {
+		return AdaptiveBytes{dict: r.DictionaryBytes(col), isDictionary: true}
+	}
+	return AdaptiveBytes{raw: r.RawBytes(col)}
+}
+
+// AdaptiveUints retrieves the col'th column as a column of uints written by an
+// AdaptiveUintBuilder. The column must be of type DataTypeUint or
+// DataTypeRunLengthUint.
+func (r *BlockReader) AdaptiveUints(col int) AdaptiveUints {
+	if r.DataType(col) == DataTypeRunLengthUint {
+		return AdaptiveUints{runs: r.RunLengthUints(col), isRunLength: true}
+	}
+	return AdaptiveUints{uints: r.Uints(col)}
+}
+
+// AdaptiveBitmap retrieves the col'th column as a column of bools written by
+// an AdaptiveBitmapBuilder. The column must be of type DataTypeBool or
+// DataTypeRunLengthBool.
+func (r *BlockReader) AdaptiveBitmap(col int) AdaptiveBitmap {
+	if r.DataType(col) == DataTypeRunLengthBool {
+		return AdaptiveBitmap{runs: r.RunLengthBitmap(col), isRunLength: true}
+	}
+	return AdaptiveBitmap{bitmap: r.Bitmap(col)}
+}
+
 func (r *BlockReader) pageStart(col int) uint32 {
 	if uint16(col) >= r.header.Columns {
 		// -1 for the trailing version byte
@@ -430,6 +480,12 @@ func (r *BlockReader) columnToBinFormatter(f *binfmt.Formatter, col, rows int) {
 			prefixBytesToBinFormatter(f, rows, nil)
 		case DataTypeBytes:
 			rawBytesToBinFormatter(f, rows, nil)
+		case DataTypeDictionaryBytes:
+			dictionaryBytesToBinFormatter(f, rows)
+		case DataTypeRunLengthUint:
+			runLengthUintsToBinFormatter(f, rows)
+		case DataTypeRunLengthBool:
+			runLengthBitmapToBinFormatter(f, rows)
 		default:
 			panic("unimplemented")
 		}
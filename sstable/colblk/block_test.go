@@ -109,6 +109,16 @@ func TestBlockWriter(t *testing.T) {
 					colWriters[i] = bb
 				case DataTypePrefixBytes:
 					panic("unimplemented")
+				case DataTypeDictionaryBytes:
+					b := &AdaptiveBytesBuilder{}
+					b.Init()
+					colWriters[i] = b
+				case DataTypeRunLengthUint:
+					b := &AdaptiveUintBuilder{}
+					b.Init()
+					colWriters[i] = b
+				case DataTypeRunLengthBool:
+					colWriters[i] = &AdaptiveBitmapBuilder{}
 				default:
 					panic(fmt.Sprintf("unsupported data type: %s", v))
 				}
@@ -148,6 +158,27 @@ func TestBlockWriter(t *testing.T) {
 					}
 				case DataTypePrefixBytes:
 					panic("unimplemented")
+				case DataTypeDictionaryBytes:
+					b := colWriters[c].(*AdaptiveBytesBuilder)
+					for r := range lineFields {
+						b.Put([]byte(lineFields[r][c]))
+					}
+				case DataTypeRunLengthUint:
+					b := colWriters[c].(*AdaptiveUintBuilder)
+					for r := range lineFields {
+						v, err := strconv.ParseUint(lineFields[r][c], 10, 64)
+						panicIfErr(dataType, lineFields[r][c], err)
+						b.Set(r, v)
+					}
+				case DataTypeRunLengthBool:
+					b := colWriters[c].(*AdaptiveBitmapBuilder)
+					for r := range lineFields {
+						v, err := strconv.ParseBool(lineFields[r][c])
+						panicIfErr(dataType, lineFields[r][c], err)
+						if v {
+							b.Set(r)
+						}
+					}
 				default:
 					panic(fmt.Sprintf("unsupported data type: %s", dataType))
 				}
@@ -208,7 +239,39 @@ func randBlock(rng *rand.Rand, rows int, schema []testColumnSpec) ([]byte, []int
 			// PrefixBytes are required to be lexicographically sorted.
 			slices.SortFunc(v, bytes.Compare)
 			data[col] = v
-
+		case DataTypeDictionaryBytes:
+			// Choose values from a small set of distinct values, so that a
+			// dictionary encoding is usually smaller.
+			distinct := make([][]byte, 1+rng.Intn(8))
+			for i := range distinct {
+				distinct[i] = make([]byte, rng.Intn(20))
+				rng.Read(distinct[i])
+			}
+			v := make([][]byte, rows)
+			for row := 0; row < rows; row++ {
+				v[row] = distinct[rng.Intn(len(distinct))]
+			}
+			data[col] = v
+		case DataTypeRunLengthUint:
+			// Generate runs of equal values, so that a run-length encoding is
+			// usually smaller.
+			v := make([]uint64, rows)
+			for row := 0; row < rows; row++ {
+				v[row] = schema[col].IntRange.Rand(rng)
+				if row > 0 && rng.Intn(10) > 0 {
+					v[row] = v[row-1]
+				}
+			}
+			data[col] = v
+		case DataTypeRunLengthBool:
+			v := make([]bool, rows)
+			for row := 0; row < rows; row++ {
+				v[row] = (rng.Int31() % 2) == 0
+				if row > 0 && rng.Intn(10) > 0 {
+					v[row] = v[row-1]
+				}
+			}
+			data[col] = v
 		}
 	}
 	buf := buildBlock(schema, rows, data)
@@ -254,11 +317,49 @@ func buildBlock(schema []testColumnSpec, rows int, data []interface{}) []byte {
 				pbb.Put(v, sharedPrefix)
 			}
 			cw[col] = &pbb
+		case DataTypeDictionaryBytes:
+			var b AdaptiveBytesBuilder
+			b.Init()
+			for _, v := range data[col].([][]byte) {
+				b.Put(v)
+			}
+			cw[col] = &b
+		case DataTypeRunLengthUint:
+			var b AdaptiveUintBuilder
+			b.Init()
+			for row, v := range data[col].([]uint64) {
+				b.Set(row, v)
+			}
+			cw[col] = &b
+		case DataTypeRunLengthBool:
+			var b AdaptiveBitmapBuilder
+			b.Reset()
+			for row, v := range data[col].([]bool) {
+				if v {
+					b.Set(row)
+				}
+			}
+			cw[col] = &b
 		}
 	}
 	return FinishBlock(rows, cw)
 }
 
+// adaptiveFallbackDataType returns the data type an adaptive builder of the
+// provided data type writes when it doesn't choose the provided data type.
+func adaptiveFallbackDataType(dt DataType) DataType {
+	switch dt {
+	case DataTypeDictionaryBytes:
+		return DataTypeBytes
+	case DataTypeRunLengthUint:
+		return DataTypeUint
+	case DataTypeRunLengthBool:
+		return DataTypeBool
+	default:
+		return dt
+	}
+}
+
 func testRandomBlock(t *testing.T, rng *rand.Rand, rows int, schema []testColumnSpec) {
 	var sb strings.Builder
 	for i := range schema {
@@ -278,7 +379,9 @@ func testRandomBlock(t *testing.T, rng *rand.Rand, rows int, schema []testColumn
 			t.Fatalf("expected %d rows, but found %d\n", rows, r.header.Rows)
 		}
 		for col := range schema {
-			if schema[col].DataType != r.DataType(col) {
+			// Columns written by adaptive builders may fall back to the
+			// encoding of the corresponding non-adaptive type.
+			if dt := r.DataType(col); dt != schema[col].DataType && dt != adaptiveFallbackDataType(schema[col].DataType) {
 				t.Fatalf("schema mismatch: %s != %s\n", schema[col], r.DataType(col))
 			}
 		}
@@ -295,6 +398,12 @@ func testRandomBlock(t *testing.T, rng *rand.Rand, rows int, schema []testColumn
 				got = Clone(r.RawBytes(col), rows)
 			case DataTypePrefixBytes:
 				got = Clone(r.PrefixBytes(col), rows)
+			case DataTypeDictionaryBytes:
+				got = Clone(r.AdaptiveBytes(col), rows)
+			case DataTypeRunLengthUint:
+				got = Clone(r.AdaptiveUints(col), rows)
+			case DataTypeRunLengthBool:
+				got = Clone(r.AdaptiveBitmap(col), rows)
 			}
 			if !reflect.DeepEqual(data[col], got) {
 				t.Fatalf("%d: %s: expected\n%+v\ngot\n%+v\n% x",
@@ -317,6 +426,11 @@ func TestBlockWriterRandomized(t *testing.T) {
 	}
 	testRandomBlock(t, rng, randInt(1, 100), []testColumnSpec{{DataType: DataTypeBytes}})
 	testRandomBlock(t, rng, randInt(1, 100), []testColumnSpec{{DataType: DataTypePrefixBytes, BundleSize: 1 << randInt(0, 6)}})
+	testRandomBlock(t, rng, randInt(1, 100), []testColumnSpec{{DataType: DataTypeDictionaryBytes}})
+	for _, r := range interestingIntRanges {
+		testRandomBlock(t, rng, randInt(1, 100), []testColumnSpec{{DataType: DataTypeRunLengthUint, IntRange: r}})
+	}
+	testRandomBlock(t, rng, randInt(1, 100), []testColumnSpec{{DataType: DataTypeRunLengthBool}})
 
 	for i := 0; i < 100; i++ {
 		schema := make([]testColumnSpec, 2+rng.Intn(8))
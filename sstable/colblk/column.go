@@ -25,16 +25,28 @@ const (
 	// DataTypePrefixBytes is a data type encoding variable-length,
 	// lexicographically-sorted byte strings, with prefix compression.
 	DataTypePrefixBytes DataType = 4
+	// DataTypeDictionaryBytes is a data type encoding a variable-length byte
+	// string per row as a code into a dictionary of the column's distinct
+	// values.
+	DataTypeDictionaryBytes DataType = 5
+	// DataTypeRunLengthUint is a data type encoding runs of equal unsigned
+	// integers.
+	DataTypeRunLengthUint DataType = 6
+	// DataTypeRunLengthBool is a data type encoding runs of equal bools.
+	DataTypeRunLengthBool DataType = 7
 
-	dataTypesCount DataType = 5
+	dataTypesCount DataType = 8
 )
 
 var dataTypeName [dataTypesCount]string = [dataTypesCount]string{
-	DataTypeInvalid:     "invalid",
-	DataTypeBool:        "bool",
-	DataTypeUint:        "uint",
-	DataTypeBytes:       "bytes",
-	DataTypePrefixBytes: "prefixbytes",
+	DataTypeInvalid:         "invalid",
+	DataTypeBool:            "bool",
+	DataTypeUint:            "uint",
+	DataTypeBytes:           "bytes",
+	DataTypePrefixBytes:     "prefixbytes",
+	DataTypeDictionaryBytes: "dictbytes",
+	DataTypeRunLengthUint:   "rleuint",
+	DataTypeRunLengthBool:   "rlebool",
 }
 
 // String returns a human-readable string representation of the data type.
@@ -48,7 +60,10 @@ type ColumnWriter interface {
 	Encoder
 	// NumColumns returns the number of columns the ColumnWriter will encode.
 	NumColumns() int
-	// DataType returns the data type of the col'th column.
+	// DataType returns the data type of the col'th column. A ColumnWriter that
+	// chooses between physical representations returns the data type of the
+	// representation chosen by the most recent call to Finish; DataType is
+	// called after the column is finished.
 	DataType(col int) DataType
 	// Finish serializes the column at the specified index, writing the column's
 	// data to buf at offset, and returning the offset at which the next column
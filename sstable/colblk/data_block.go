@@ -146,12 +146,41 @@ var defaultKeySeekerPool = sync.Pool{
 // DefaultKeySchema returns the default key schema that decomposes a user key
 // into its prefix and suffix. Prefixes are sorted in lexicographical order.
 func DefaultKeySchema(comparer *base.Comparer, prefixBundleSize int) KeySchema {
+	return DefaultKeySchemaWithOptions(comparer, DefaultKeySchemaOptions{
+		PrefixBundleSize: prefixBundleSize,
+	})
+}
+
+// DefaultKeySchemaOptions configures a default key schema.
+type DefaultKeySchemaOptions struct {
+	// PrefixBundleSize is the bundle size of the prefix column. See
+	// PrefixBytes.
+	PrefixBundleSize int
+	// AdaptiveSuffixes configures the schema's KeyWriter to choose the encoding
+	// of each block's suffix column, encoding the suffixes as DictionaryBytes
+	// when that's smaller than RawBytes. A block's suffixes are usually low
+	// cardinality when its keys were written at a handful of timestamps. The
+	// schema's KeySeeker reads blocks written with or without the option.
+	AdaptiveSuffixes bool
+}
+
+// DefaultKeySchemaWithOptions returns a default key schema, as returned by
+// DefaultKeySchema, configured by the provided options.
+func DefaultKeySchemaWithOptions(comparer *base.Comparer, opts DefaultKeySchemaOptions) KeySchema {
 	return KeySchema{
 		ColumnTypes: defaultSchemaColumnTypes,
 		NewKeyWriter: func() KeyWriter {
 			kw := &defaultKeyWriter{comparer: comparer}
-			kw.prefixes.Init(prefixBundleSize)
-			kw.suffixes.Init()
+			kw.prefixes.Init(opts.PrefixBundleSize)
+			if opts.AdaptiveSuffixes {
+				suffixes := &AdaptiveBytesBuilder{}
+				suffixes.Init()
+				kw.suffixes = suffixes
+			} else {
+				suffixes := &RawBytesBuilder{}
+				suffixes.Init()
+				kw.suffixes = suffixes
+			}
 			return kw
 		},
 		NewKeySeeker: func() KeySeeker {
@@ -168,7 +197,16 @@ var _ KeyWriter = (*defaultKeyWriter)(nil)
 type defaultKeyWriter struct {
 	comparer *base.Comparer
 	prefixes PrefixBytesBuilder
-	suffixes RawBytesBuilder
+	suffixes suffixesBuilder
+}
+
+// suffixesBuilder builds the suffix column of the default key schema. It's
+// implemented by *RawBytesBuilder and *AdaptiveBytesBuilder.
+type suffixesBuilder interface {
+	ColumnWriter
+	Put(s []byte)
+	Rows() int
+	UnsafeGet(i int) []byte
 }
 
 func (w *defaultKeyWriter) ComparePrev(key []byte) KeyComparison {
@@ -189,7 +227,7 @@ func (w *defaultKeyWriter) ComparePrev(key []byte) KeyComparison {
 	if cmpv.CommonPrefixLen == cmpv.PrefixLen {
 		// The keys share the same MVCC prefix. Compare the suffixes.
 		cmpv.UserKeyComparison = int32(w.comparer.CompareSuffixes(key[cmpv.PrefixLen:],
-			w.suffixes.UnsafeGet(w.suffixes.rows-1)))
+			w.suffixes.UnsafeGet(w.suffixes.Rows()-1)))
 		if invariants.Enabled {
 			if !w.comparer.Equal(lp, key[:cmpv.PrefixLen]) {
 				panic(errors.AssertionFailedf("keys have different logical prefixes: %q != %q", lp, key[:cmpv.PrefixLen]))
@@ -215,7 +253,7 @@ func (w *defaultKeyWriter) ComparePrev(key []byte) KeyComparison {
 		// the prefixes and nonzero.
 		if cmpv.UserKeyComparison == 0 {
 			panic(errors.AssertionFailedf("user keys should not be equal: %q+%q, %q",
-				lp, w.suffixes.UnsafeGet(w.suffixes.rows-1), key))
+				lp, w.suffixes.UnsafeGet(w.suffixes.Rows()-1), key))
 		}
 		if v := w.comparer.Compare(key, lp); v != int(cmpv.UserKeyComparison) {
 			panic(errors.AssertionFailedf("user key comparison mismatch: Compare(%q, %q) = %d ≠ %d",
@@ -243,6 +281,9 @@ func (w *defaultKeyWriter) NumColumns() int {
 }
 
 func (w *defaultKeyWriter) DataType(col int) DataType {
+	if col == defaultKeySchemaColumnSuffix {
+		return w.suffixes.DataType(0)
+	}
 	return defaultSchemaColumnTypes[col]
 }
 
@@ -284,14 +325,14 @@ type defaultKeySeeker struct {
 	comparer     *base.Comparer
 	reader       *DataBlockReader
 	prefixes     PrefixBytes
-	suffixes     RawBytes
+	suffixes     AdaptiveBytes
 	sharedPrefix []byte
 }
 
 func (ks *defaultKeySeeker) Init(r *DataBlockReader) error {
 	ks.reader = r
 	ks.prefixes = r.r.PrefixBytes(defaultKeySchemaColumnPrefix)
-	ks.suffixes = r.r.RawBytes(defaultKeySchemaColumnSuffix)
+	ks.suffixes = r.r.AdaptiveBytes(defaultKeySchemaColumnSuffix)
 	ks.sharedPrefix = ks.prefixes.SharedPrefix()
 	return nil
 }
@@ -372,7 +413,7 @@ type DataBlockWriter struct {
 	Schema    KeySchema
 	KeyWriter KeyWriter
 	// trailers is the column writer for InternalKey uint64 trailers.
-	trailers UintBuilder
+	trailers AdaptiveUintBuilder
 	// prefixSame is the column writer for the prefix-changed bitmap that
 	// indicates when a new key prefix begins. During block building, the bitmap
 	// represents when the prefix stays the same, which is expected to be a
@@ -381,14 +422,17 @@ type DataBlockWriter struct {
 	// values is the column writer for values. Iff the isValueExternal bitmap
 	// indicates a value is external, the value is prefixed with a ValuePrefix
 	// byte.
-	values RawBytesBuilder
+	values AdaptiveBytesBuilder
 	// isValueExternal is the column writer for the is-value-external bitmap
 	// that indicates when a value is stored out-of-band in a value block.
-	isValueExternal BitmapBuilder
+	isValueExternal AdaptiveBitmapBuilder
 	// isObsolete is the column writer for the is-obsolete bitmap that indicates
 	// when a key is known to be obsolete/non-live (i.e., shadowed by another
 	// identical point key or range deletion with a higher sequence number).
 	isObsolete BitmapBuilder
+	// valueColumns is the column writer for the columns of values decomposed
+	// by the ValueSchema. It's only used if the ValueSchema has columns.
+	valueColumns valueColumnsWriter
 
 	enc              blockEncoder
 	rows             int
@@ -414,7 +458,15 @@ const dataBlockCustomHeaderSize = 4
 
 // Init initializes the data block writer.
 func (w *DataBlockWriter) Init(schema KeySchema) {
+	w.InitWithValueSchema(schema, ValueSchema{})
+}
+
+// InitWithValueSchema initializes the data block writer, decomposing values
+// stored in-place according to the provided ValueSchema. The columns of the
+// value schema are encoded after the data block's fixed columns.
+func (w *DataBlockWriter) InitWithValueSchema(schema KeySchema, valueSchema ValueSchema) {
 	w.Schema = schema
+	w.valueColumns.init(valueSchema)
 	w.KeyWriter = schema.NewKeyWriter()
 	w.trailers.Init()
 	w.prefixSame.Reset()
@@ -435,6 +487,7 @@ func (w *DataBlockWriter) Reset() {
 	w.values.Reset()
 	w.isValueExternal.Reset()
 	w.isObsolete.Reset()
+	w.valueColumns.Reset()
 	w.rows = 0
 	w.maximumKeyLength = 0
 	w.lastUserKeyTmp = w.lastUserKeyTmp[:0]
@@ -468,6 +521,18 @@ func (w *DataBlockWriter) String() string {
 	w.isObsolete.WriteDebug(&buf, w.rows)
 	fmt.Fprintln(&buf)
 
+	if len(w.valueColumns.cols) > 0 {
+		col := len(w.Schema.ColumnTypes) + dataBlockColumnMax
+		fmt.Fprintf(&buf, "%d: is-value-split: ", col)
+		w.valueColumns.isSplit.WriteDebug(&buf, w.rows)
+		fmt.Fprintln(&buf)
+		for i := range w.valueColumns.cols {
+			fmt.Fprintf(&buf, "%d: value[%d]:       ", col+1+i, i)
+			w.valueColumns.cols[i].writer().WriteDebug(&buf, w.rows)
+			fmt.Fprintln(&buf)
+		}
+	}
+
 	return buf.String()
 }
 
@@ -499,6 +564,13 @@ func (w *DataBlockWriter) Add(
 		// Write the value with the value prefix byte preceding the value.
 		w.valuePrefixTmp[0] = byte(valuePrefix)
 		w.values.PutConcat(w.valuePrefixTmp[:], value)
+		if len(w.valueColumns.cols) > 0 {
+			w.valueColumns.add(w.rows, value, false /* decompose */)
+		}
+	} else if len(w.valueColumns.cols) > 0 && w.valueColumns.add(w.rows, value, isDecomposable(ikey.Kind())) {
+		// The value is stored in the value columns. Readers will examine the
+		// isValueSplit bitmap and reconstitute the value.
+		w.values.Put(nil)
 	} else {
 		// Elide the value prefix. Readers will examine the isValueExternal
 		// bitmap and know there is no value prefix byte if !isValueExternal.
@@ -510,20 +582,40 @@ func (w *DataBlockWriter) Add(
 	w.rows++
 }
 
+// isDecomposable returns true if the values of keys of the given kind are
+// application values which may be decomposed by a ValueSchema. The values of
+// other kinds (eg, merge operands or the size of a DELSIZED) are stored
+// verbatim.
+func isDecomposable(kind base.InternalKeyKind) bool {
+	return kind == base.InternalKeyKindSet || kind == base.InternalKeyKindSetWithDelete
+}
+
 // Rows returns the number of rows in the current pending data block.
 func (w *DataBlockWriter) Rows() int {
 	return w.rows
 }
 
+// columns returns the number of columns in the data block.
+func (w *DataBlockWriter) columns() int {
+	n := len(w.Schema.ColumnTypes) + dataBlockColumnMax
+	if len(w.valueColumns.cols) > 0 {
+		n += w.valueColumns.NumColumns()
+	}
+	return n
+}
+
 // Size returns the size of the current pending data block.
 func (w *DataBlockWriter) Size() int {
-	off := blockHeaderSize(len(w.Schema.ColumnTypes)+dataBlockColumnMax, dataBlockCustomHeaderSize)
+	off := blockHeaderSize(w.columns(), dataBlockCustomHeaderSize)
 	off = w.KeyWriter.Size(w.rows, off)
 	off = w.trailers.Size(w.rows, off)
 	off = w.prefixSame.InvertedSize(w.rows, off)
 	off = w.values.Size(w.rows, off)
 	off = w.isValueExternal.Size(w.rows, off)
 	off = w.isObsolete.Size(w.rows, off)
+	if len(w.valueColumns.cols) > 0 {
+		off = w.valueColumns.Size(w.rows, off)
+	}
 	off++ // trailer padding byte
 	return int(off)
 }
@@ -542,7 +634,7 @@ func (w *DataBlockWriter) Finish(rows, size int) (finished []byte, lastKey base.
 		panic(errors.AssertionFailedf("data block has %d rows; asked to finish %d", w.rows, rows))
 	}
 
-	cols := len(w.Schema.ColumnTypes) + dataBlockColumnMax
+	cols := w.columns()
 	h := Header{
 		Version: Version1,
 		Columns: uint16(cols),
@@ -564,6 +656,9 @@ func (w *DataBlockWriter) Finish(rows, size int) (finished []byte, lastKey base.
 	w.enc.encode(rows, &w.values)
 	w.enc.encode(rows, &w.isValueExternal)
 	w.enc.encode(rows, &w.isObsolete)
+	if len(w.valueColumns.cols) > 0 {
+		w.enc.encode(rows, &w.valueColumns)
+	}
 	finished = w.enc.finish()
 
 	w.lastUserKeyTmp = w.lastUserKeyTmp[:0]
@@ -578,6 +673,9 @@ func (w *DataBlockWriter) Finish(rows, size int) (finished []byte, lastKey base.
 // DataBlockRewriter rewrites data blocks. See RewriteSuffixes.
 type DataBlockRewriter struct {
 	KeySchema KeySchema
+	// ValueSchema is the schema of the values of the rewritten blocks, if
+	// any.
+	ValueSchema ValueSchema
 
 	writer    DataBlockWriter
 	reader    DataBlockReader
@@ -604,7 +702,7 @@ func (rw *DataBlockRewriter) RewriteSuffixes(
 ) (start, end base.InternalKey, rewritten []byte, err error) {
 	if !rw.initialized {
 		rw.keySeeker = rw.KeySchema.NewKeySeeker()
-		rw.writer.Init(rw.KeySchema)
+		rw.writer.InitWithValueSchema(rw.KeySchema, rw.ValueSchema)
 		rw.initialized = true
 	}
 
@@ -632,7 +730,8 @@ func (rw *DataBlockRewriter) RewriteSuffixes(
 	rw.reader.Init(rw.KeySchema, input)
 	rw.keySeeker.Init(&rw.reader)
 	rw.writer.Reset()
-	if err = rw.iter.Init(&rw.reader, rw.keySeeker, nil, block.IterTransforms{}); err != nil {
+	err = rw.iter.InitWithValueSchema(&rw.reader, rw.keySeeker, rw.ValueSchema, nil, block.IterTransforms{})
+	if err != nil {
 		return base.InternalKey{}, base.InternalKey{}, nil, err
 	}
 
@@ -685,7 +784,7 @@ type DataBlockReader struct {
 	r BlockReader
 	// trailers holds an array of the InternalKey trailers, encoding the key
 	// kind and sequence number of each key.
-	trailers UnsafeUints
+	trailers AdaptiveUints
 	// prefixChanged is a bitmap indicating when the prefix (as defined by
 	// Split) of a key changes, relative to the preceding key. This is used to
 	// bound seeks within a prefix, and to optimize NextPrefix.
@@ -693,15 +792,25 @@ type DataBlockReader struct {
 	// values is the column reader for values. If the isValueExternal bitmap
 	// indicates a value is external, the value is prefixed with a ValuePrefix
 	// byte.
-	values RawBytes
+	values AdaptiveBytes
 	// isValueExternal is the column reader for the is-value-external bitmap
 	// that indicates whether a value is stored out-of-band in a value block. If
 	// true, the value contains a ValuePrefix byte followed by an encoded value
 	// handle indicating the value's location within the value block(s).
-	isValueExternal Bitmap
+	isValueExternal AdaptiveBitmap
 	// isObsolete is the column reader for the is-obsolete bitmap
 	// that indicates whether a key is obsolete/non-live.
 	isObsolete Bitmap
+	// valueColumnsStart is the index of the first of the columns holding
+	// values decomposed by a ValueSchema, or zero if the block was written
+	// without a ValueSchema. The first of these columns is the isValueSplit
+	// bitmap, followed by a column for each of the ValueSchema's fields.
+	valueColumnsStart int
+	// isValueSplit is the column reader for the is-value-split bitmap that
+	// indicates whether a value was decomposed into the value columns. If
+	// true, the value is reconstituted from the value columns, and the values
+	// column holds an empty value.
+	isValueSplit Bitmap
 	// maximumKeyLength is the maximum length of a user key in the block.
 	// Iterators may use it to allocate a sufficiently large buffer up front,
 	// and elide size checks during iteration. Note that iterators should add +1
@@ -719,11 +828,16 @@ func (r *DataBlockReader) BlockReader() *BlockReader {
 // Init initializes the data block reader with the given serialized data block.
 func (r *DataBlockReader) Init(schema KeySchema, data []byte) {
 	r.r.Init(data, dataBlockCustomHeaderSize)
-	r.trailers = r.r.Uints(len(schema.ColumnTypes) + dataBlockColumnTrailer)
+	r.trailers = r.r.AdaptiveUints(len(schema.ColumnTypes) + dataBlockColumnTrailer)
 	r.prefixChanged = r.r.Bitmap(len(schema.ColumnTypes) + dataBlockColumnPrefixChanged)
-	r.values = r.r.RawBytes(len(schema.ColumnTypes) + dataBlockColumnValue)
-	r.isValueExternal = r.r.Bitmap(len(schema.ColumnTypes) + dataBlockColumnIsValueExternal)
+	r.values = r.r.AdaptiveBytes(len(schema.ColumnTypes) + dataBlockColumnValue)
+	r.isValueExternal = r.r.AdaptiveBitmap(len(schema.ColumnTypes) + dataBlockColumnIsValueExternal)
 	r.isObsolete = r.r.Bitmap(len(schema.ColumnTypes) + dataBlockColumnIsObsolete)
+	r.valueColumnsStart, r.isValueSplit = 0, Bitmap{}
+	if n := len(schema.ColumnTypes) + dataBlockColumnMax; int(r.r.header.Columns) > n {
+		r.valueColumnsStart = n
+		r.isValueSplit = r.r.Bitmap(n)
+	}
 	r.maximumKeyLength = binary.LittleEndian.Uint32(data[:dataBlockCustomHeaderSize])
 }
 
@@ -764,6 +878,10 @@ type MultiDataBlockIter struct {
 	// KeySchema when initializing the DataBlockIter for iteration over a new
 	// block.
 	KeySchema KeySchema
+	// ValueSchema configures the DataBlockIterConfig to use the provided
+	// ValueSchema when initializing the DataBlockIter for iteration over a new
+	// block.
+	ValueSchema ValueSchema
 	// GetLazyValuer configures the DataBlockIterConfig to initialize the
 	// DataBlockIter to use the provided handler for retrieving lazy values.
 	GetLazyValuer block.GetLazyValueForPrefixAndValueHandler
@@ -779,7 +897,12 @@ func (c *MultiDataBlockIter) InitHandle(
 	c.h.Release()
 	c.h = h
 	c.r.Init(c.KeySchema, h.Get())
-	return c.DataBlockIter.Init(&c.r, c.KeySchema.NewKeySeeker(), c.GetLazyValuer, t)
+	if c.keySeeker != nil {
+		c.keySeeker.Release()
+	}
+	err := c.DataBlockIter.InitWithValueSchema(&c.r, c.KeySchema.NewKeySeeker(), c.ValueSchema, c.GetLazyValuer, t)
+	c.DataBlockIter.cmp, c.DataBlockIter.split = cmp, split
+	return err
 }
 
 // Handle returns the handle to the block.
@@ -789,7 +912,7 @@ func (c *MultiDataBlockIter) Handle() block.BufferHandle {
 
 // Valid returns true if the iterator is currently positioned at a valid KV.
 func (i *MultiDataBlockIter) Valid() bool {
-	return i.row >= 0 && i.row <= i.maxRow
+	return i.DataBlockIter.r != nil && i.row >= 0 && i.row <= i.maxRow
 }
 
 // KV returns the key-value pair at the current iterator position. The
@@ -803,6 +926,9 @@ func (i *MultiDataBlockIter) KV() *base.InternalKV {
 // a call to Invalidate, but all positioning methods should return false.
 // Valid() must also return false.
 func (c *MultiDataBlockIter) Invalidate() {
+	if c.keySeeker != nil {
+		c.keySeeker.Release()
+	}
 	c.DataBlockIter = DataBlockIter{}
 }
 
@@ -820,6 +946,7 @@ func (i *MultiDataBlockIter) ResetForReuse() MultiDataBlockIter {
 			keyIter: PrefixBytesIter{buf: i.DataBlockIter.keyIter.buf},
 		},
 		KeySchema:     i.KeySchema,
+		ValueSchema:   i.ValueSchema,
 		GetLazyValuer: i.GetLazyValuer,
 	}
 }
@@ -844,6 +971,10 @@ type DataBlockIter struct {
 	keySeeker     KeySeeker
 	getLazyValuer block.GetLazyValueForPrefixAndValueHandler
 	transforms    block.IterTransforms
+	valueSchema   ValueSchema
+	// valueCols holds the readers for the projected value columns. It's empty
+	// if the block was written without a ValueSchema.
+	valueCols []valueColumnReader
 
 	// state
 	keyIter PrefixBytesIter
@@ -855,17 +986,52 @@ type DataBlockIter struct {
 	// It is used to optimize skipping of obsolete points during forward
 	// iteration.
 	nextObsoletePoint int
+	// valueFields and valueBuf are used to reconstitute decomposed values.
+	// Fields that are not projected are always zero.
+	valueFields []ValueField
+	valueBuf    []byte
+
+	// cmp and split are used to apply the synthetic prefix and suffix
+	// transforms. They're only set when the iterator is initialized through
+	// MultiDataBlockIter.InitHandle, which is the only way to iterate over a
+	// block with a synthetic prefix or suffix.
+	cmp   base.Compare
+	split base.Split
+	// synthetic is true if transforms.SyntheticPrefix or
+	// transforms.SyntheticSuffix is set.
+	synthetic bool
+	// synthKeyBuf holds the user key of i.kv with the synthetic prefix and
+	// suffix applied, and synthSeekBuf holds keys with synthetic transforms
+	// applied that are compared against seek keys.
+	synthKeyBuf  []byte
+	synthSeekBuf []byte
 }
 
 // Init initializes the data block iterator, configuring it to read from the
-// provided reader.
+// provided reader. The block must have been written without a ValueSchema.
 func (i *DataBlockIter) Init(
 	r *DataBlockReader,
 	keyIterator KeySeeker,
 	getLazyValuer block.GetLazyValueForPrefixAndValueHandler,
 	transforms block.IterTransforms,
+) error {
+	return i.InitWithValueSchema(r, keyIterator, ValueSchema{}, getLazyValuer, transforms)
+}
+
+// InitWithValueSchema initializes the data block iterator, configuring it to
+// read from the provided reader. If the block was written with a ValueSchema,
+// valueSchema must be the same schema. Decomposed values are reconstituted
+// from the value columns included in transforms.Projection.
+func (i *DataBlockIter) InitWithValueSchema(
+	r *DataBlockReader,
+	keyIterator KeySeeker,
+	valueSchema ValueSchema,
+	getLazyValuer block.GetLazyValueForPrefixAndValueHandler,
+	transforms block.IterTransforms,
 ) error {
 	numRows := int(r.r.header.Rows)
+	valueCols, valueFields, valueBuf := i.valueCols[:0], i.valueFields, i.valueBuf[:0]
+	synthKeyBuf, synthSeekBuf := i.synthKeyBuf[:0], i.synthSeekBuf[:0]
 	*i = DataBlockIter{
 		r:             r,
 		maxRow:        numRows - 1,
@@ -876,6 +1042,18 @@ func (i *DataBlockIter) Init(
 		kvRow:         math.MinInt,
 		kv:            base.InternalKV{},
 		keyIter:       PrefixBytesIter{},
+		valueBuf:      valueBuf,
+		synthetic:     transforms.SyntheticPrefix.IsSet() || transforms.SyntheticSuffix.IsSet(),
+		synthKeyBuf:   synthKeyBuf,
+		synthSeekBuf:  synthSeekBuf,
+	}
+	if r.valueColumnsStart > 0 {
+		var err error
+		i.valueSchema = valueSchema
+		i.valueCols, i.valueFields, err = initValueColumns(r, valueSchema, transforms.Projection, valueCols, valueFields)
+		if err != nil {
+			return err
+		}
 	}
 	if i.transforms.HideObsoletePoints && r.isObsolete.SeekSetBitGE(0) == numRows {
 		// There are no obsolete points in the block; don't bother checking.
@@ -894,9 +1072,70 @@ func (i *DataBlockIter) Init(
 	return i.keySeeker.Init(r)
 }
 
+// initValueColumns initializes readers for the value columns of r that are
+// included in the projection, reusing the provided slices.
+func initValueColumns(
+	r *DataBlockReader,
+	schema ValueSchema,
+	projection []int,
+	cols []valueColumnReader,
+	fields []ValueField,
+) ([]valueColumnReader, []ValueField, error) {
+	if n := int(r.r.header.Columns) - r.valueColumnsStart - 1; n != len(schema.ColumnTypes) {
+		return nil, nil, base.CorruptionErrorf(
+			"pebble: data block has %d value columns; value schema has %d", n, len(schema.ColumnTypes))
+	}
+	addCol := func(field int) error {
+		if field < 0 || field >= len(schema.ColumnTypes) {
+			return errors.Errorf("pebble: projected value column %d out of range [0, %d)", field, len(schema.ColumnTypes))
+		}
+		col := r.valueColumnsStart + 1 + field
+		if t := r.r.DataType(col); t != schema.ColumnTypes[field] {
+			return base.CorruptionErrorf(
+				"pebble: data block value column %d has type %s; value schema has %s", field, t, schema.ColumnTypes[field])
+		}
+		c := valueColumnReader{field: field, dataType: schema.ColumnTypes[field]}
+		switch c.dataType {
+		case DataTypeUint:
+			c.uints = r.r.Uints(col)
+		case DataTypeBytes:
+			c.bytes = r.r.RawBytes(col)
+		case DataTypeBool:
+			c.bools = r.r.Bitmap(col)
+		}
+		cols = append(cols, c)
+		return nil
+	}
+	if projection == nil {
+		for field := range schema.ColumnTypes {
+			if err := addCol(field); err != nil {
+				return nil, nil, err
+			}
+		}
+	} else {
+		for _, field := range projection {
+			if err := addCol(field); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if cap(fields) < len(schema.ColumnTypes) {
+		fields = make([]ValueField, len(schema.ColumnTypes))
+	}
+	fields = fields[:len(schema.ColumnTypes)]
+	clear(fields)
+	return cols, fields, nil
+}
+
 // IsLowerBound implements the block.DataBlockIterator interface.
 func (i *DataBlockIter) IsLowerBound(k []byte) bool {
 	// Note: we ignore HideObsoletePoints, but false negatives are allowed.
+	if i.synthetic {
+		if i.maxRow < 0 {
+			return true
+		}
+		return i.cmp(i.synthesizeSeekKey(0, true /* withPrefix */), k) >= 0
+	}
 	return i.keySeeker.IsLowerBound(k)
 }
 
@@ -905,11 +1144,15 @@ func (i *DataBlockIter) SeekGE(key []byte, flags base.SeekGEFlags) *base.Interna
 	if i.r == nil {
 		return nil
 	}
-	searchDir := int8(0)
-	if flags.TrySeekUsingNext() {
-		searchDir = +1
+	if i.synthetic {
+		i.row = i.seekGESynthetic(key)
+	} else {
+		searchDir := int8(0)
+		if flags.TrySeekUsingNext() {
+			searchDir = +1
+		}
+		i.row = i.keySeeker.SeekGE(key, i.row, searchDir)
 	}
-	i.row = i.keySeeker.SeekGE(key, i.row, searchDir)
 	if i.transforms.HideObsoletePoints {
 		i.nextObsoletePoint = i.r.isObsolete.SeekSetBitGE(i.row)
 		if i.atObsoletePointForward() {
@@ -941,7 +1184,11 @@ func (i *DataBlockIter) SeekLT(key []byte, _ base.SeekLTFlags) *base.InternalKV
 	if i.r == nil {
 		return nil
 	}
-	i.row = i.keySeeker.SeekGE(key, i.row, 0 /* searchDir */) - 1
+	if i.synthetic {
+		i.row = i.seekGESynthetic(key) - 1
+	} else {
+		i.row = i.keySeeker.SeekGE(key, i.row, 0 /* searchDir */) - 1
+	}
 	if i.transforms.HideObsoletePoints {
 		i.nextObsoletePoint = i.r.isObsolete.SeekSetBitGE(max(i.row, 0))
 		if i.atObsoletePointBackward() {
@@ -1015,10 +1262,20 @@ func (i *DataBlockIter) Next() *base.InternalKV {
 	if n := i.transforms.SyntheticSeqNum; n != 0 {
 		i.kv.K.SetSeqNum(base.SeqNum(n))
 	}
-	// Inline i.r.values.At(row).
-	v := i.r.values.slice(i.r.values.offsets.At2(i.row))
+	if i.synthetic {
+		i.kv.K.UserKey = i.synthesizeKey(i.kv.K.UserKey)
+	}
+	// Inline i.r.values.At(row) for the common case of a RawBytes column.
+	var v []byte
+	if !i.r.values.isDictionary {
+		v = i.r.values.raw.slice(i.r.values.raw.offsets.At2(i.row))
+	} else {
+		v = i.r.values.dict.At(i.row)
+	}
 	if i.r.isValueExternal.At(i.row) {
 		i.kv.V = i.getLazyValuer.GetLazyValueForPrefixAndValueHandle(v)
+	} else if i.r.isValueSplit.At(i.row) {
+		i.kv.V = base.MakeInPlaceValue(i.joinValue())
 	} else {
 		i.kv.V = base.MakeInPlaceValue(v)
 	}
@@ -1171,11 +1428,21 @@ func (i *DataBlockIter) decodeRow() *base.InternalKV {
 		if n := i.transforms.SyntheticSeqNum; n != 0 {
 			i.kv.K.SetSeqNum(base.SeqNum(n))
 		}
-		// Inline i.r.values.At(row).
-		startOffset := i.r.values.offsets.At(i.row)
-		v := unsafe.Slice((*byte)(i.r.values.ptr(startOffset)), i.r.values.offsets.At(i.row+1)-startOffset)
+		if i.synthetic {
+			i.kv.K.UserKey = i.synthesizeKey(i.kv.K.UserKey)
+		}
+		// Inline i.r.values.At(row) for the common case of a RawBytes column.
+		var v []byte
+		if !i.r.values.isDictionary {
+			startOffset := i.r.values.raw.offsets.At(i.row)
+			v = unsafe.Slice((*byte)(i.r.values.raw.ptr(startOffset)), i.r.values.raw.offsets.At(i.row+1)-startOffset)
+		} else {
+			v = i.r.values.dict.At(i.row)
+		}
 		if i.r.isValueExternal.At(i.row) {
 			i.kv.V = i.getLazyValuer.GetLazyValueForPrefixAndValueHandle(v)
+		} else if i.r.isValueSplit.At(i.row) {
+			i.kv.V = base.MakeInPlaceValue(i.joinValue())
 		} else {
 			i.kv.V = base.MakeInPlaceValue(v)
 		}
@@ -1184,6 +1451,26 @@ func (i *DataBlockIter) decodeRow() *base.InternalKV {
 	}
 }
 
+// joinValue reconstitutes the decomposed value at i.row from the projected
+// value columns. The returned slice is owned by the iterator and is only valid
+// until the iterator is repositioned.
+func (i *DataBlockIter) joinValue() []byte {
+	for j := range i.valueCols {
+		c := &i.valueCols[j]
+		f := &i.valueFields[c.field]
+		switch c.dataType {
+		case DataTypeUint:
+			f.Uint = c.uints.At(i.row)
+		case DataTypeBytes:
+			f.Bytes = c.bytes.At(i.row)
+		case DataTypeBool:
+			f.Bool = c.bools.At(i.row)
+		}
+	}
+	i.valueBuf = i.valueSchema.Join(i.valueBuf[:0], i.valueFields)
+	return i.valueBuf
+}
+
 // decodeKey updates i.kv.K to the key for i.row (which must be valid).
 // This function does not inline, so we copy its code verbatim. For any updates
 // to this code, all code preceded by "Inline decodeKey" must be updated.
@@ -1195,10 +1482,74 @@ func (i *DataBlockIter) decodeKey() {
 	if n := i.transforms.SyntheticSeqNum; n != 0 {
 		i.kv.K.SetSeqNum(base.SeqNum(n))
 	}
+	if i.synthetic {
+		i.kv.K.UserKey = i.synthesizeKey(i.kv.K.UserKey)
+	}
 }
 
 var _ = (*DataBlockIter).decodeKey
 
+// synthesizeKey returns the provided user key with the synthetic prefix and
+// suffix applied. The returned slice is owned by the iterator and remains
+// valid until the next call to synthesizeKey.
+func (i *DataBlockIter) synthesizeKey(key []byte) []byte {
+	i.synthKeyBuf = i.appendSynthesizedKey(i.synthKeyBuf[:0], key, true /* withPrefix */)
+	return i.synthKeyBuf
+}
+
+func (i *DataBlockIter) appendSynthesizedKey(dst, key []byte, withPrefix bool) []byte {
+	if withPrefix {
+		dst = append(dst, i.transforms.SyntheticPrefix...)
+	}
+	if i.transforms.SyntheticSuffix.IsSet() {
+		dst = append(dst, key[:i.split(key)]...)
+		return append(dst, i.transforms.SyntheticSuffix...)
+	}
+	return append(dst, key...)
+}
+
+// synthesizeSeekKey materializes the user key at the provided row with the
+// synthetic suffix, and optionally the synthetic prefix, applied. It's used to
+// compare a row's key against a seek key. Materializing the key repositions
+// i.keyIter, so i.kv must be decoded anew before it's next returned.
+func (i *DataBlockIter) synthesizeSeekKey(row int, withPrefix bool) []byte {
+	i.kvRow = math.MinInt
+	k := i.keySeeker.MaterializeUserKey(&i.keyIter, math.MinInt, row)
+	i.synthSeekBuf = i.appendSynthesizedKey(i.synthSeekBuf[:0], k, withPrefix)
+	return i.synthSeekBuf
+}
+
+// seekGESynthetic returns the index of the first row with a key, after
+// applying the synthetic prefix and suffix, greater than or equal to [key].
+//
+// Applying a synthetic suffix does not change the relative ordering of keys
+// within a block, because every key within a block with a synthetic suffix
+// has a distinct prefix. A key's suffix replacement may move it across the
+// seek key only if it shares the seek key's prefix, so the row found when
+// seeking among the original keys is off by at most one.
+func (i *DataBlockIter) seekGESynthetic(key []byte) int {
+	if prefix := i.transforms.SyntheticPrefix; prefix.IsSet() {
+		if !bytes.HasPrefix(key, prefix) {
+			// The seek key sorts before or after all of the block's keys, which
+			// all begin with the synthetic prefix.
+			if i.cmp(key, prefix) < 0 {
+				return 0
+			}
+			return i.maxRow + 1
+		}
+		key = key[len(prefix):]
+	}
+	row := i.keySeeker.SeekGE(key, i.row, 0 /* searchDir */)
+	if i.transforms.SyntheticSuffix.IsSet() {
+		if row > 0 && i.cmp(i.synthesizeSeekKey(row-1, false /* withPrefix */), key) >= 0 {
+			row--
+		} else if row <= i.maxRow && i.cmp(i.synthesizeSeekKey(row, false /* withPrefix */), key) < 0 {
+			row++
+		}
+	}
+	return row
+}
+
 // Close implements the base.InternalIterator interface.
 func (i *DataBlockIter) Close() error {
 	i.keySeeker.Release()
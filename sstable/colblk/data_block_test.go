@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -18,11 +19,40 @@ import (
 	"github.com/cockroachdb/pebble/internal/itertest"
 	"github.com/cockroachdb/pebble/internal/testkeys"
 	"github.com/cockroachdb/pebble/sstable/block"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/rand"
 )
 
 var testKeysSchema = DefaultKeySchema(testkeys.Comparer, 16)
 
+// testValueSchema decomposes values of the form <uint>,<bytes>,<bool> (eg,
+// "7,apple,true") into three columns.
+var testValueSchema = ValueSchema{
+	Name:        "test-value-schema",
+	ColumnTypes: []DataType{DataTypeUint, DataTypeBytes, DataTypeBool},
+	Split: func(value []byte, fields []ValueField) bool {
+		parts := bytes.Split(value, []byte(","))
+		if len(parts) != 3 {
+			return false
+		}
+		u, err := strconv.ParseUint(string(parts[0]), 10, 64)
+		if err != nil {
+			return false
+		}
+		b, err := strconv.ParseBool(string(parts[2]))
+		if err != nil {
+			return false
+		}
+		fields[0].Uint, fields[1].Bytes, fields[2].Bool = u, parts[1], b
+		return true
+	},
+	Join: func(dst []byte, fields []ValueField) []byte {
+		dst = strconv.AppendUint(dst, fields[0].Uint, 10)
+		dst = append(append(dst, ','), fields[1].Bytes...)
+		return strconv.AppendBool(append(dst, ','), fields[2].Bool)
+	},
+}
+
 func TestDataBlock(t *testing.T) {
 	var buf bytes.Buffer
 	var w DataBlockWriter
@@ -30,18 +60,23 @@ func TestDataBlock(t *testing.T) {
 	var it DataBlockIter
 	var rw DataBlockRewriter
 	rw.KeySchema = testKeysSchema
+	var valueSchema ValueSchema
 	var sizes []int
 	datadriven.Walk(t, "testdata/data_block", func(t *testing.T, path string) {
 		datadriven.RunTest(t, path, func(t *testing.T, td *datadriven.TestData) string {
 			buf.Reset()
 			switch td.Cmd {
 			case "init":
-				var bundleSize int
-				if td.MaybeScanArgs(t, "bundle-size", &bundleSize) {
-					w.Init(DefaultKeySchema(testkeys.Comparer, bundleSize))
-				} else {
-					w.Init(testKeysSchema)
+				valueSchema = ValueSchema{}
+				if td.HasArg("value-schema") {
+					valueSchema = testValueSchema
 				}
+				bundleSize := 16
+				td.MaybeScanArgs(t, "bundle-size", &bundleSize)
+				w.InitWithValueSchema(DefaultKeySchemaWithOptions(testkeys.Comparer, DefaultKeySchemaOptions{
+					PrefixBundleSize: bundleSize,
+					AdaptiveSuffixes: td.HasArg("adaptive-suffixes"),
+				}), valueSchema)
 				fmt.Fprint(&buf, &w)
 				sizes = sizes[:0]
 				return buf.String()
@@ -49,6 +84,7 @@ func TestDataBlock(t *testing.T) {
 				// write-block does init/write/finish in a single command, and doesn't
 				// print anything.
 				if td.Cmd == "write-block" {
+					valueSchema = ValueSchema{}
 					w.Init(testKeysSchema)
 				}
 				for _, line := range strings.Split(td.Input, "\n") {
@@ -111,9 +147,30 @@ func TestDataBlock(t *testing.T) {
 					SyntheticPrefix:    []byte(syntheticPrefix),
 					SyntheticSuffix:    []byte(syntheticSuffix),
 				}
-				it.Init(&r, testKeysSchema.NewKeySeeker(), getLazyValuer(func([]byte) base.LazyValue {
-					return base.LazyValue{ValueOrHandle: []byte("mock external value")}
-				}), transforms)
+				if arg, ok := td.Arg("projection"); ok {
+					transforms.Projection = []int{}
+					for _, v := range arg.Vals {
+						if v != "" {
+							col, err := strconv.Atoi(v)
+							require.NoError(t, err)
+							transforms.Projection = append(transforms.Projection, col)
+						}
+					}
+				}
+				vs := valueSchema
+				if td.HasArg("no-value-schema") {
+					vs = ValueSchema{}
+				}
+				err := it.InitWithValueSchema(&r, testKeysSchema.NewKeySeeker(), vs,
+					getLazyValuer(func([]byte) base.LazyValue {
+						return base.LazyValue{ValueOrHandle: []byte("mock external value")}
+					}), transforms)
+				if err != nil {
+					return fmt.Sprintf("error: %s", err)
+				}
+				// MultiDataBlockIter.InitHandle configures the comparer used to
+				// apply synthetic prefixes and suffixes.
+				it.cmp, it.split = testkeys.Comparer.Compare, testkeys.Comparer.Split
 				o := []itertest.IterOpt{itertest.ShowCommands}
 				if td.HasArg("verbose") {
 					o = append(o, itertest.Verbose)
@@ -129,6 +186,84 @@ func TestDataBlock(t *testing.T) {
 	})
 }
 
+// TestDataBlockAdaptiveSuffixesRandomized tests seeking within blocks written
+// by a default key schema with adaptive suffixes, using the KeySeeker of a
+// default key schema without them.
+func TestDataBlockAdaptiveSuffixesRandomized(t *testing.T) {
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+
+	schema := DefaultKeySchemaWithOptions(testkeys.Comparer, DefaultKeySchemaOptions{
+		PrefixBundleSize: 16,
+		AdaptiveSuffixes: true,
+	})
+	var w DataBlockWriter
+	w.Init(schema)
+	var r DataBlockReader
+	var it DataBlockIter
+	randKey := func(suffixes int) []byte {
+		k := make([]byte, 1+rng.Intn(4))
+		for i := range k {
+			k[i] = byte('a' + rng.Intn(4))
+		}
+		// Suffix zero is the absence of a suffix.
+		if suffix := rng.Int63n(int64(suffixes)); suffix > 0 {
+			k = append(k, testkeys.Suffix(suffix)...)
+		}
+		return k
+	}
+	for _, suffixes := range []int{1, 3, 50} {
+		t.Run(fmt.Sprintf("suffixes=%d", suffixes), func(t *testing.T) {
+			var dictionaryBlocks int
+			for iter := 0; iter < 20; iter++ {
+				keys := make([][]byte, 1+rng.Intn(200))
+				for i := range keys {
+					keys[i] = randKey(suffixes)
+				}
+				slices.SortFunc(keys, testkeys.Comparer.Compare)
+				keys = slices.CompactFunc(keys, testkeys.Comparer.Equal)
+
+				w.Reset()
+				for _, k := range keys {
+					kcmp := w.KeyWriter.ComparePrev(k)
+					w.Add(base.MakeInternalKey(k, 1, base.InternalKeyKindSet), k,
+						block.InPlaceValuePrefix(kcmp.PrefixEqual()), kcmp, false /* isObsolete */)
+				}
+				finished, _ := w.Finish(w.Rows(), w.Size())
+				r.Init(testKeysSchema, finished)
+				if r.r.DataType(defaultKeySchemaColumnSuffix) == DataTypeDictionaryBytes {
+					dictionaryBlocks++
+				}
+				require.NoError(t, it.Init(&r, testKeysSchema.NewKeySeeker(), nil, block.NoTransforms))
+
+				i := 0
+				for kv := it.First(); kv != nil; kv = it.Next() {
+					require.Equal(t, string(keys[i]), string(kv.K.UserKey))
+					i++
+				}
+				require.Equal(t, len(keys), i)
+				for j := 0; j < 100; j++ {
+					seekKey := randKey(suffixes + 1)
+					i, _ := slices.BinarySearchFunc(keys, seekKey, testkeys.Comparer.Compare)
+					kv := it.SeekGE(seekKey, base.SeekGEFlagsNone)
+					if i == len(keys) {
+						require.Nil(t, kv, "SeekGE(%q)", seekKey)
+					} else {
+						require.NotNil(t, kv, "SeekGE(%q)", seekKey)
+						require.Equal(t, string(keys[i]), string(kv.K.UserKey), "SeekGE(%q)", seekKey)
+					}
+				}
+				require.NoError(t, it.Close())
+			}
+			t.Logf("%d of 20 blocks with dictionary-encoded suffixes", dictionaryBlocks)
+			if suffixes > 1 && suffixes < 50 {
+				require.Positive(t, dictionaryBlocks)
+			}
+		})
+	}
+}
+
 func BenchmarkDataBlockWriter(b *testing.B) {
 	for _, prefixSize := range []int{8, 32, 128} {
 		for _, valueSize := range []int{8, 128, 1024} {
@@ -0,0 +1,287 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package colblk
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cockroachdb/pebble/internal/binfmt"
+)
+
+// DictionaryBytes holds an array of byte slices, each encoded as a code into a
+// dictionary of the distinct byte slices within the array. A dictionary
+// encoding is compact when an array holds many repetitions of few distinct
+// values that are not necessarily adjacent, such as a low-cardinality
+// component of a key that prefix compression encodes poorly.
+//
+// # Representation
+//
+// The dictionary is a RawBytes of the distinct values in the order of their
+// first appearance, preceded by its cardinality. The codes are encoded as a
+// uint column with a row per byte slice.
+//
+//	+-------------------------------------------------------------------+
+//	|                    cardinality (uint32, 4 bytes)                  |
+//	+-------------------------------------------------------------------+
+//	|            dictionary of distinct values (see RawBytes)           |
+//	+-------------------------------------------------------------------+
+//	|       codes, usually encoded with 8-bits (see UintEncoding)       |
+//	+-------------------------------------------------------------------+
+type DictionaryBytes struct {
+	dict  RawBytes
+	codes UnsafeUints
+}
+
+// Assert that DictionaryBytes implements Array[[]byte].
+var _ Array[[]byte] = DictionaryBytes{}
+
+// DecodeDictionaryBytes decodes the structure of a DictionaryBytes,
+// constructing an accessor for an array of byte slices constructed by
+// AdaptiveBytesBuilder. Rows must be the number of byte slices within the
+// array.
+func DecodeDictionaryBytes(
+	b []byte, offset uint32, rows int,
+) (dictBytes DictionaryBytes, endOffset uint32) {
+	if rows == 0 {
+		return DictionaryBytes{}, offset
+	}
+	cardinality := int(binary.LittleEndian.Uint32(b[offset:]))
+	offset += 4
+	dictBytes.dict, offset = DecodeRawBytes(b, offset, cardinality)
+	dictBytes.codes, offset = DecodeUnsafeUints(b, offset, rows)
+	return dictBytes, offset
+}
+
+// Assert that DecodeDictionaryBytes implements DecodeFunc.
+var _ DecodeFunc[DictionaryBytes] = DecodeDictionaryBytes
+
+// At returns the []byte at index i. The returned slice should not be mutated.
+func (d DictionaryBytes) At(i int) []byte {
+	return d.dict.At(int(d.codes.At(i)))
+}
+
+// Code returns the dictionary code of the byte slice at index i. Two rows hold
+// equal byte slices iff they have equal codes.
+func (d DictionaryBytes) Code(i int) int {
+	return int(d.codes.At(i))
+}
+
+// Cardinality returns the number of distinct byte slices within the array.
+func (d DictionaryBytes) Cardinality() int {
+	return d.dict.slices
+}
+
+// Dictionary returns the dictionary of distinct byte slices, indexed by code.
+func (d DictionaryBytes) Dictionary() RawBytes {
+	return d.dict
+}
+
+func dictionaryBytesToBinFormatter(f *binfmt.Formatter, rows int) {
+	if rows == 0 {
+		return
+	}
+	cardinality := int(f.PeekUint(4))
+	f.CommentLine("dictionary bytes")
+	f.HexBytesln(4, "cardinality: %d", cardinality)
+	f.CommentLine("dictionary")
+	rawBytesToBinFormatter(f, cardinality, nil)
+	f.CommentLine("codes")
+	uintsToBinFormatter(f, rows, nil)
+}
+
+// maxDictionaryBytesCardinality is the cardinality beyond which an
+// AdaptiveBytesBuilder abandons a dictionary encoding, unless at least two rows
+// have been put for every distinct value. It bounds the cost of maintaining a
+// dictionary for high-cardinality columns.
+const maxDictionaryBytesCardinality = 32
+
+// AdaptiveBytesBuilder encodes a column of byte slices, choosing per column
+// between a RawBytes encoding and a DictionaryBytes encoding, whichever is
+// smaller. The resulting column may be read using an AdaptiveBytes.
+type AdaptiveBytesBuilder struct {
+	raw RawBytesBuilder
+	// The dictionary encoding is maintained as byte slices are put, until the
+	// column's cardinality proves too high for a dictionary encoding to be
+	// worthwhile.
+	dict struct {
+		abandoned bool
+		// rows is the number of rows covered by the dictionary encoding. It
+		// stops growing once the encoding is abandoned, so that the first
+		// Rows()-1 rows may still be finished using the dictionary.
+		rows   int
+		codes  map[string]uint32
+		values RawBytesBuilder
+		// firstRows holds the row at which each distinct value first appeared,
+		// indexed by code.
+		firstRows []int
+		rowCodes  UintBuilder
+	}
+	// isDictionary is true if the most recent call to Finish used a
+	// dictionary encoding.
+	isDictionary bool
+}
+
+// Assert that *AdaptiveBytesBuilder implements ColumnWriter.
+var _ ColumnWriter = (*AdaptiveBytesBuilder)(nil)
+
+// Init initializes the builder for first-time use.
+func (b *AdaptiveBytesBuilder) Init() {
+	b.raw.Init()
+	b.dict.codes = make(map[string]uint32)
+	b.dict.values.Init()
+	b.dict.rowCodes.Init()
+	b.Reset()
+}
+
+// Reset resets the builder to an empty state.
+func (b *AdaptiveBytesBuilder) Reset() {
+	b.raw.Reset()
+	b.dict.abandoned = false
+	b.dict.rows = 0
+	clear(b.dict.codes)
+	b.dict.values.Reset()
+	b.dict.firstRows = b.dict.firstRows[:0]
+	b.dict.rowCodes.Reset()
+	b.isDictionary = false
+}
+
+// NumColumns implements ColumnWriter.
+func (b *AdaptiveBytesBuilder) NumColumns() int { return 1 }
+
+// DataType implements ColumnWriter.
+func (b *AdaptiveBytesBuilder) DataType(int) DataType {
+	if b.isDictionary {
+		return DataTypeDictionaryBytes
+	}
+	return DataTypeBytes
+}
+
+// Put appends the provided byte slice to the builder.
+func (b *AdaptiveBytesBuilder) Put(s []byte) {
+	b.raw.Put(s)
+	b.putDictionary()
+}
+
+// PutConcat appends a single byte slice formed by the concatenation of the two
+// byte slice arguments.
+func (b *AdaptiveBytesBuilder) PutConcat(s1, s2 []byte) {
+	b.raw.PutConcat(s1, s2)
+	b.putDictionary()
+}
+
+// putDictionary adds the most recently put byte slice to the dictionary
+// encoding.
+func (b *AdaptiveBytesBuilder) putDictionary() {
+	if b.dict.abandoned {
+		return
+	}
+	row := b.raw.Rows() - 1
+	v := b.raw.UnsafeGet(row)
+	code, ok := b.dict.codes[string(v)]
+	if !ok {
+		cardinality := len(b.dict.firstRows)
+		if cardinality >= maxDictionaryBytesCardinality && 2*(cardinality+1) > row+1 {
+			b.dict.abandoned = true
+			return
+		}
+		code = uint32(cardinality)
+		b.dict.codes[string(v)] = code
+		b.dict.values.Put(v)
+		b.dict.firstRows = append(b.dict.firstRows, row)
+	}
+	b.dict.rowCodes.Set(row, uint64(code))
+	b.dict.rows = row + 1
+}
+
+// Rows returns the count of slices that have been added to the builder.
+func (b *AdaptiveBytesBuilder) Rows() int {
+	return b.raw.Rows()
+}
+
+// UnsafeGet returns the i'th slice added to the builder. The returned slice is
+// owned by the builder and must not be mutated.
+func (b *AdaptiveBytesBuilder) UnsafeGet(i int) []byte {
+	return b.raw.UnsafeGet(i)
+}
+
+// cardinality returns the number of distinct values within the first [rows]
+// rows, if the dictionary encoding covers them.
+func (b *AdaptiveBytesBuilder) cardinality(rows int) int {
+	return sort.SearchInts(b.dict.firstRows, rows)
+}
+
+// useDictionary returns true if the first [rows] rows should be encoded using
+// a dictionary encoding.
+func (b *AdaptiveBytesBuilder) useDictionary(rows int) bool {
+	if rows == 0 || rows > b.dict.rows {
+		return false
+	}
+	return b.dictionarySize(rows, 0) < b.raw.Size(rows, 0)
+}
+
+func (b *AdaptiveBytesBuilder) dictionarySize(rows int, offset uint32) uint32 {
+	offset += 4 // cardinality
+	offset = b.dict.values.Size(b.cardinality(rows), offset)
+	return b.dict.rowCodes.Size(rows, offset)
+}
+
+// Size implements ColumnWriter.
+func (b *AdaptiveBytesBuilder) Size(rows int, offset uint32) uint32 {
+	if b.useDictionary(rows) {
+		return b.dictionarySize(rows, offset)
+	}
+	return b.raw.Size(rows, offset)
+}
+
+// Finish implements ColumnWriter, serializing the first [rows] rows using
+// whichever encoding is smaller.
+func (b *AdaptiveBytesBuilder) Finish(col, rows int, offset uint32, buf []byte) uint32 {
+	b.isDictionary = b.useDictionary(rows)
+	if !b.isDictionary {
+		return b.raw.Finish(col, rows, offset, buf)
+	}
+	cardinality := b.cardinality(rows)
+	binary.LittleEndian.PutUint32(buf[offset:], uint32(cardinality))
+	offset += 4
+	offset = b.dict.values.Finish(0, cardinality, offset, buf)
+	return b.dict.rowCodes.Finish(0, rows, offset, buf)
+}
+
+// WriteDebug implements Encoder.
+func (b *AdaptiveBytesBuilder) WriteDebug(w io.Writer, rows int) {
+	b.raw.WriteDebug(w, rows)
+	if b.useDictionary(rows) {
+		fmt.Fprintf(w, "; dictionary of %d values", b.cardinality(rows))
+	}
+}
+
+// AdaptiveBytes holds an array of byte slices written by an
+// AdaptiveBytesBuilder, encoded as either a RawBytes or a DictionaryBytes.
+type AdaptiveBytes struct {
+	raw          RawBytes
+	dict         DictionaryBytes
+	isDictionary bool
+}
+
+// Assert that AdaptiveBytes implements Array[[]byte].
+var _ Array[[]byte] = AdaptiveBytes{}
+
+// At returns the []byte at index i. The returned slice should not be mutated.
+func (b AdaptiveBytes) At(i int) []byte {
+	if b.isDictionary {
+		return b.dict.At(i)
+	}
+	return b.raw.At(i)
+}
+
+// DictionaryBytes returns the array's dictionary encoding, and true, if the
+// array is dictionary encoded. Callers that compare or aggregate rows may use
+// the dictionary's codes to avoid comparing byte slices.
+func (b AdaptiveBytes) DictionaryBytes() (DictionaryBytes, bool) {
+	return b.dict, b.isDictionary
+}
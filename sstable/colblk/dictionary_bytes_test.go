@@ -0,0 +1,81 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package colblk
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/internal/aligned"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+)
+
+func TestAdaptiveBytesBuilderRandomized(t *testing.T) {
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+
+	var b AdaptiveBytesBuilder
+	b.Init()
+	for _, cardinality := range []int{1, 2, 10, 40, 200} {
+		t.Run(fmt.Sprintf("cardinality=%d", cardinality), func(t *testing.T) {
+			distinct := make([][]byte, cardinality)
+			for i := range distinct {
+				distinct[i] = make([]byte, rng.Intn(30))
+				rng.Read(distinct[i])
+			}
+			for iter := 0; iter < 20; iter++ {
+				b.Reset()
+				n := 1 + rng.Intn(300)
+				offset := uint32(rng.Intn(8))
+				values := make([][]byte, n)
+				var sizeBeforeLast uint32
+				for i := range values {
+					if i == n-1 {
+						sizeBeforeLast = b.Size(i, offset)
+					}
+					values[i] = distinct[rng.Intn(cardinality)]
+					b.Put(values[i])
+				}
+				// Finish may be called with one less row than has been put, in
+				// which case the size must be unaffected by the last row.
+				rows := n - rng.Intn(2)
+				size := b.Size(rows, offset)
+				if rows == n-1 {
+					require.Equal(t, sizeBeforeLast, size)
+				}
+				buf := aligned.ByteSlice(int(size) + 1)
+				require.Equal(t, size, b.Finish(0, rows, offset, buf))
+
+				var got AdaptiveBytes
+				var endOffset uint32
+				switch b.DataType(0) {
+				case DataTypeDictionaryBytes:
+					got.isDictionary = true
+					got.dict, endOffset = DecodeDictionaryBytes(buf, offset, rows)
+					distinctRows := make(map[string]bool)
+					for _, v := range values[:rows] {
+						distinctRows[string(v)] = true
+					}
+					require.Equal(t, len(distinctRows), got.dict.Cardinality())
+				case DataTypeBytes:
+					got.raw, endOffset = DecodeRawBytes(buf, offset, rows)
+				default:
+					t.Fatalf("unexpected data type %s", b.DataType(0))
+				}
+				require.Equal(t, size, endOffset)
+				require.Equal(t, values[:rows], Clone(got, rows))
+				// A dictionary of a single value is smaller than the raw
+				// bytes once the value is repeated, unless the value is too
+				// short for the repetitions to outweigh the dictionary's codes.
+				if cardinality == 1 && rows > 8 && len(distinct[0]) >= 8 {
+					require.Equal(t, DataTypeDictionaryBytes, b.DataType(0))
+				}
+			}
+		})
+	}
+}
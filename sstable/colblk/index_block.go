@@ -5,6 +5,8 @@
 package colblk
 
 import (
+	"bytes"
+
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/binfmt"
@@ -177,10 +179,17 @@ func (r *IndexReader) Describe(f *binfmt.Formatter) {
 // IndexIter is an iterator over the block entries in an index block.
 type IndexIter struct {
 	compare base.Compare
+	split   base.Split
 	r       *IndexReader
 	n       int
 	row     int
 
+	syntheticPrefix block.SyntheticPrefix
+	syntheticSuffix block.SyntheticSuffix
+	// sepBuf is used to materialize separators when a synthetic prefix or
+	// suffix is set.
+	sepBuf []byte
+
 	h           block.BufferHandle
 	allocReader IndexReader
 }
@@ -194,20 +203,29 @@ func (i *IndexIter) InitReader(compare base.Compare, r *IndexReader) {
 		compare:     compare,
 		r:           r,
 		n:           int(r.br.header.Rows),
+		sepBuf:      i.sepBuf,
 		h:           i.h,
 		allocReader: i.allocReader,
 	}
 }
 
+// applyTransforms configures the iterator to apply the provided transforms
+// to separators.
+func (i *IndexIter) applyTransforms(split base.Split, transforms block.IterTransforms) {
+	i.split = split
+	i.syntheticPrefix = transforms.SyntheticPrefix
+	i.syntheticSuffix = transforms.SyntheticSuffix
+}
+
 // Init initializes an iterator from the provided block data slice.
 func (i *IndexIter) Init(
 	cmp base.Compare, split base.Split, blk []byte, transforms block.IterTransforms,
 ) error {
 	i.h.Release()
 	i.h = block.BufferHandle{}
-	// TODO(jackson): Handle the transforms.
 	i.allocReader.Init(blk)
 	i.InitReader(cmp, &i.allocReader)
+	i.applyTransforms(split, transforms)
 	return nil
 }
 
@@ -215,8 +233,6 @@ func (i *IndexIter) Init(
 func (i *IndexIter) InitHandle(
 	cmp base.Compare, split base.Split, blk block.BufferHandle, transforms block.IterTransforms,
 ) error {
-	// TODO(jackson): Handle the transforms.
-
 	// TODO(jackson): If block.h != nil, use a *IndexReader that's allocated
 	// when the block is loaded into the block cache. On cache hits, this will
 	// reduce the amount of setup necessary to use an iterator. (It's relatively
@@ -226,6 +242,7 @@ func (i *IndexIter) InitHandle(
 	i.h = blk
 	i.allocReader.Init(i.h.Get())
 	i.InitReader(cmp, &i.allocReader)
+	i.applyTransforms(split, transforms)
 	return nil
 }
 
@@ -272,7 +289,23 @@ func (i *IndexIter) Handle() block.BufferHandle {
 // Separator returns the separator at the iterator's current position. The
 // iterator must be positioned at a valid row.
 func (i *IndexIter) Separator() []byte {
-	return i.r.separators.At(i.row)
+	sep := i.r.separators.At(i.row)
+	if !i.syntheticPrefix.IsSet() && !i.syntheticSuffix.IsSet() {
+		return sep
+	}
+	i.sepBuf = append(i.sepBuf[:0], i.syntheticPrefix...)
+	i.sepBuf = i.appendSuffixed(i.sepBuf, sep)
+	return i.sepBuf
+}
+
+// appendSuffixed appends sep to dst, replacing its suffix with the synthetic
+// suffix if one is set.
+func (i *IndexIter) appendSuffixed(dst, sep []byte) []byte {
+	if !i.syntheticSuffix.IsSet() {
+		return append(dst, sep...)
+	}
+	dst = append(dst, sep[:i.split(sep)]...)
+	return append(dst, i.syntheticSuffix...)
 }
 
 // BlockHandleWithProperties decodes the block handle with any encoded
@@ -291,6 +324,19 @@ func (i *IndexIter) BlockHandleWithProperties() (block.HandleWithProperties, err
 // greater or equal to the given key. It returns false if the seek key is
 // greater than all index block separators.
 func (i *IndexIter) SeekGE(key []byte) bool {
+	if i.syntheticPrefix.IsSet() {
+		searchKey, ok := bytes.CutPrefix(key, i.syntheticPrefix)
+		if !ok {
+			// The seek key is before or after the entire block, all of whose
+			// separators begin with the synthetic prefix.
+			if i.compare(key, i.syntheticPrefix) < 0 {
+				return i.First()
+			}
+			i.row = i.n
+			return false
+		}
+		key = searchKey
+	}
 	// Define f(-1) == false and f(upper) == true.
 	// Invariant: f(index-1) == false, f(upper) == true.
 	index, upper := 0, i.n
@@ -300,7 +346,12 @@ func (i *IndexIter) SeekGE(key []byte) bool {
 
 		// TODO(jackson): Is Bytes.At or Bytes.Slice(Bytes.Offset(h),
 		// Bytes.Offset(h+1)) faster in this code?
-		c := i.compare(key, i.r.separators.At(h))
+		sep := i.r.separators.At(h)
+		if i.syntheticSuffix.IsSet() {
+			i.sepBuf = i.appendSuffixed(i.sepBuf[:0], sep)
+			sep = i.sepBuf
+		}
+		c := i.compare(key, sep)
 		if c > 0 {
 			index = h + 1 // preserves f(index-1) == false
 		} else {
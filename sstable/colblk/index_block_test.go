@@ -54,6 +54,14 @@ func TestIndexBlock(t *testing.T) {
 		case "iter":
 			var it IndexIter
 			it.InitReader(testkeys.Comparer.Compare, &r)
+			var syntheticPrefix, syntheticSuffix string
+			d.MaybeScanArgs(t, "synthetic-prefix", &syntheticPrefix)
+			d.MaybeScanArgs(t, "synthetic-suffix", &syntheticSuffix)
+			transforms := block.IterTransforms{
+				SyntheticPrefix: []byte(syntheticPrefix),
+				SyntheticSuffix: []byte(syntheticSuffix),
+			}
+			it.applyTransforms(testkeys.Comparer.Split, transforms)
 			for _, line := range strings.Split(d.Input, "\n") {
 				fields := strings.Fields(line)
 				var valid bool
@@ -86,7 +94,11 @@ func TestIndexBlock(t *testing.T) {
 					if len(bhp.Props) > 0 {
 						bp = fmt.Sprintf(" props=%q", bhp.Props)
 					}
-					fmt.Fprintf(&buf, "block %d: %d-%d%s\n", it.row, bhp.Offset, bhp.Offset+bhp.Length, bp)
+					var sep string
+					if transforms.SyntheticPrefix.IsSet() || transforms.SyntheticSuffix.IsSet() {
+						sep = fmt.Sprintf(" sep=%q", it.Separator())
+					}
+					fmt.Fprintf(&buf, "block %d: %d-%d%s%s\n", it.row, bhp.Offset, bhp.Offset+bhp.Length, bp, sep)
 				} else {
 					fmt.Fprintln(&buf, ".")
 				}
@@ -113,7 +125,7 @@ func TestIndexIterInitHandle(t *testing.T) {
 	defer c.Unref()
 	v := block.Alloc(len(b), nil)
 	copy(v.Get(), b)
-	v.MakeHandle(c, cache.ID(1), base.DiskFileNum(1), 0).Release()
+	v.MakeHandle(c, cache.ID(1), base.DiskFileNum(1), 0, cache.NormalPriority).Release()
 
 	getBlockAndIterate := func(it *IndexIter) {
 		h := c.Get(cache.ID(1), base.DiskFileNum(1), 0)
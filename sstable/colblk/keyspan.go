@@ -368,6 +368,10 @@ type keyspanIter struct {
 	//   i.r.userKeys.At(i.startBoundIndex+1)
 	startBoundIndex int
 	keyBuf          [2]keyspan.Key
+	// startKeyBuf and endKeyBuf are used to materialize the span's bounds when
+	// a synthetic prefix is set.
+	startKeyBuf []byte
+	endKeyBuf   []byte
 }
 
 // Assert that KeyspanIter implements the FragmentIterator interface.
@@ -392,6 +396,18 @@ func (i *keyspanIter) init(
 // or equal to the given key. This is equivalent to seeking to the first
 // span with an end key greater than the given key.
 func (i *keyspanIter) SeekGE(key []byte) (*keyspan.Span, error) {
+	if i.transforms.SyntheticPrefix.IsSet() {
+		searchKey, ok := bytes.CutPrefix(key, i.transforms.SyntheticPrefix)
+		if !ok {
+			// The seek key is before or after all the spans in the block, all
+			// of which begin with the synthetic prefix.
+			if i.cmp(key, i.transforms.SyntheticPrefix) < 0 {
+				return i.First()
+			}
+			return i.gatherKeysForward(int(i.r.boundaryKeysCount) - 1), nil
+		}
+		key = searchKey
+	}
 	// Seek among the boundary keys.
 	j, eq := i.r.searchBoundaryKeys(i.cmp, key)
 	// If the found boundary key does not exactly equal the given key, it's
@@ -407,18 +423,26 @@ func (i *keyspanIter) SeekGE(key []byte) (*keyspan.Span, error) {
 // given key. This is equivalent to seeking to the last span with a start
 // key less than the given key.
 func (i *keyspanIter) SeekLT(key []byte) (*keyspan.Span, error) {
-	// Seek among the boundary keys.
-	j, eq := i.r.searchBoundaryKeys(i.cmp, key)
-	// If eq is true, the found boundary key exactly equals the given key. A
-	// span that starts at exactly [key] does not contain any keys strictly less
-	// than [key], so back up one index.
-	if eq {
-		j--
+	if i.transforms.SyntheticPrefix.IsSet() {
+		searchKey, ok := bytes.CutPrefix(key, i.transforms.SyntheticPrefix)
+		if !ok {
+			// The seek key is before or after all the spans in the block, all
+			// of which begin with the synthetic prefix.
+			if i.cmp(key, i.transforms.SyntheticPrefix) < 0 {
+				return i.gatherKeysBackward(-1), nil
+			}
+			return i.Last()
+		}
+		key = searchKey
 	}
-	// If all boundaries are less than [key], or only the last boundary is
-	// greater than the key, then we want the last span so we clamp the index to
-	// the second to last boundary.
-	return i.gatherKeysBackward(min(j, int(i.r.boundaryKeysCount)-2)), nil
+	// Seek among the boundary keys. The found boundary key is greater than or
+	// equal to [key], so a span that starts at it does not contain any keys
+	// strictly less than [key]. Back up one index to the span that ends at the
+	// found boundary key.
+	j, _ := i.r.searchBoundaryKeys(i.cmp, key)
+	// If all boundaries are less than [key], then we want the last span so we
+	// clamp the index to the second to last boundary.
+	return i.gatherKeysBackward(min(j-1, int(i.r.boundaryKeysCount)-2)), nil
 }
 
 // First moves the iterator to the first span.
@@ -499,8 +523,6 @@ func (i *keyspanIter) isNonemptySpan(startBoundIndex int) bool {
 // materializeSpan constructs the current span from i.startBoundIndex and
 // i.{start,end}KeyIndex.
 func (i *keyspanIter) materializeSpan() *keyspan.Span {
-	// TODO(jackson): Apply i.transforms.
-
 	i.span = keyspan.Span{
 		Start: i.r.boundaryKeys.At(i.startBoundIndex),
 		End:   i.r.boundaryKeys.At(i.startBoundIndex + 1),
@@ -518,9 +540,45 @@ func (i *keyspanIter) materializeSpan() *keyspan.Span {
 			Value:   i.r.values.At(int(j)),
 		})
 	}
+	i.applyTransforms()
 	return &i.span
 }
 
+// applyTransforms applies i.transforms to the materialized span.
+func (i *keyspanIter) applyTransforms() {
+	if i.transforms.SyntheticSeqNum != block.NoSyntheticSeqNum {
+		for j := range i.span.Keys {
+			k := &i.span.Keys[j]
+			k.Trailer = base.MakeTrailer(base.SeqNum(i.transforms.SyntheticSeqNum), k.Kind())
+		}
+	}
+	if i.transforms.ElideSameSeqNum && len(i.span.Keys) > 0 {
+		lastSeqNum := i.span.Keys[0].SeqNum()
+		k := 1
+		for j := 1; j < len(i.span.Keys); j++ {
+			if lastSeqNum != i.span.Keys[j].SeqNum() {
+				lastSeqNum = i.span.Keys[j].SeqNum()
+				i.span.Keys[k] = i.span.Keys[j]
+				k++
+			}
+		}
+		i.span.Keys = i.span.Keys[:k]
+	}
+	if i.transforms.SyntheticPrefix.IsSet() {
+		i.startKeyBuf = append(append(i.startKeyBuf[:0], i.transforms.SyntheticPrefix...), i.span.Start...)
+		i.endKeyBuf = append(append(i.endKeyBuf[:0], i.transforms.SyntheticPrefix...), i.span.End...)
+		i.span.Start, i.span.End = i.startKeyBuf, i.endKeyBuf
+	}
+	if i.transforms.SyntheticSuffix.IsSet() {
+		for j := range i.span.Keys {
+			k := &i.span.Keys[j]
+			if k.Kind() == base.InternalKeyKindRangeKeySet && len(k.Suffix) > 0 {
+				k.Suffix = i.transforms.SyntheticSuffix
+			}
+		}
+	}
+}
+
 // Close closes the iterator.
 func (i *keyspanIter) Close() {
 	*i = keyspanIter{}
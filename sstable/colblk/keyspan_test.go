@@ -52,8 +52,19 @@ func TestKeyspanBlock(t *testing.T) {
 			fmt.Fprint(&buf, kr.DebugString())
 			return buf.String()
 		case "iter":
+			var seqNum uint64
+			var syntheticPrefix, syntheticSuffix string
+			td.MaybeScanArgs(t, "synthetic-seq-num", &seqNum)
+			td.MaybeScanArgs(t, "synthetic-prefix", &syntheticPrefix)
+			td.MaybeScanArgs(t, "synthetic-suffix", &syntheticSuffix)
+			transforms := block.FragmentIterTransforms{
+				SyntheticSeqNum: block.SyntheticSeqNum(seqNum),
+				ElideSameSeqNum: td.HasArg("elide-same-seq-num"),
+				SyntheticPrefix: []byte(syntheticPrefix),
+				SyntheticSuffix: []byte(syntheticSuffix),
+			}
 			var iter keyspanIter
-			iter.init(base.DefaultComparer.Compare, &kr, block.NoFragmentTransforms)
+			iter.init(testkeys.Comparer.Compare, &kr, transforms)
 			return keyspan.RunFragmentIteratorCmd(&iter, td.Input, nil)
 		default:
 			return fmt.Sprintf("unknown command: %s", td.Cmd)
@@ -76,7 +87,7 @@ func TestKeyspanBlockPooling(t *testing.T) {
 	defer c.Unref()
 	v := block.Alloc(len(b), nil)
 	copy(v.Get(), b)
-	v.MakeHandle(c, cache.ID(1), base.DiskFileNum(1), 0).Release()
+	v.MakeHandle(c, cache.ID(1), base.DiskFileNum(1), 0, cache.NormalPriority).Release()
 
 	getBlockAndIterate := func() {
 		h := c.Get(cache.ID(1), base.DiskFileNum(1), 0)
@@ -110,6 +121,66 @@ func TestKeyspanBlockPooling(t *testing.T) {
 	wg.Wait()
 }
 
+// TestKeyspanBlockSeekRandomized compares seeks within a keyspan block against
+// seeks among the same spans held in memory.
+func TestKeyspanBlockSeekRandomized(t *testing.T) {
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+
+	key := func(i int) []byte { return []byte(fmt.Sprintf("%03d", i)) }
+	var w KeyspanBlockWriter
+	var kr KeyspanReader
+	for iter := 0; iter < 50; iter++ {
+		// Build fragmented spans over the keys [0, 100), with gaps between some
+		// of them.
+		var spans []keyspan.Span
+		for start := rng.Intn(5); start < 100; {
+			end := start + 1 + rng.Intn(10)
+			spans = append(spans, keyspan.Span{
+				Start: key(start),
+				End:   key(end),
+				Keys:  []keyspan.Key{{Trailer: base.MakeTrailer(base.SeqNum(start), base.InternalKeyKindRangeDelete)}},
+			})
+			start = end + rng.Intn(3)
+		}
+		w.Init(bytes.Equal)
+		for _, s := range spans {
+			w.AddSpan(s)
+		}
+		kr.Init(w.Finish())
+		var it keyspanIter
+		it.init(base.DefaultComparer.Compare, &kr, block.NoFragmentTransforms)
+		expected := keyspan.NewIter(base.DefaultComparer.Compare, spans)
+
+		for j := 0; j < 100; j++ {
+			k := key(rng.Intn(110))
+			if rng.Intn(2) == 0 {
+				// Seek to a key between the keys of the spans' bounds.
+				k = append(k, '5')
+			}
+			got, err := it.SeekGE(k)
+			require.NoError(t, err)
+			want, err := expected.SeekGE(k)
+			require.NoError(t, err)
+			require.Equal(t, spanString(want), spanString(got), "SeekGE(%q)", k)
+
+			got, err = it.SeekLT(k)
+			require.NoError(t, err)
+			want, err = expected.SeekLT(k)
+			require.NoError(t, err)
+			require.Equal(t, spanString(want), spanString(got), "SeekLT(%q)", k)
+		}
+	}
+}
+
+func spanString(s *keyspan.Span) string {
+	if s == nil {
+		return "."
+	}
+	return s.String()
+}
+
 func BenchmarkKeyspanBlock_RangeDeletions(b *testing.B) {
 	for _, numSpans := range []int{1, 10, 100} {
 		for _, keysPerSpan := range []int{1, 2, 5} {
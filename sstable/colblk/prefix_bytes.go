@@ -882,8 +882,14 @@ func (b *PrefixBytesBuilder) UnsafeGet(i int) []byte {
 		return b.data[len(b.data)-b.sizings[i&1].lastKeyLen:]
 	case b.nKeys - 2:
 		// Check if the very last key is a duplicate of the second-to-last key.
+		// A duplicate key is never the first key of a bundle, and its offset
+		// is a copy of the preceding offset. Note that the offset following
+		// the last row may not have been written yet if the last row
+		// completes a bundle, so we compare against the preceding offset
+		// rather than the next one.
 		lastKeyLen := b.sizings[(i+1)&1].lastKeyLen
-		if b.offsets.elems.At(b.rowSuffixIndex(i+1)) == b.offsets.elems.At(b.rowSuffixIndex(i+2)) {
+		if (i+1)&(b.bundleSize-1) != 0 &&
+			b.offsets.elems.At(b.offsets.count-1) == b.offsets.elems.At(b.offsets.count-2) {
 			return b.data[len(b.data)-b.sizings[i&1].lastKeyLen:]
 		}
 		lastLastKeyLen := b.sizings[i&1].lastKeyLen
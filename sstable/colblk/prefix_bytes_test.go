@@ -335,3 +335,38 @@ func wrapStr(w io.Writer, s string, width int) {
 		}
 	}
 }
+
+// TestPrefixBytesBuilderUnsafeGetDuplicates tests retrieving the last two keys
+// put to a PrefixBytesBuilder through UnsafeGet, when keys are often
+// duplicates of their preceding keys.
+func TestPrefixBytesBuilderUnsafeGetDuplicates(t *testing.T) {
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+
+	var pbb PrefixBytesBuilder
+	for _, bundleSize := range []int{1, 2, 4, 16} {
+		t.Run(fmt.Sprintf("bundleSize=%d", bundleSize), func(t *testing.T) {
+			for iter := 0; iter < 20; iter++ {
+				pbb.Init(bundleSize)
+				var keys [][]byte
+				for i := 0; i < 100; i++ {
+					k := []byte(fmt.Sprintf("key%03d", i))
+					// Put each key between one and three times.
+					for n := 1 + rng.Intn(3); n > 0; n-- {
+						shared := 0
+						if len(keys) > 0 {
+							shared = crbytes.CommonPrefix(keys[len(keys)-1], k)
+						}
+						pbb.Put(k, shared)
+						keys = append(keys, k)
+						require.Equal(t, string(keys[len(keys)-1]), string(pbb.UnsafeGet(len(keys)-1)))
+						if len(keys) > 1 {
+							require.Equal(t, string(keys[len(keys)-2]), string(pbb.UnsafeGet(len(keys)-2)))
+						}
+					}
+				}
+			}
+		})
+	}
+}
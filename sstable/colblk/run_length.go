@@ -0,0 +1,508 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package colblk
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble/internal/binfmt"
+	"github.com/cockroachdb/pebble/internal/invariants"
+)
+
+// RunLengthUints holds an array of unsigned integers encoded as runs of equal
+// values. A run-length encoding is compact when an array holds long runs of
+// equal adjacent values, such as a low-cardinality component of sorted keys.
+//
+// # Representation
+//
+// The array is encoded as the number of runs, followed by a uint column of the
+// exclusive end row of each run and a uint column of each run's value.
+//
+//	+-------------------------------------------------------------------+
+//	|                        runs (uint32, 4 bytes)                     |
+//	+-------------------------------------------------------------------+
+//	|             run end rows (exclusive; see UintEncoding)            |
+//	+-------------------------------------------------------------------+
+//	|                  run values (see UintEncoding)                    |
+//	+-------------------------------------------------------------------+
+type RunLengthUints struct {
+	runs   int
+	ends   UnsafeUints
+	values UnsafeUints
+}
+
+// Assert that RunLengthUints implements Array[uint64].
+var _ Array[uint64] = RunLengthUints{}
+
+// DecodeRunLengthUints decodes the structure of a RunLengthUints, constructing
+// an accessor for an array of uints constructed by AdaptiveUintBuilder. Rows
+// must be the number of uints within the array.
+func DecodeRunLengthUints(
+	b []byte, offset uint32, rows int,
+) (runLengthUints RunLengthUints, endOffset uint32) {
+	if rows == 0 {
+		return RunLengthUints{}, offset
+	}
+	runLengthUints.runs = int(binary.LittleEndian.Uint32(b[offset:]))
+	offset += 4
+	runLengthUints.ends, offset = DecodeUnsafeUints(b, offset, runLengthUints.runs)
+	runLengthUints.values, offset = DecodeUnsafeUints(b, offset, runLengthUints.runs)
+	return runLengthUints, offset
+}
+
+// Assert that DecodeRunLengthUints implements DecodeFunc.
+var _ DecodeFunc[RunLengthUints] = DecodeRunLengthUints
+
+// At returns the i'th uint.
+func (u RunLengthUints) At(i int) uint64 {
+	return u.values.At(u.Run(i))
+}
+
+// Runs returns the number of runs within the array.
+func (u RunLengthUints) Runs() int {
+	return u.runs
+}
+
+// Run returns the index of the run containing the i'th row.
+func (u RunLengthUints) Run(i int) int {
+	return searchRunEnds(u.ends, u.runs, i)
+}
+
+// RunEnd returns the exclusive end row of the j'th run. The j'th run begins at
+// the end of the (j-1)'th run, or row zero.
+func (u RunLengthUints) RunEnd(j int) int {
+	return int(u.ends.At(j))
+}
+
+// RunValue returns the value of every row of the j'th run.
+func (u RunLengthUints) RunValue(j int) uint64 {
+	return u.values.At(j)
+}
+
+// searchRunEnds returns the index of the run containing the i'th row, given
+// the exclusive end rows of the runs.
+func searchRunEnds(ends UnsafeUints, runs int, i int) int {
+	return sort.Search(runs, func(j int) bool { return ends.At(j) > uint64(i) })
+}
+
+func runLengthUintsToBinFormatter(f *binfmt.Formatter, rows int) {
+	if rows == 0 {
+		return
+	}
+	runs := int(f.PeekUint(4))
+	f.CommentLine("run-length uints")
+	f.HexBytesln(4, "runs: %d", runs)
+	f.CommentLine("run ends")
+	uintsToBinFormatter(f, runs, nil)
+	f.CommentLine("run values")
+	uintsToBinFormatter(f, runs, nil)
+}
+
+// AdaptiveUintBuilder builds a column of unsigned integers, choosing per
+// column between the encoding of a UintBuilder and a run-length encoding,
+// whichever is smaller. Rows must be set in order. The resulting column may be
+// read using an AdaptiveUints.
+type AdaptiveUintBuilder struct {
+	uints UintBuilder
+	rows  int
+	// runStarts holds the first row of each run of equal values.
+	runStarts []int
+	// isRunLength is true if the most recent call to Finish used a run-length
+	// encoding.
+	isRunLength bool
+	scratch     []uint64
+}
+
+// Assert that *AdaptiveUintBuilder implements ColumnWriter.
+var _ ColumnWriter = (*AdaptiveUintBuilder)(nil)
+
+// Init initializes the AdaptiveUintBuilder.
+func (b *AdaptiveUintBuilder) Init() {
+	b.uints.Init()
+	b.Reset()
+}
+
+// Reset implements ColumnWriter and resets the builder, reusing existing
+// allocated memory.
+func (b *AdaptiveUintBuilder) Reset() {
+	b.uints.Reset()
+	b.rows = 0
+	b.runStarts = b.runStarts[:0]
+	b.isRunLength = false
+}
+
+// NumColumns implements ColumnWriter.
+func (b *AdaptiveUintBuilder) NumColumns() int { return 1 }
+
+// DataType implements ColumnWriter.
+func (b *AdaptiveUintBuilder) DataType(int) DataType {
+	if b.isRunLength {
+		return DataTypeRunLengthUint
+	}
+	return DataTypeUint
+}
+
+// Get gets the value of the provided row index. The provided row must have
+// been Set.
+func (b *AdaptiveUintBuilder) Get(row int) uint64 {
+	return b.uints.Get(row)
+}
+
+// Set sets the value of the provided row index to v. Rows must be set in
+// order, beginning with row zero.
+func (b *AdaptiveUintBuilder) Set(row int, v uint64) {
+	if invariants.Enabled && row != b.rows {
+		panic(errors.AssertionFailedf("Set(%d) on AdaptiveUintBuilder with %d rows", row, b.rows))
+	}
+	if row == 0 || b.uints.Get(row-1) != v {
+		b.runStarts = append(b.runStarts, row)
+	}
+	b.uints.Set(row, v)
+	b.rows++
+}
+
+// runs returns the number of runs within the first [rows] rows.
+func (b *AdaptiveUintBuilder) runs(rows int) int {
+	return sort.SearchInts(b.runStarts, rows)
+}
+
+// useRunLength returns true if the first [rows] rows should be encoded using
+// a run-length encoding.
+func (b *AdaptiveUintBuilder) useRunLength(rows int) bool {
+	if rows == 0 {
+		return false
+	}
+	return b.runLengthSize(rows, 0) < b.uints.Size(rows, 0)
+}
+
+// runEndsEncoding returns the encoding of the run ends of the first [rows]
+// rows. The run ends are increasing, bounded by the first run's end and rows.
+func (b *AdaptiveUintBuilder) runEndsEncoding(rows, runs int) UintEncoding {
+	firstEnd := rows
+	if runs > 1 {
+		firstEnd = b.runStarts[1]
+	}
+	return DetermineUintEncoding(uint64(firstEnd), uint64(rows))
+}
+
+func (b *AdaptiveUintBuilder) runLengthSize(rows int, offset uint32) uint32 {
+	runs := b.runs(rows)
+	// The run values span the same range of values as the rows, so they use
+	// the same encoding.
+	e, _ := b.uints.determineEncoding(rows)
+	offset += 4 // runs
+	offset = uintColumnSize(uint32(runs), offset, b.runEndsEncoding(rows, runs))
+	return uintColumnSize(uint32(runs), offset, e)
+}
+
+// Size implements ColumnWriter.
+func (b *AdaptiveUintBuilder) Size(rows int, offset uint32) uint32 {
+	if b.useRunLength(rows) {
+		return b.runLengthSize(rows, offset)
+	}
+	return b.uints.Size(rows, offset)
+}
+
+// Finish implements ColumnWriter, serializing the first [rows] rows using
+// whichever encoding is smaller.
+func (b *AdaptiveUintBuilder) Finish(col, rows int, offset uint32, buf []byte) uint32 {
+	b.isRunLength = b.useRunLength(rows)
+	if !b.isRunLength {
+		return b.uints.Finish(col, rows, offset, buf)
+	}
+	runs := b.runs(rows)
+	binary.LittleEndian.PutUint32(buf[offset:], uint32(runs))
+	offset += 4
+	b.scratch = b.scratch[:0]
+	for j := 1; j < runs; j++ {
+		b.scratch = append(b.scratch, uint64(b.runStarts[j]))
+	}
+	b.scratch = append(b.scratch, uint64(rows))
+	offset = uintColumnFinish(b.scratch[0], b.scratch, b.runEndsEncoding(rows, runs), offset, buf)
+	b.scratch = b.scratch[:0]
+	for j := 0; j < runs; j++ {
+		b.scratch = append(b.scratch, b.uints.Get(b.runStarts[j]))
+	}
+	e, minimum := b.uints.determineEncoding(rows)
+	return uintColumnFinish(minimum, b.scratch, e, offset, buf)
+}
+
+// WriteDebug implements Encoder.
+func (b *AdaptiveUintBuilder) WriteDebug(w io.Writer, rows int) {
+	b.uints.WriteDebug(w, rows)
+	if b.useRunLength(rows) {
+		fmt.Fprintf(w, "; %d runs", b.runs(rows))
+	}
+}
+
+// AdaptiveUints holds an array of uints written by an AdaptiveUintBuilder,
+// encoded as either an UnsafeUints or a RunLengthUints.
+type AdaptiveUints struct {
+	uints       UnsafeUints
+	runs        RunLengthUints
+	isRunLength bool
+}
+
+// Assert that AdaptiveUints implements Array[uint64].
+var _ Array[uint64] = AdaptiveUints{}
+
+// At returns the i'th uint.
+func (u AdaptiveUints) At(i int) uint64 {
+	if u.isRunLength {
+		return u.runs.At(i)
+	}
+	return u.uints.At(i)
+}
+
+// RunLengthUints returns the array's run-length encoding, and true, if the
+// array is run-length encoded.
+func (u AdaptiveUints) RunLengthUints() (RunLengthUints, bool) {
+	return u.runs, u.isRunLength
+}
+
+// RunLengthBitmap holds an array of bools encoded as runs of equal values.
+//
+// # Representation
+//
+// The array is encoded as the number of runs and the value of the first run,
+// followed by a uint column of the exclusive end row of each run. Adjacent
+// runs alternate in value.
+//
+//	+-------------------------------------------------------------------+
+//	|                        runs (uint32, 4 bytes)                     |
+//	+-------------------------------------------------------------------+
+//	|                  value of the first run (1 byte)                  |
+//	+-------------------------------------------------------------------+
+//	|             run end rows (exclusive; see UintEncoding)            |
+//	+-------------------------------------------------------------------+
+type RunLengthBitmap struct {
+	runs  int
+	first bool
+	ends  UnsafeUints
+}
+
+// Assert that RunLengthBitmap implements Array[bool].
+var _ Array[bool] = RunLengthBitmap{}
+
+// DecodeRunLengthBitmap decodes the structure of a RunLengthBitmap,
+// constructing an accessor for an array of bools constructed by
+// AdaptiveBitmapBuilder. Rows must be the number of bools within the array.
+func DecodeRunLengthBitmap(
+	b []byte, offset uint32, rows int,
+) (runLengthBitmap RunLengthBitmap, endOffset uint32) {
+	if rows == 0 {
+		return RunLengthBitmap{}, offset
+	}
+	runLengthBitmap.runs = int(binary.LittleEndian.Uint32(b[offset:]))
+	offset += 4
+	runLengthBitmap.first = b[offset] == 1
+	offset++
+	runLengthBitmap.ends, offset = DecodeUnsafeUints(b, offset, runLengthBitmap.runs)
+	return runLengthBitmap, offset
+}
+
+// Assert that DecodeRunLengthBitmap implements DecodeFunc.
+var _ DecodeFunc[RunLengthBitmap] = DecodeRunLengthBitmap
+
+// At returns the bool at index i.
+func (b RunLengthBitmap) At(i int) bool {
+	return b.RunValue(b.Run(i))
+}
+
+// Runs returns the number of runs within the array.
+func (b RunLengthBitmap) Runs() int {
+	return b.runs
+}
+
+// Run returns the index of the run containing the i'th row.
+func (b RunLengthBitmap) Run(i int) int {
+	return searchRunEnds(b.ends, b.runs, i)
+}
+
+// RunEnd returns the exclusive end row of the j'th run. The j'th run begins at
+// the end of the (j-1)'th run, or row zero.
+func (b RunLengthBitmap) RunEnd(j int) int {
+	return int(b.ends.At(j))
+}
+
+// RunValue returns the value of every row of the j'th run.
+func (b RunLengthBitmap) RunValue(j int) bool {
+	return b.first == (j%2 == 0)
+}
+
+func runLengthBitmapToBinFormatter(f *binfmt.Formatter, rows int) {
+	if rows == 0 {
+		return
+	}
+	runs := int(f.PeekUint(4))
+	f.CommentLine("run-length bitmap")
+	f.HexBytesln(4, "runs: %d", runs)
+	f.HexBytesln(1, "first run value: %t", f.PeekUint(1) == 1)
+	f.CommentLine("run ends")
+	uintsToBinFormatter(f, runs, nil)
+}
+
+// AdaptiveBitmapBuilder builds a column of bools, choosing per column between
+// the encoding of a BitmapBuilder and a run-length encoding, whichever is
+// smaller. Bits must be set in order. The resulting column may be read using an
+// AdaptiveBitmap.
+type AdaptiveBitmapBuilder struct {
+	bitmap BitmapBuilder
+	// setRuns holds the [start, end) rows of each run of set bits.
+	setRuns [][2]int
+	// isRunLength is true if the most recent call to Finish used a run-length
+	// encoding.
+	isRunLength bool
+	scratch     []uint64
+}
+
+// Assert that *AdaptiveBitmapBuilder implements ColumnWriter.
+var _ ColumnWriter = (*AdaptiveBitmapBuilder)(nil)
+
+// Reset resets the bitmap to the empty state.
+func (b *AdaptiveBitmapBuilder) Reset() {
+	b.bitmap.Reset()
+	b.setRuns = b.setRuns[:0]
+	b.isRunLength = false
+}
+
+// NumColumns implements the ColumnWriter interface.
+func (b *AdaptiveBitmapBuilder) NumColumns() int { return 1 }
+
+// DataType implements the ColumnWriter interface.
+func (b *AdaptiveBitmapBuilder) DataType(int) DataType {
+	if b.isRunLength {
+		return DataTypeRunLengthBool
+	}
+	return DataTypeBool
+}
+
+// Set sets the bit at position i to true. Bits must be set in increasing
+// order.
+func (b *AdaptiveBitmapBuilder) Set(i int) {
+	n := len(b.setRuns)
+	if invariants.Enabled && n > 0 && i < b.setRuns[n-1][1] {
+		panic(errors.AssertionFailedf("Set(%d) on AdaptiveBitmapBuilder after Set(%d)", i, b.setRuns[n-1][1]-1))
+	}
+	if n > 0 && b.setRuns[n-1][1] == i {
+		b.setRuns[n-1][1]++
+	} else {
+		b.setRuns = append(b.setRuns, [2]int{i, i + 1})
+	}
+	b.bitmap.Set(i)
+}
+
+// runs returns the number of runs within the first [rows] rows, the number of
+// runs of set bits among them and the end of the first run.
+func (b *AdaptiveBitmapBuilder) runs(rows int) (runs, setRuns, firstEnd int) {
+	setRuns = sort.Search(len(b.setRuns), func(j int) bool { return b.setRuns[j][0] >= rows })
+	if setRuns == 0 {
+		return 1, 0, rows
+	}
+	// Each run of set bits is preceded by a run of unset bits, unless it
+	// begins at row zero, and the last may be followed by a run of unset bits.
+	runs = 2 * setRuns
+	firstEnd = b.setRuns[0][0]
+	if b.setRuns[0][0] == 0 {
+		runs--
+		firstEnd = min(b.setRuns[0][1], rows)
+	}
+	if b.setRuns[setRuns-1][1] < rows {
+		runs++
+	}
+	return runs, setRuns, firstEnd
+}
+
+// useRunLength returns true if the first [rows] rows should be encoded using
+// a run-length encoding.
+func (b *AdaptiveBitmapBuilder) useRunLength(rows int) bool {
+	if rows == 0 {
+		return false
+	}
+	return b.runLengthSize(rows, 0) < b.bitmap.Size(rows, 0)
+}
+
+func (b *AdaptiveBitmapBuilder) runLengthSize(rows int, offset uint32) uint32 {
+	runs, _, firstEnd := b.runs(rows)
+	offset += 5 // runs, first run value
+	return uintColumnSize(uint32(runs), offset, DetermineUintEncoding(uint64(firstEnd), uint64(rows)))
+}
+
+// Size implements the ColumnWriter interface.
+func (b *AdaptiveBitmapBuilder) Size(rows int, offset uint32) uint32 {
+	if b.useRunLength(rows) {
+		return b.runLengthSize(rows, offset)
+	}
+	return b.bitmap.Size(rows, offset)
+}
+
+// Finish implements the ColumnWriter interface, serializing the first [rows]
+// rows using whichever encoding is smaller.
+func (b *AdaptiveBitmapBuilder) Finish(col, rows int, offset uint32, buf []byte) uint32 {
+	b.isRunLength = b.useRunLength(rows)
+	if !b.isRunLength {
+		return b.bitmap.Finish(col, rows, offset, buf)
+	}
+	runs, setRuns, firstEnd := b.runs(rows)
+	binary.LittleEndian.PutUint32(buf[offset:], uint32(runs))
+	offset += 4
+	buf[offset] = 0
+	if setRuns > 0 && b.setRuns[0][0] == 0 {
+		buf[offset] = 1
+	}
+	offset++
+	b.scratch = b.scratch[:0]
+	for _, r := range b.setRuns[:setRuns] {
+		if r[0] > 0 {
+			b.scratch = append(b.scratch, uint64(r[0]))
+		}
+		b.scratch = append(b.scratch, uint64(min(r[1], rows)))
+	}
+	if len(b.scratch) < runs {
+		b.scratch = append(b.scratch, uint64(rows))
+	}
+	if invariants.Enabled && len(b.scratch) != runs {
+		panic(errors.AssertionFailedf("encoded %d runs; expected %d", len(b.scratch), runs))
+	}
+	return uintColumnFinish(uint64(firstEnd), b.scratch, DetermineUintEncoding(uint64(firstEnd), uint64(rows)), offset, buf)
+}
+
+// WriteDebug implements the ColumnWriter interface.
+func (b *AdaptiveBitmapBuilder) WriteDebug(w io.Writer, rows int) {
+	b.bitmap.WriteDebug(w, rows)
+	if b.useRunLength(rows) {
+		runs, _, _ := b.runs(rows)
+		fmt.Fprintf(w, "; %d runs", runs)
+	}
+}
+
+// AdaptiveBitmap holds an array of bools written by an AdaptiveBitmapBuilder,
+// encoded as either a Bitmap or a RunLengthBitmap.
+type AdaptiveBitmap struct {
+	bitmap      Bitmap
+	runs        RunLengthBitmap
+	isRunLength bool
+}
+
+// Assert that AdaptiveBitmap implements Array[bool].
+var _ Array[bool] = AdaptiveBitmap{}
+
+// At returns the bool at index i.
+func (b AdaptiveBitmap) At(i int) bool {
+	if b.isRunLength {
+		return b.runs.At(i)
+	}
+	return b.bitmap.At(i)
+}
+
+// RunLengthBitmap returns the array's run-length encoding, and true, if the
+// array is run-length encoded.
+func (b AdaptiveBitmap) RunLengthBitmap() (RunLengthBitmap, bool) {
+	return b.runs, b.isRunLength
+}
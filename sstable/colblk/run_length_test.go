@@ -0,0 +1,108 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package colblk
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/internal/aligned"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/rand"
+)
+
+func TestAdaptiveUintBuilderRandomized(t *testing.T) {
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+
+	var b AdaptiveUintBuilder
+	b.Init()
+	for _, r := range interestingIntRanges {
+		for _, runProb := range []float64{0, 0.5, 0.99} {
+			t.Run(fmt.Sprintf("[%d,%d],runs=%.2f", r.Min, r.Max, runProb), func(t *testing.T) {
+				b.Reset()
+				n := 1 + rng.Intn(300)
+				values := make([]uint64, n)
+				for i := range values {
+					values[i] = r.Rand(rng)
+					if i > 0 && rng.Float64() < runProb {
+						values[i] = values[i-1]
+					}
+					b.Set(i, values[i])
+				}
+				// Finish may be called with one less row than has been set.
+				rows := n - rng.Intn(2)
+				offset := uint32(rng.Intn(8))
+				size := b.Size(rows, offset)
+				buf := aligned.ByteSlice(int(size) + 1)
+				require.Equal(t, size, b.Finish(0, rows, offset, buf))
+
+				var got AdaptiveUints
+				var endOffset uint32
+				switch b.DataType(0) {
+				case DataTypeRunLengthUint:
+					got.isRunLength = true
+					got.runs, endOffset = DecodeRunLengthUints(buf, offset, rows)
+					require.Equal(t, rows, got.runs.RunEnd(got.runs.Runs()-1))
+				case DataTypeUint:
+					got.uints, endOffset = DecodeUnsafeUints(buf, offset, rows)
+				default:
+					t.Fatalf("unexpected data type %s", b.DataType(0))
+				}
+				require.Equal(t, size, endOffset)
+				require.Equal(t, values[:rows], Clone(got, rows))
+			})
+		}
+	}
+}
+
+func TestAdaptiveBitmapBuilderRandomized(t *testing.T) {
+	seed := uint64(time.Now().UnixNano())
+	t.Logf("seed: %d", seed)
+	rng := rand.New(rand.NewSource(seed))
+
+	var b AdaptiveBitmapBuilder
+	for _, setProb := range []float64{0, 0.1, 0.5, 1} {
+		for _, runProb := range []float64{0, 0.5, 0.99} {
+			t.Run(fmt.Sprintf("set=%.2f,runs=%.2f", setProb, runProb), func(t *testing.T) {
+				b.Reset()
+				n := 1 + rng.Intn(1000)
+				values := make([]bool, n)
+				for i := range values {
+					values[i] = rng.Float64() < setProb
+					if i > 0 && rng.Float64() < runProb {
+						values[i] = values[i-1]
+					}
+					if values[i] {
+						b.Set(i)
+					}
+				}
+				// Finish may be called with one less row than has been set.
+				rows := n - rng.Intn(2)
+				offset := uint32(rng.Intn(8))
+				size := b.Size(rows, offset)
+				buf := aligned.ByteSlice(int(size) + 1)
+				require.Equal(t, size, b.Finish(0, rows, offset, buf))
+
+				var got AdaptiveBitmap
+				var endOffset uint32
+				switch b.DataType(0) {
+				case DataTypeRunLengthBool:
+					got.isRunLength = true
+					got.runs, endOffset = DecodeRunLengthBitmap(buf, offset, rows)
+					require.Equal(t, rows, got.runs.RunEnd(got.runs.Runs()-1))
+				case DataTypeBool:
+					got.bitmap, endOffset = DecodeBitmap(buf, offset, rows)
+				default:
+					t.Fatalf("unexpected data type %s", b.DataType(0))
+				}
+				require.Equal(t, size, endOffset)
+				require.Equal(t, values[:rows], Clone(got, rows))
+			})
+		}
+	}
+}
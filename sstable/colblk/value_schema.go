@@ -0,0 +1,208 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package colblk
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ValueSchema defines the schema of values, as defined by the user's
+// application. A ValueSchema decomposes values into a fixed set of typed
+// fields, each stored in its own column of the data block. Iterators
+// configured with a projection (see block.IterTransforms.Projection) only
+// decode the projected columns, which makes scans that only need a few fields
+// of wide values cheap.
+//
+// Only the values of SET and SETWITHDEL keys stored in-place within the data
+// block are decomposed. Values stored out-of-band in value blocks, the values
+// of other kinds of keys (eg, merge operands) and values that Split declines
+// are stored whole in the data block's value column and are never projected.
+//
+// A data block written with a ValueSchema must be read with the same
+// ValueSchema. Tables record the Name of the schema their data blocks were
+// written with, and may only be read with a schema of the same name.
+type ValueSchema struct {
+	// Name identifies the schema. It's required if the schema has columns,
+	// and must change whenever the schema's columns or the way values are
+	// decomposed into them change.
+	Name string
+	// ColumnTypes holds the data type of each of the value's fields. The
+	// supported data types are DataTypeUint, DataTypeBytes and DataTypeBool.
+	ColumnTypes []DataType
+	// Split decomposes the value into its fields, setting fields[i] to the
+	// value of the i'th column. The provided fields are zeroed and
+	// len(fields) = len(ColumnTypes). Bytes fields may alias the value.
+	//
+	// Split returns false if the value does not conform to the schema, in
+	// which case the value is stored whole.
+	Split func(value []byte, fields []ValueField) bool
+	// Join appends the value composed of the provided fields to dst and
+	// returns the result. It's the inverse of Split. When iterating with a
+	// projection, the fields of columns that are not projected are zero.
+	Join func(dst []byte, fields []ValueField) []byte
+}
+
+// ValueField holds the value of one of a value's fields. Only the member
+// corresponding to the column's data type is used.
+type ValueField struct {
+	Uint  uint64
+	Bytes []byte
+	Bool  bool
+}
+
+// Validate returns an error if the schema is malformed.
+func (s *ValueSchema) Validate() error {
+	for i, t := range s.ColumnTypes {
+		switch t {
+		case DataTypeUint, DataTypeBytes, DataTypeBool:
+		default:
+			return errors.Errorf("value column %d has unsupported data type %s", i, t)
+		}
+	}
+	if len(s.ColumnTypes) > 0 && (s.Split == nil || s.Join == nil) {
+		return errors.New("value schema must define Split and Join")
+	}
+	if len(s.ColumnTypes) > 0 && s.Name == "" {
+		return errors.New("value schema must have a name")
+	}
+	return nil
+}
+
+// valueColumnsWriter is a ColumnWriter encoding the columns of values
+// decomposed by a ValueSchema. The first column is a bitmap indicating which
+// rows' values were decomposed, followed by a column for each field.
+type valueColumnsWriter struct {
+	schema  ValueSchema
+	isSplit BitmapBuilder
+	cols    []valueColumnWriter
+	fields  []ValueField
+}
+
+// Assert that *valueColumnsWriter implements ColumnWriter.
+var _ ColumnWriter = (*valueColumnsWriter)(nil)
+
+// valueColumnWriter holds the builder for a single value field. Only the
+// builder for the field's data type is used.
+type valueColumnWriter struct {
+	dataType DataType
+	uints    UintBuilder
+	bytes    RawBytesBuilder
+	bools    BitmapBuilder
+}
+
+func (c *valueColumnWriter) writer() ColumnWriter {
+	switch c.dataType {
+	case DataTypeUint:
+		return &c.uints
+	case DataTypeBytes:
+		return &c.bytes
+	default:
+		return &c.bools
+	}
+}
+
+func (w *valueColumnsWriter) init(schema ValueSchema) {
+	if err := schema.Validate(); err != nil {
+		panic(errors.AssertionFailedf("invalid value schema: %v", err))
+	}
+	w.schema = schema
+	w.isSplit.Reset()
+	w.cols = make([]valueColumnWriter, len(schema.ColumnTypes))
+	for i, t := range schema.ColumnTypes {
+		w.cols[i].dataType = t
+		// Values that aren't decomposed leave their fields unset.
+		w.cols[i].uints.InitWithDefault()
+		w.cols[i].bytes.Init()
+	}
+	w.fields = make([]ValueField, len(schema.ColumnTypes))
+}
+
+// add adds the row'th value, decomposing it if decompose is true and the
+// schema's Split accepts it. It returns true if the value was decomposed, in
+// which case the caller must not store the value itself.
+func (w *valueColumnsWriter) add(row int, value []byte, decompose bool) bool {
+	if decompose {
+		clear(w.fields)
+		decompose = w.schema.Split(value, w.fields)
+	}
+	if decompose {
+		w.isSplit.Set(row)
+	}
+	for i := range w.cols {
+		c := &w.cols[i]
+		switch c.dataType {
+		case DataTypeUint:
+			if decompose {
+				c.uints.Set(row, w.fields[i].Uint)
+			}
+		case DataTypeBytes:
+			// Every row must be Put, regardless of whether it was decomposed.
+			if decompose {
+				c.bytes.Put(w.fields[i].Bytes)
+			} else {
+				c.bytes.Put(nil)
+			}
+		case DataTypeBool:
+			if decompose && w.fields[i].Bool {
+				c.bools.Set(row)
+			}
+		}
+	}
+	return decompose
+}
+
+// NumColumns implements ColumnWriter.
+func (w *valueColumnsWriter) NumColumns() int { return 1 + len(w.cols) }
+
+// DataType implements ColumnWriter.
+func (w *valueColumnsWriter) DataType(col int) DataType {
+	if col == 0 {
+		return DataTypeBool
+	}
+	return w.cols[col-1].dataType
+}
+
+// Reset implements ColumnWriter.
+func (w *valueColumnsWriter) Reset() {
+	w.isSplit.Reset()
+	for i := range w.cols {
+		w.cols[i].writer().Reset()
+	}
+}
+
+// Size implements ColumnWriter.
+func (w *valueColumnsWriter) Size(rows int, offset uint32) uint32 {
+	offset = w.isSplit.Size(rows, offset)
+	for i := range w.cols {
+		offset = w.cols[i].writer().Size(rows, offset)
+	}
+	return offset
+}
+
+// Finish implements ColumnWriter.
+func (w *valueColumnsWriter) Finish(col, rows int, offset uint32, buf []byte) uint32 {
+	if col == 0 {
+		return w.isSplit.Finish(0, rows, offset, buf)
+	}
+	return w.cols[col-1].writer().Finish(0, rows, offset, buf)
+}
+
+// WriteDebug implements Encoder.
+func (w *valueColumnsWriter) WriteDebug(dst io.Writer, rows int) {
+	fmt.Fprintf(dst, "value columns(%d)", len(w.cols))
+}
+
+// valueColumnReader reads a single projected value field.
+type valueColumnReader struct {
+	// field is the index of the field within the ValueSchema.
+	field    int
+	dataType DataType
+	uints    UnsafeUints
+	bytes    RawBytes
+	bools    Bitmap
+}
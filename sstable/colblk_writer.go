@@ -9,6 +9,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"slices"
 	"sync"
 
 	"github.com/cockroachdb/errors"
@@ -122,11 +123,12 @@ func NewColumnarWriter(writable objstorage.Writable, o WriterOptions) *RawColumn
 			blockSizeThreshold:      (o.IndexBlockSize*o.BlockSizeThreshold + 99) / 100,
 			sizeClassAwareThreshold: (o.IndexBlockSize*o.SizeClassAwareThreshold + 99) / 100,
 		},
-		allocatorSizeClasses: o.AllocatorSizeClasses,
-		opts:                 o,
-		layout:               makeLayoutWriter(writable, o),
+		allocatorSizeClasses:  o.AllocatorSizeClasses,
+		disableKeyOrderChecks: o.internal.DisableKeyOrderChecks,
+		opts:                  o,
+		layout:                makeLayoutWriter(writable, o),
 	}
-	w.dataBlock.Init(o.KeySchema)
+	w.dataBlock.InitWithValueSchema(o.KeySchema, o.ValueSchema)
 	w.indexBlock.Init()
 	w.topLevelIndexBlock.Init()
 	w.rangeDelBlock.Init(w.comparer.Equal)
@@ -168,6 +170,9 @@ func NewColumnarWriter(writable objstorage.Writable, o WriterOptions) *RawColumn
 	w.props.ComparerName = o.Comparer.Name
 	w.props.CompressionName = o.Compression.String()
 	w.props.MergerName = o.MergerName
+	if len(o.ValueSchema.ColumnTypes) > 0 {
+		w.props.ValueSchemaName = o.ValueSchema.Name
+	}
 
 	w.writeQueue.ch = make(chan *compressedBlock)
 	w.writeQueue.wg.Add(1)
@@ -183,7 +188,10 @@ func (w *RawColumnWriter) Error() error {
 // EstimatedSize returns the estimated size of the sstable being written if
 // a call to Close() was made without adding additional keys.
 func (w *RawColumnWriter) EstimatedSize() uint64 {
-	sz := rocksDBFooterLen + w.queuedDataSize
+	sz := rocksDBFooterLen + w.queuedDataSize + uint64(w.pendingDataBlockSize)
+	if w.indexBlock.Rows() > 0 {
+		sz += uint64(w.indexBlock.Size())
+	}
 	// TODO(jackson): Avoid iterating over partitions by incrementally
 	// maintaining the size contribution of all buffered partitions.
 	for _, bib := range w.indexBuffering.partitions {
@@ -286,6 +294,11 @@ func (w *RawColumnWriter) EncodeSpan(span keyspan.Span) error {
 				panic(errors.Errorf("pebble: invalid range key type: %s", k.Kind()))
 			}
 		}
+		for i := range w.blockPropCollectors {
+			if err := w.blockPropCollectors[i].AddRangeKeys(span); err != nil {
+				return err
+			}
+		}
 	}
 	if !w.disableKeyOrderChecks && blockWriter.KeyCount() > 0 {
 		// Check that spans are being added in fragmented order. If the two
@@ -666,6 +679,10 @@ func (w *RawColumnWriter) enqueuePhysicalBlock(cb *compressedBlock, separator []
 	i := w.indexBlock.AddBlockHandle(separator, dataBlockHandle, dataBlockProps)
 	sizeWithEntry := w.indexBlock.Size()
 	if shouldFlushWithoutLatestKV(sizeWithEntry, w.indexBlockSize, i, w.indexBlockOptions, w.allocatorSizeClasses) {
+		// finishIndexBlock reuses w.blockPropsEncoder, overwriting the data
+		// block's properties. Copy them first so that they may be re-added to
+		// the new index block.
+		dataBlockProps = slices.Clone(dataBlockProps)
 		if err = w.finishIndexBlock(w.indexBlock.Rows() - 1); err != nil {
 			return err
 		}
@@ -764,7 +781,7 @@ func (w *RawColumnWriter) flushBufferedIndexBlocks() (rootIndex block.Handle, er
 				return block.Handle{}, err
 			}
 			w.props.IndexSize += bh.Length + block.TrailerLen
-			w.props.NumDataBlocks += uint64(w.indexBuffering.partitions[0].nEntries)
+			w.props.NumDataBlocks += uint64(part.nEntries)
 			w.topLevelIndexBlock.AddBlockHandle(part.sep.UserKey, bh, part.properties)
 		}
 		rootIndex, err = w.layout.WriteIndexBlock(w.topLevelIndexBlock.Finish(w.topLevelIndexBlock.Rows()))
@@ -937,6 +954,7 @@ func (w *RawColumnWriter) Close() (err error) {
 		return err
 	}
 	w.meta.Properties = w.props
+	w.meta.TableFormat = w.opts.TableFormat
 	// Release any held memory and make any future calls error.
 	// TODO(jackson): Ensure other calls error appropriately if the writer is
 	// cleared.
@@ -959,7 +977,7 @@ func (w *RawColumnWriter) rewriteSuffixes(
 	}
 	// Copy data blocks in parallel, rewriting suffixes as we go.
 	blocks, err := rewriteDataBlocksInParallel(r, wo, l.Data, from, to, concurrency, func() blockRewriter {
-		return &colblk.DataBlockRewriter{KeySchema: wo.KeySchema}
+		return &colblk.DataBlockRewriter{KeySchema: w.opts.KeySchema, ValueSchema: w.opts.ValueSchema}
 	})
 	if err != nil {
 		return errors.Wrap(err, "rewriting data blocks")
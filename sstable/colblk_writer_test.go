@@ -7,11 +7,15 @@ package sstable
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/cockroachdb/datadriven"
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/aligned"
+	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/binfmt"
 	"github.com/cockroachdb/pebble/internal/testkeys"
 	"github.com/cockroachdb/pebble/objstorage"
@@ -145,3 +149,124 @@ func describeSSTableBinary(f *binfmt.Formatter, schema colblk.KeySchema) error {
 	describeFooter(f)
 	return nil
 }
+
+func TestColumnarWriterValueSchema(t *testing.T) {
+	// Values are an 8-byte little-endian uint followed by arbitrary bytes.
+	valueSchema := colblk.ValueSchema{
+		Name:        "uint-and-bytes",
+		ColumnTypes: []colblk.DataType{colblk.DataTypeUint, colblk.DataTypeBytes},
+		Split: func(value []byte, fields []colblk.ValueField) bool {
+			if len(value) < 8 {
+				return false
+			}
+			fields[0].Uint = binary.LittleEndian.Uint64(value)
+			fields[1].Bytes = value[8:]
+			return true
+		},
+		Join: func(dst []byte, fields []colblk.ValueField) []byte {
+			dst = binary.LittleEndian.AppendUint64(dst, fields[0].Uint)
+			return append(dst, fields[1].Bytes...)
+		},
+	}
+	makeValue := func(n uint64, b []byte) []byte {
+		return append(binary.LittleEndian.AppendUint64(nil, n), b...)
+	}
+
+	const numKeys = 1000
+	obj := &objstorage.MemObj{}
+	w := NewWriter(obj, WriterOptions{
+		Comparer:       testkeys.Comparer,
+		TableFormat:    TableFormatPebblev5,
+		ValueSchema:    valueSchema,
+		BlockSize:      512,
+		IndexBlockSize: 512,
+	})
+	for i := 0; i < numKeys; i++ {
+		v := makeValue(uint64(i), []byte(fmt.Sprintf("payload-%d", i)))
+		if i%10 == 0 {
+			// Values that don't conform to the schema are stored whole.
+			v = []byte("short")
+		}
+		require.NoError(t, w.Set([]byte(fmt.Sprintf("key%05d", i)), v))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(context.Background(), obj, ReaderOptions{
+		Comparer:    testkeys.Comparer,
+		KeySchema:   colblk.DefaultKeySchema(testkeys.Comparer, 16),
+		ValueSchema: valueSchema,
+	})
+	require.NoError(t, err)
+	defer r.Close()
+	require.Equal(t, uint32(twoLevelIndex), r.Properties.IndexType)
+
+	for _, projection := range [][]int{nil, {0}, {1}, {}, {1, 0}} {
+		t.Run(fmt.Sprint(projection), func(t *testing.T) {
+			projected := func(col int) bool {
+				return projection == nil || slices.Contains(projection, col)
+			}
+			iter, err := r.NewIter(block.IterTransforms{Projection: projection}, nil, nil)
+			require.NoError(t, err)
+			defer iter.Close()
+			i := 0
+			for kv := iter.First(); kv != nil; kv = iter.Next() {
+				require.Equal(t, fmt.Sprintf("key%05d", i), string(kv.K.UserKey))
+				want := []byte("short")
+				if i%10 != 0 {
+					var n uint64
+					var b []byte
+					if projected(0) {
+						n = uint64(i)
+					}
+					if projected(1) {
+						b = []byte(fmt.Sprintf("payload-%d", i))
+					}
+					want = makeValue(n, b)
+				}
+				v, _, err := kv.Value(nil)
+				require.NoError(t, err)
+				require.Equal(t, want, v)
+				i++
+			}
+			require.NoError(t, iter.Error())
+			require.Equal(t, numKeys, i)
+		})
+	}
+
+	// The table records the name of its value schema, and can't be opened
+	// without it. Opening it with a different schema isn't corruption.
+	require.Equal(t, valueSchema.Name, r.Properties.ValueSchemaName)
+	otherSchema := valueSchema
+	otherSchema.Name = "other"
+	for _, vs := range []colblk.ValueSchema{{}, otherSchema} {
+		_, err := NewReader(context.Background(), obj, ReaderOptions{
+			Comparer:    testkeys.Comparer,
+			KeySchema:   colblk.DefaultKeySchema(testkeys.Comparer, 16),
+			ValueSchema: vs,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "value schema uint-and-bytes")
+		require.False(t, errors.Is(err, base.ErrCorruption))
+	}
+
+	// A table written without a value schema may be read with one.
+	obj = &objstorage.MemObj{}
+	w = NewWriter(obj, WriterOptions{Comparer: testkeys.Comparer, TableFormat: TableFormatPebblev5})
+	require.NoError(t, w.Set([]byte("key"), makeValue(1, []byte("payload"))))
+	require.NoError(t, w.Close())
+	r2, err := NewReader(context.Background(), obj, ReaderOptions{
+		Comparer:    testkeys.Comparer,
+		KeySchema:   colblk.DefaultKeySchema(testkeys.Comparer, 16),
+		ValueSchema: valueSchema,
+	})
+	require.NoError(t, err)
+	defer r2.Close()
+	iter, err := r2.NewIter(block.IterTransforms{Projection: []int{1}}, nil, nil)
+	require.NoError(t, err)
+	kv := iter.First()
+	require.NotNil(t, kv)
+	v, _, err := kv.Value(nil)
+	require.NoError(t, err)
+	require.Equal(t, makeValue(1, []byte("payload")), v)
+	require.NoError(t, iter.Close())
+}
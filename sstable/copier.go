@@ -10,6 +10,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/bytealloc"
+	"github.com/cockroachdb/pebble/internal/cache"
 	"github.com/cockroachdb/pebble/objstorage"
 	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
 	"github.com/cockroachdb/pebble/sstable/block"
@@ -55,6 +56,19 @@ func CopySpan(
 	if r.Properties.NumValueBlocks > 0 || r.Properties.NumRangeKeys() > 0 || r.Properties.NumRangeDeletions > 0 {
 		return copyWholeFileBecauseOfUnsupportedFeature(ctx, input, output) // Finishes/Aborts output.
 	}
+	// TODO(jackson): Support copying spans of sstables with columnar blocks.
+	if r.tableFormat.BlockColumnar() {
+		// Preserve the ErrEmptySpan contract of the row-oriented path so that
+		// callers can drop tables that contain no keys within the span.
+		if empty, err := spanIsEmpty(r, start, end); err != nil || empty {
+			output.Abort()
+			if err == nil {
+				err = ErrEmptySpan
+			}
+			return 0, err
+		}
+		return copyWholeFileBecauseOfUnsupportedFeature(ctx, input, output) // Finishes/Aborts output.
+	}
 
 	// If our input has not filters, our output cannot have filters either.
 	if r.tableFilter == nil {
@@ -270,7 +284,7 @@ func intersectingIndexEntries(
 			alloc, entry.sep.UserKey = alloc.Copy(entry.sep.UserKey)
 			res = append(res, entry)
 		} else {
-			subBlk, err := r.readBlock(ctx, bh.Handle, nil, rh, nil, nil, nil)
+			subBlk, err := r.readBlock(ctx, bh.Handle, nil, rh, nil, nil, nil, cache.NormalPriority)
 			if err != nil {
 				return nil, err
 			}
@@ -323,3 +337,17 @@ func copyWholeFileBecauseOfUnsupportedFeature(
 	}
 	return length, output.Finish()
 }
+
+// spanIsEmpty returns true if the sstable contains no point keys within the
+// span [start, end). It's used by CopySpan for table formats for which it does
+// not yet support copying individual blocks.
+func spanIsEmpty(r *Reader, start, end InternalKey) (bool, error) {
+	iter, err := r.NewPointIter(
+		context.TODO(), NoTransforms, start.UserKey, end.UserKey, nil, NeverUseFilterBlock,
+		nil /* stats */, CategoryAndQoS{}, nil /* statsCollector */, MakeTrivialReaderProvider(r))
+	if err != nil {
+		return false, err
+	}
+	kv := iter.First()
+	return kv == nil, errors.CombineErrors(iter.Error(), iter.Close())
+}
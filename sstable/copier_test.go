@@ -0,0 +1,73 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package sstable
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/testkeys"
+	"github.com/cockroachdb/pebble/objstorage"
+	"github.com/cockroachdb/pebble/sstable/colblk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopySpan(t *testing.T) {
+	for _, format := range []TableFormat{TableFormatPebblev4, TableFormatPebblev5} {
+		t.Run(format.String(), func(t *testing.T) {
+			writerOpts := WriterOptions{
+				BlockSize:   64,
+				Comparer:    testkeys.Comparer,
+				KeySchema:   colblk.DefaultKeySchema(testkeys.Comparer, 16),
+				TableFormat: format,
+			}
+			obj := &objstorage.MemObj{}
+			w := NewWriter(obj, writerOpts)
+			for i := 0; i < 100; i++ {
+				require.NoError(t, w.Set([]byte(fmt.Sprintf("k%03d", i)), []byte("value")))
+			}
+			require.NoError(t, w.Close())
+			r, err := openReader(obj, &writerOpts, 0 /* cacheSize */)
+			require.NoError(t, err)
+			defer r.Close()
+
+			copySpan := func(start, end string) (*objstorage.MemObj, error) {
+				out := &objstorage.MemObj{}
+				size, err := CopySpan(context.Background(), obj, r, ReaderOptions{
+					Comparer:  writerOpts.Comparer,
+					KeySchema: writerOpts.KeySchema,
+				}, out, writerOpts, base.MakeSearchKey([]byte(start)), base.MakeSearchKey([]byte(end)))
+				if err != nil {
+					return nil, err
+				}
+				require.Equal(t, uint64(out.Size()), size)
+				return out, nil
+			}
+
+			// The copy must contain all of the keys within the span.
+			out, err := copySpan("k010", "k020")
+			require.NoError(t, err)
+			copied, err := openReader(out, &writerOpts, 0 /* cacheSize */)
+			require.NoError(t, err)
+			defer copied.Close()
+			iter, err := copied.NewIter(NoTransforms, []byte("k010"), []byte("k020"))
+			require.NoError(t, err)
+			var n int
+			for kv := iter.First(); kv != nil; kv = iter.Next() {
+				require.Equal(t, fmt.Sprintf("k%03d", 10+n), string(kv.K.UserKey))
+				n++
+			}
+			require.NoError(t, iter.Close())
+			require.Equal(t, 10, n)
+
+			// A span beyond all of the table's keys is empty.
+			_, err = copySpan("k100", "k200")
+			require.True(t, errors.Is(err, ErrEmptySpan), "%v", err)
+		})
+	}
+}
@@ -126,6 +126,15 @@ func runBuildMemObjCmd(
 					if forceObsolete {
 						return errors.Errorf("force-obsolete is not allowed for RANGEDEL")
 					}
+					if writerOpts.TableFormat.BlockColumnar() {
+						// Columnar writers only accept range deletions through
+						// EncodeSpan.
+						return w.EncodeSpan(keyspan.Span{
+							Start: key.UserKey,
+							End:   value,
+							Keys:  []keyspan.Key{{Trailer: key.Trailer}},
+						})
+					}
 					return w.AddWithForceObsolete(key, value, false /* forceObsolete */)
 				default:
 					return w.AddWithForceObsolete(key, value, forceObsolete)
@@ -89,6 +89,13 @@ type ReaderOptions struct {
 	// higher.
 	KeySchema colblk.KeySchema
 
+	// ValueSchema describes the schema to use when interpreting values
+	// decomposed into columns. If the sstable was written with a value schema,
+	// its name must match ValueSchema.Name; otherwise, NewReader returns an
+	// error. Only used for sstables encoded in format TableFormatPebblev5 or
+	// higher.
+	ValueSchema colblk.ValueSchema
+
 	// Filters is a map from filter policy name to filter policy. Filters with
 	// policies that are not in this map will be ignored.
 	Filters map[string]FilterPolicy
@@ -130,6 +137,9 @@ func (o ReaderOptions) ensureDefaults() ReaderOptions {
 	if o.DeniedUserProperties == nil {
 		o.DeniedUserProperties = ignoredInternalProperties
 	}
+	if len(o.KeySchema.ColumnTypes) == 0 {
+		o.KeySchema = colblk.DefaultKeySchema(o.Comparer, 16 /* bundle size */)
+	}
 	return o
 }
 
@@ -205,6 +215,13 @@ type WriterOptions struct {
 	// Ignored if TableFormat <= TableFormatPebblev4.
 	KeySchema colblk.KeySchema
 
+	// ValueSchema describes the schema to use for sstable formats that make
+	// use of columnar blocks, decomposing values stored in-place into typed
+	// columns that iterators may decode selectively. If the ValueSchema has no
+	// columns, values are stored opaquely. Ignored if TableFormat <=
+	// TableFormatPebblev4.
+	ValueSchema colblk.ValueSchema
+
 	// Merger defines the associative merge operation to use for merging values
 	// written with {Batch,DB}.Merge. The MergerName is checked for consistency
 	// with the value stored in the sstable when it was written.
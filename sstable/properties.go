@@ -183,6 +183,9 @@ type Properties struct {
 	SnapshotPinnedValueSize uint64 `prop:"pebble.raw.snapshot-pinned-values.size"`
 	// Size of the top-level index if kTwoLevelIndexSearch is used.
 	TopLevelIndexSize uint64 `prop:"rocksdb.top-level.index.size"`
+	// The name of the value schema the table's values were decomposed with.
+	// Empty if the table's data blocks have no value columns.
+	ValueSchemaName string `prop:"pebble.value-schema.name"`
 	// User collected properties. Currently, we only use them to store block
 	// properties aggregated at the table level.
 	UserProperties map[string]string
@@ -413,6 +416,9 @@ func (p *Properties) save(tblFormat TableFormat, w *rowblk.Writer) {
 	if p.ValueBlocksSize > 0 {
 		p.saveUvarint(m, unsafe.Offsetof(p.ValueBlocksSize), p.ValueBlocksSize)
 	}
+	if p.ValueSchemaName != "" {
+		p.saveString(m, unsafe.Offsetof(p.ValueSchemaName), p.ValueSchemaName)
+	}
 	if p.NumTombstoneDenseBlocks != 0 {
 		p.saveUvarint(m, unsafe.Offsetof(p.NumTombstoneDenseBlocks), p.NumTombstoneDenseBlocks)
 	}
@@ -87,6 +87,7 @@ var testProps = Properties{
 	NumValuesInValueBlocks: 23,
 	PropertyCollectorNames: "prefix collector names",
 	TopLevelIndexSize:      27,
+	ValueSchemaName:        "value schema name",
 	UserProperties: map[string]string{
 		"user-prop-a": "1",
 		"user-prop-b": "2",
@@ -53,6 +53,7 @@ type Reader struct {
 	// The following fields are copied from the ReadOptions.
 	cacheOpts            sstableinternal.CacheOptions
 	keySchema            colblk.KeySchema
+	valueSchema          colblk.ValueSchema
 	loadBlockSema        *fifo.Semaphore
 	deniedUserProperties map[string]struct{}
 	filterMetricsTracker *FilterMetricsTracker
@@ -329,7 +330,7 @@ func (r *Reader) readIndex(
 	iterStats *iterStatsAccumulator,
 ) (block.BufferHandle, error) {
 	ctx = objiotracing.WithBlockType(ctx, objiotracing.MetadataBlock)
-	return r.readBlock(ctx, r.indexBH, nil, readHandle, stats, iterStats, nil /* buffer pool */)
+	return r.readBlock(ctx, r.indexBH, nil, readHandle, stats, iterStats, nil /* buffer pool */, cache.HighPriority)
 }
 
 func (r *Reader) readFilter(
@@ -339,21 +340,21 @@ func (r *Reader) readFilter(
 	iterStats *iterStatsAccumulator,
 ) (block.BufferHandle, error) {
 	ctx = objiotracing.WithBlockType(ctx, objiotracing.FilterBlock)
-	return r.readBlock(ctx, r.filterBH, nil /* transform */, readHandle, stats, iterStats, nil /* buffer pool */)
+	return r.readBlock(ctx, r.filterBH, nil /* transform */, readHandle, stats, iterStats, nil /* buffer pool */, cache.HighPriority)
 }
 
 func (r *Reader) readRangeDel(
 	ctx context.Context, stats *base.InternalIteratorStats, iterStats *iterStatsAccumulator,
 ) (block.BufferHandle, error) {
 	ctx = objiotracing.WithBlockType(ctx, objiotracing.MetadataBlock)
-	return r.readBlock(ctx, r.rangeDelBH, nil /* transform */, nil /* readHandle */, stats, iterStats, nil /* buffer pool */)
+	return r.readBlock(ctx, r.rangeDelBH, nil /* transform */, nil /* readHandle */, stats, iterStats, nil /* buffer pool */, cache.NormalPriority)
 }
 
 func (r *Reader) readRangeKey(
 	ctx context.Context, stats *base.InternalIteratorStats, iterStats *iterStatsAccumulator,
 ) (block.BufferHandle, error) {
 	ctx = objiotracing.WithBlockType(ctx, objiotracing.MetadataBlock)
-	return r.readBlock(ctx, r.rangeKeyBH, nil /* transform */, nil /* readHandle */, stats, iterStats, nil /* buffer pool */)
+	return r.readBlock(ctx, r.rangeKeyBH, nil /* transform */, nil /* readHandle */, stats, iterStats, nil /* buffer pool */, cache.NormalPriority)
 }
 
 func checkChecksum(
@@ -399,6 +400,7 @@ func (r *Reader) readBlock(
 	stats *base.InternalIteratorStats,
 	iterStats *iterStatsAccumulator,
 	bufferPool *block.BufferPool,
+	priority cache.Priority,
 ) (handle block.BufferHandle, _ error) {
 	if h := r.cacheOpts.Cache.Get(r.cacheOpts.CacheID, r.cacheOpts.FileNum, bh.Offset); h.Get() != nil {
 		// Cache hit.
@@ -500,7 +502,7 @@ func (r *Reader) readBlock(
 	if iterStats != nil {
 		iterStats.reportStats(bh.Length, 0, readDuration)
 	}
-	h := decompressed.MakeHandle(r.cacheOpts.Cache, r.cacheOpts.CacheID, r.cacheOpts.FileNum, bh.Offset)
+	h := decompressed.MakeHandle(r.cacheOpts.Cache, r.cacheOpts.CacheID, r.cacheOpts.FileNum, bh.Offset, priority)
 	return h, nil
 }
 
@@ -525,7 +527,7 @@ func (r *Reader) readMetaindex(
 
 	b, err := r.readBlock(
 		ctx, metaindexBH, nil /* transform */, readHandle, nil, /* stats */
-		nil /* iterStats */, &r.metaBufferPool)
+		nil /* iterStats */, &r.metaBufferPool, cache.NormalPriority)
 	if err != nil {
 		return err
 	}
@@ -546,7 +548,7 @@ func (r *Reader) readMetaindex(
 	if bh, ok := meta[metaPropertiesName]; ok {
 		b, err = r.readBlock(
 			ctx, bh, nil /* transform */, readHandle, nil, /* stats */
-			nil /* iterStats */, nil /* buffer pool */)
+			nil /* iterStats */, nil /* buffer pool */, cache.NormalPriority)
 		if err != nil {
 			return err
 		}
@@ -646,7 +648,7 @@ func (r *Reader) Layout() (*Layout, error) {
 			l.Index = append(l.Index, indexBH.Handle)
 
 			subIndex, err := r.readBlock(context.Background(), indexBH.Handle,
-				nil /* transform */, nil /* readHandle */, nil /* stats */, nil /* iterStats */, nil /* buffer pool */)
+				nil /* transform */, nil /* readHandle */, nil /* stats */, nil /* iterStats */, nil /* buffer pool */, cache.HighPriority)
 			if err != nil {
 				return nil, err
 			}
@@ -674,7 +676,7 @@ func (r *Reader) Layout() (*Layout, error) {
 		}
 	}
 	if r.valueBIH.h.Length != 0 {
-		vbiH, err := r.readBlock(context.Background(), r.valueBIH.h, nil, nil, nil, nil, nil /* buffer pool */)
+		vbiH, err := r.readBlock(context.Background(), r.valueBIH.h, nil, nil, nil, nil, nil /* buffer pool */, cache.NormalPriority)
 		if err != nil {
 			return nil, err
 		}
@@ -727,7 +729,7 @@ func (r *Reader) ValidateBlockChecksums() error {
 		}
 
 		// Read the block, which validates the checksum.
-		h, err := r.readBlock(context.Background(), bh, nil, rh, nil, nil /* iterStats */, nil /* buffer pool */)
+		h, err := r.readBlock(context.Background(), bh, nil, rh, nil, nil /* iterStats */, nil /* buffer pool */, cache.NormalPriority)
 		if err != nil {
 			return err
 		}
@@ -737,6 +739,64 @@ func (r *Reader) ValidateBlockChecksums() error {
 	return nil
 }
 
+// CorruptBlock describes a block of an sstable whose contents do not match
+// its checksum.
+type CorruptBlock struct {
+	// Name describes the kind of block, e.g. "data" or "index" (see
+	// Layout.Describe).
+	Name   string
+	Handle block.Handle
+	Err    error
+}
+
+// FindCorruptBlocks reads every block in the SSTable from storage and returns
+// the blocks whose checksums do not match their contents. Unlike
+// ValidateBlockChecksums, the block cache is bypassed so that the blocks are
+// verified as they are stored, value blocks are included, and validation
+// continues past a corrupt block.
+//
+// If non-nil, pace is called with the length of each block before it is read,
+// and may block to limit the rate at which the file is read. An error is
+// returned if pace returns an error, if a block cannot be read, or if the
+// layout of the SSTable cannot be decoded (which may itself be due to a
+// corrupt index or metaindex block).
+func (r *Reader) FindCorruptBlocks(
+	ctx context.Context, pace func(length uint64) error,
+) ([]CorruptBlock, error) {
+	l, err := r.Layout()
+	if err != nil {
+		return nil, err
+	}
+	rh := r.readable.NewReadHandle(objstorage.NoReadBefore)
+	defer rh.Close()
+
+	var corrupt []CorruptBlock
+	var buf []byte
+	for _, bh := range l.orderedBlocks() {
+		// The footer is not a block with a checksum.
+		if bh.Handle == l.Footer {
+			continue
+		}
+		n := bh.Length + block.TrailerLen
+		if pace != nil {
+			if err := pace(n); err != nil {
+				return nil, err
+			}
+		}
+		if uint64(cap(buf)) < n {
+			buf = make([]byte, n)
+		}
+		buf = buf[:n]
+		if err := rh.ReadAt(ctx, buf, int64(bh.Offset)); err != nil {
+			return nil, err
+		}
+		if err := checkChecksum(r.checksumType, buf, bh.Handle, r.cacheOpts.FileNum); err != nil {
+			corrupt = append(corrupt, CorruptBlock{Name: bh.Name, Handle: bh.Handle, Err: err})
+		}
+	}
+	return corrupt, nil
+}
+
 // CommonProperties implemented the CommonReader interface.
 func (r *Reader) CommonProperties() *CommonProperties {
 	return &r.Properties.CommonProperties
@@ -801,7 +861,7 @@ func estimateDiskUsage[I any, PI indexBlockIterator[I]](
 			return 0, errCorruptIndexEntry(err)
 		}
 		startIdxBlock, err := r.readBlock(context.Background(), startIndexBH.Handle,
-			nil /* transform */, nil /* readHandle */, nil /* stats */, nil /* iterStats */, nil /* buffer pool */)
+			nil /* transform */, nil /* readHandle */, nil /* stats */, nil /* iterStats */, nil /* buffer pool */, cache.HighPriority)
 		if err != nil {
 			return 0, err
 		}
@@ -818,7 +878,7 @@ func estimateDiskUsage[I any, PI indexBlockIterator[I]](
 				return 0, errCorruptIndexEntry(err)
 			}
 			endIdxBlock, err := r.readBlock(context.Background(),
-				endIndexBH.Handle, nil /* transform */, nil /* readHandle */, nil /* stats */, nil /* iterStats */, nil /* buffer pool */)
+				endIndexBH.Handle, nil /* transform */, nil /* readHandle */, nil /* stats */, nil /* iterStats */, nil /* buffer pool */, cache.HighPriority)
 			if err != nil {
 				return 0, err
 			}
@@ -872,6 +932,172 @@ func estimateDiskUsage[I any, PI indexBlockIterator[I]](
 		endBH.Offset + endBH.Length + block.TrailerLen - startBH.Offset), nil
 }
 
+// CoveredDataBlocks describes a contiguous run of a table's data blocks whose
+// keys all fall within a span. See Reader.VisitCoveredDataBlocks.
+type CoveredDataBlocks struct {
+	// Count is the number of data blocks in the run. If zero, the bounds are
+	// unset.
+	Count int
+	// After is an exclusive lower bound on the user keys of the run's blocks.
+	// If nil, the run begins with the table's first data block.
+	After []byte
+	// Through is an inclusive upper bound on the user keys of the run's
+	// blocks.
+	Through []byte
+}
+
+// VisitCoveredDataBlocks finds the run of data blocks whose keys are all known
+// to be ≥ start and < end without reading the blocks, and invokes fn with the
+// block property collected for each block by each of the named block-property
+// collectors. The index of the collector within names is passed to fn, along
+// with the encoded property (which may be empty). A nil start indicates that
+// all of the table's keys are ≥ start. The returned CoveredDataBlocks bounds
+// the keys of the visited blocks, so that the caller may read the remaining
+// keys of the table through an iterator.
+//
+// The index separators are the only information used to bound the blocks'
+// keys, so a block containing the first key ≥ start or a key ≥ end is never
+// visited. VisitCoveredDataBlocks returns an error if any of the named
+// properties was not collected when the table was written.
+func (r *Reader) VisitCoveredDataBlocks(
+	start, end []byte, names []string, fn func(i int, prop []byte) error,
+) (CoveredDataBlocks, error) {
+	if !r.tableFormat.BlockColumnar() {
+		return visitCoveredDataBlocks[rowblk.IndexIter, *rowblk.IndexIter](r, start, end, names, fn)
+	}
+	return visitCoveredDataBlocks[colblk.IndexIter, *colblk.IndexIter](r, start, end, names, fn)
+}
+
+func visitCoveredDataBlocks[I any, PI indexBlockIterator[I]](
+	r *Reader, start, end []byte, names []string, fn func(i int, prop []byte) error,
+) (CoveredDataBlocks, error) {
+	if r.err != nil {
+		return CoveredDataBlocks{}, r.err
+	}
+	// Map the collectors' shortIDs to their index within names.
+	var shortIDToName []int
+	for i, name := range names {
+		prop, ok := r.Properties.UserProperties[name]
+		if !ok || len(prop) < 1 {
+			return CoveredDataBlocks{}, errors.Errorf("block property %q not collected", name)
+		}
+		id := int(prop[0])
+		for len(shortIDToName) <= id {
+			shortIDToName = append(shortIDToName, -1)
+		}
+		shortIDToName[id] = i
+	}
+
+	ctx := context.Background()
+	// NB: The index iterators take ownership of the block handles they're
+	// initialized with, releasing them on Close.
+	indexH, err := r.readIndex(ctx, nil, nil, nil)
+	if err != nil {
+		return CoveredDataBlocks{}, err
+	}
+
+	var res CoveredDataBlocks
+	var prevSep []byte
+	havePrev := false
+	// visitIndex iterates over the data blocks referenced by the index block
+	// iter, which must be positioned at the first entry to consider. It
+	// returns true once a separator ≥ end is reached.
+	visitIndex := func(iter PI, valid bool) (done bool, err error) {
+		for ; valid; valid = iter.Next() {
+			sep := iter.Separator()
+			if end != nil && r.Compare(sep, end) >= 0 {
+				return true, nil
+			}
+			// The block's keys are > prevSep, so the block is covered if prevSep
+			// is ≥ start.
+			if start == nil || (havePrev && r.Compare(prevSep, start) >= 0) {
+				bhp, err := iter.BlockHandleWithProperties()
+				if err != nil {
+					return false, errCorruptIndexEntry(err)
+				}
+				decoder := makeBlockPropertiesDecoder(len(shortIDToName), bhp.Props)
+				for !decoder.Done() {
+					id, prop, err := decoder.Next()
+					if err != nil {
+						return false, err
+					}
+					if i := shortIDToName[id]; i >= 0 {
+						if err := fn(i, prop); err != nil {
+							return false, err
+						}
+					}
+				}
+				if res.Count == 0 && havePrev {
+					res.After = slices.Clone(prevSep)
+				}
+				res.Count++
+				res.Through = append(res.Through[:0], sep...)
+			}
+			prevSep = append(prevSep[:0], sep...)
+			havePrev = true
+		}
+		return false, nil
+	}
+
+	seek := func(iter PI) bool {
+		if start == nil {
+			return iter.First()
+		}
+		return iter.SeekGE(start)
+	}
+	if r.Properties.IndexPartitions == 0 {
+		var iter PI = new(I)
+		if err := iter.InitHandle(r.Compare, r.Split, indexH, NoTransforms); err != nil {
+			_ = iter.Close()
+			return CoveredDataBlocks{}, err
+		}
+		defer func() { _ = iter.Close() }()
+		if _, err := visitIndex(iter, seek(iter)); err != nil {
+			return CoveredDataBlocks{}, err
+		}
+		return res, nil
+	}
+
+	var topIter PI = new(I)
+	if err := topIter.InitHandle(r.Compare, r.Split, indexH, NoTransforms); err != nil {
+		_ = topIter.Close()
+		return CoveredDataBlocks{}, err
+	}
+	defer func() { _ = topIter.Close() }()
+	first := true
+	for valid := seek(topIter); valid; valid = topIter.Next() {
+		bhp, err := topIter.BlockHandleWithProperties()
+		if err != nil {
+			return CoveredDataBlocks{}, errCorruptIndexEntry(err)
+		}
+		done, err := func() (bool, error) {
+			indexBlock, err := r.readBlock(ctx, bhp.Handle, nil /* transform */, nil, /* readHandle */
+				nil /* stats */, nil /* iterStats */, nil /* buffer pool */, cache.HighPriority)
+			if err != nil {
+				return false, err
+			}
+			var iter PI = new(I)
+			if err := iter.InitHandle(r.Compare, r.Split, indexBlock, NoTransforms); err != nil {
+				_ = iter.Close()
+				return false, err
+			}
+			defer func() { _ = iter.Close() }()
+			valid := iter.First()
+			if first {
+				valid = seek(iter)
+			}
+			return visitIndex(iter, valid)
+		}()
+		if err != nil {
+			return CoveredDataBlocks{}, err
+		} else if done {
+			break
+		}
+		first = false
+	}
+	return res, nil
+}
+
 // TableFormat returns the format version for the table.
 func (r *Reader) TableFormat() (TableFormat, error) {
 	if r.err != nil {
@@ -894,6 +1120,7 @@ func NewReader(ctx context.Context, f objstorage.Readable, o ReaderOptions) (*Re
 		readable:             f,
 		cacheOpts:            o.internal.CacheOpts,
 		keySchema:            o.KeySchema,
+		valueSchema:          o.ValueSchema,
 		loadBlockSema:        o.LoadBlockSema,
 		deniedUserProperties: o.DeniedUserProperties,
 		filterMetricsTracker: o.FilterMetricsTracker,
@@ -964,6 +1191,18 @@ func NewReader(ctx context.Context, f objstorage.Readable, o ReaderOptions) (*Re
 		}
 	}
 
+	// Tables written without a value schema have no value columns and may be
+	// read with any value schema.
+	if name := r.Properties.ValueSchemaName; name != "" && name != r.valueSchema.Name {
+		if r.valueSchema.Name == "" {
+			r.err = errors.Errorf("pebble/table: %d: value schema %s required but not configured",
+				errors.Safe(r.cacheOpts.FileNum), errors.Safe(name))
+		} else {
+			r.err = errors.Errorf("pebble/table: %d: value schema %s does not match configured value schema %s",
+				errors.Safe(r.cacheOpts.FileNum), errors.Safe(name), errors.Safe(r.valueSchema.Name))
+		}
+	}
+
 	if r.err != nil {
 		return nil, r.Close()
 	}
@@ -48,6 +48,8 @@ type CommonReader interface {
 	EstimateDiskUsage(start, end []byte) (uint64, error)
 
 	CommonProperties() *CommonProperties
+
+	TableFormat() (TableFormat, error)
 }
 
 // FilterBlockSizeLimit is a size limit for bloom filter blocks - if a bloom
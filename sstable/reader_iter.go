@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/cache"
 	"github.com/cockroachdb/pebble/internal/invariants"
 	"github.com/cockroachdb/pebble/sstable/block"
 	"github.com/cockroachdb/pebble/sstable/colblk"
@@ -58,6 +59,17 @@ type Iterator interface {
 	NextPrefix(succKey []byte) *base.InternalKV
 
 	SetCloseHook(fn func(i Iterator) error)
+
+	// SetCachePriority sets the block cache priority with which the iterator
+	// caches the data blocks it loads. Index and filter blocks are always
+	// cached with cache.HighPriority.
+	SetCachePriority(p cache.Priority)
+
+	// SetBufferPool configures the iterator to read the blocks it subsequently
+	// loads into buffers allocated from the provided pool rather than
+	// inserting them into the block cache. Blocks already resident in the
+	// block cache are still read from the cache.
+	SetBufferPool(bufferPool *block.BufferPool)
 }
 
 // Iterator positioning optimizations and singleLevelIterator and
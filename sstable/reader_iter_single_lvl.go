@@ -13,6 +13,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/cache"
 	"github.com/cockroachdb/pebble/internal/invariants"
 	"github.com/cockroachdb/pebble/internal/treeprinter"
 	"github.com/cockroachdb/pebble/objstorage"
@@ -86,6 +87,9 @@ type singleLevelIterator[I any, PI indexBlockIterator[I], D any, PD dataBlockIte
 	stats      *base.InternalIteratorStats
 	iterStats  iterStatsAccumulator
 	bufferPool *block.BufferPool
+	// cachePriority is the block cache priority of the data and value blocks
+	// loaded by the iterator.
+	cachePriority cache.Priority
 
 	// boundsCmp and positionedUsingLatestBounds are for optimizing iteration
 	// that uses multiple adjacent bounds. The seek after setting a new bound
@@ -232,6 +236,7 @@ func newColumnBlockSingleLevelIterator(
 		stats, categoryAndQoS, statsCollector, bufferPool,
 	)
 	i.data.KeySchema = r.keySchema
+	i.data.ValueSchema = r.valueSchema
 	if r.Properties.NumValueBlocks > 0 {
 		// NB: we cannot avoid this ~248 byte allocation, since valueBlockReader
 		// can outlive the singleLevelIterator due to be being embedded in a
@@ -567,7 +572,7 @@ func (i *singleLevelIterator[I, PI, P, PD]) loadBlock(dir int8) loadBlockResult
 	}
 	ctx := objiotracing.WithBlockType(i.ctx, objiotracing.DataBlock)
 	block, err := i.reader.readBlock(
-		ctx, i.dataBH, nil /* transform */, i.dataRH, i.stats, &i.iterStats, i.bufferPool)
+		ctx, i.dataBH, nil /* transform */, i.dataRH, i.stats, &i.iterStats, i.bufferPool, i.cachePriority)
 	if err != nil {
 		i.err = err
 		return loadBlockFailed
@@ -588,7 +593,7 @@ func (i *singleLevelIterator[I, PI, D, PD]) readBlockForVBR(
 	h block.Handle, stats *base.InternalIteratorStats,
 ) (block.BufferHandle, error) {
 	ctx := objiotracing.WithBlockType(i.ctx, objiotracing.ValueBlock)
-	return i.reader.readBlock(ctx, h, nil, i.vbRH, stats, &i.iterStats, i.bufferPool)
+	return i.reader.readBlock(ctx, h, nil, i.vbRH, stats, &i.iterStats, i.bufferPool, i.cachePriority)
 }
 
 // resolveMaybeExcluded is invoked when the block-property filterer has found
@@ -1570,6 +1575,18 @@ func (i *singleLevelIterator[I, PI, D, PD]) SetCloseHook(fn func(i Iterator) err
 	i.closeHook = fn
 }
 
+// SetCachePriority sets the block cache priority of the data and value blocks
+// loaded by the iterator.
+func (i *singleLevelIterator[I, PI, D, PD]) SetCachePriority(p cache.Priority) {
+	i.cachePriority = p
+}
+
+// SetBufferPool sets the buffer pool into which the iterator reads the blocks
+// it loads, bypassing the block cache.
+func (i *singleLevelIterator[I, PI, D, PD]) SetBufferPool(bufferPool *block.BufferPool) {
+	i.bufferPool = bufferPool
+}
+
 func firstError(err0, err1 error) error {
 	if err0 != nil {
 		return err0
@@ -12,6 +12,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/cache"
 	"github.com/cockroachdb/pebble/internal/invariants"
 	"github.com/cockroachdb/pebble/internal/treeprinter"
 	"github.com/cockroachdb/pebble/objstorage"
@@ -71,7 +72,7 @@ func (i *twoLevelIterator[I, PI, D, PD]) loadIndex(dir int8) loadBlockResult {
 	}
 	ctx := objiotracing.WithBlockType(i.secondLevel.ctx, objiotracing.MetadataBlock)
 	indexBlock, err := i.secondLevel.reader.readBlock(
-		ctx, bhp.Handle, nil /* transform */, i.secondLevel.indexFilterRH, i.secondLevel.stats, &i.secondLevel.iterStats, i.secondLevel.bufferPool)
+		ctx, bhp.Handle, nil /* transform */, i.secondLevel.indexFilterRH, i.secondLevel.stats, &i.secondLevel.iterStats, i.secondLevel.bufferPool, cache.HighPriority)
 	if err == nil {
 		err = PI(&i.secondLevel.index).InitHandle(i.secondLevel.cmp, i.secondLevel.reader.Split, indexBlock, i.secondLevel.transforms)
 	}
@@ -196,6 +197,7 @@ func newColumnBlockTwoLevelIterator(
 		i.secondLevel.vbRH = objstorageprovider.UsePreallocatedReadHandle(r.readable, objstorage.NoReadBefore, &i.secondLevel.vbRHPrealloc)
 	}
 	i.secondLevel.data.KeySchema = r.keySchema
+	i.secondLevel.data.ValueSchema = r.valueSchema
 	i.useFilterBlock = shouldUseFilterBlock(r, filterBlockSizeLimit)
 	topLevelIndexH, err := r.readIndex(ctx, i.secondLevel.indexFilterRH, stats, &i.secondLevel.iterStats)
 	if err == nil {
@@ -1037,6 +1039,14 @@ func (i *twoLevelIterator[I, PI, D, PD]) SetCloseHook(fn func(i Iterator) error)
 	i.secondLevel.SetCloseHook(fn)
 }
 
+func (i *twoLevelIterator[I, PI, D, PD]) SetCachePriority(p cache.Priority) {
+	i.secondLevel.SetCachePriority(p)
+}
+
+func (i *twoLevelIterator[I, PI, D, PD]) SetBufferPool(bufferPool *block.BufferPool) {
+	i.secondLevel.SetBufferPool(bufferPool)
+}
+
 func (i *twoLevelIterator[I, PI, D, PD]) SetupForCompaction() {
 	i.secondLevel.SetupForCompaction()
 }
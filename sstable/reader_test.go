@@ -635,7 +635,7 @@ func indexLayoutString(t *testing.T, r *Reader) string {
 		require.NoError(t, err)
 		fmt.Fprintf(&buf, " %s: size %d\n", string(iter.Separator()), bh.Length)
 		if twoLevelIndex {
-			b, err := r.readBlock(context.Background(), bh.Handle, nil, nil, nil, nil, nil)
+			b, err := r.readBlock(context.Background(), bh.Handle, nil, nil, nil, nil, nil, cache.NormalPriority)
 			require.NoError(t, err)
 			defer b.Release()
 			iter2 := r.tableFormat.newIndexIter()
@@ -1584,6 +1584,9 @@ func TestValidateBlockChecksums(t *testing.T) {
 
 		// Prior to corruption, validation is successful.
 		require.NoError(t, r.ValidateBlockChecksums())
+		corrupt, err := r.FindCorruptBlocks(context.Background(), nil /* pace */)
+		require.NoError(t, err)
+		require.Empty(t, corrupt)
 
 		// If we are not testing for corruption, we can stop here.
 		if len(corruptionLocations) == 0 {
@@ -1593,6 +1596,7 @@ func TestValidateBlockChecksums(t *testing.T) {
 		// Perform bit flips in various corruption locations.
 		layout, err := r.Layout()
 		require.NoError(t, err)
+		corrupted := make(map[block.Handle]bool)
 		for _, location := range corruptionLocations {
 			var bh block.Handle
 			switch location {
@@ -1615,6 +1619,7 @@ func TestValidateBlockChecksums(t *testing.T) {
 			}
 
 			// Corrupt a random byte within the selected block.
+			corrupted[bh] = true
 			pos := int64(bh.Offset) + rng.Int63n(int64(bh.Length))
 			t.Logf("altering file=%s @ offset = %d", file, pos)
 
@@ -1639,6 +1644,20 @@ func TestValidateBlockChecksums(t *testing.T) {
 		err = r.ValidateBlockChecksums()
 		require.Error(t, err)
 		require.Regexp(t, `checksum mismatch`, err.Error())
+
+		// FindCorruptBlocks reports each of the corrupted blocks, unless the
+		// layout of the table can no longer be decoded.
+		corrupt, err = r.FindCorruptBlocks(context.Background(), nil /* pace */)
+		if err != nil {
+			require.Regexp(t, `checksum mismatch`, err.Error())
+			return
+		}
+		found := make(map[block.Handle]bool)
+		for _, b := range corrupt {
+			require.Regexp(t, `checksum mismatch`, b.Err.Error())
+			found[b.Handle] = true
+		}
+		require.Equal(t, corrupted, found)
 	}
 
 	for _, tc := range testCases {
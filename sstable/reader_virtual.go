@@ -134,6 +134,15 @@ func (v *VirtualReader) ValidateBlockChecksumsOnBacking() error {
 	return v.reader.ValidateBlockChecksums()
 }
 
+// FindCorruptBlocksOnBacking wraps Reader.FindCorruptBlocks. Note that, as for
+// ValidateBlockChecksumsOnBacking, the blocks are NOT restricted to the virtual
+// sstable bounds.
+func (v *VirtualReader) FindCorruptBlocksOnBacking(
+	ctx context.Context, pace func(length uint64) error,
+) ([]CorruptBlock, error) {
+	return v.reader.FindCorruptBlocks(ctx, pace)
+}
+
 // NewRawRangeDelIter wraps Reader.NewRawRangeDelIter.
 func (v *VirtualReader) NewRawRangeDelIter(
 	ctx context.Context, transforms FragmentIterTransforms,
@@ -261,3 +270,8 @@ func (v *VirtualReader) EstimateDiskUsage(start, end []byte) (uint64, error) {
 func (v *VirtualReader) CommonProperties() *CommonProperties {
 	return &v.Properties
 }
+
+// TableFormat implements the CommonReader interface.
+func (v *VirtualReader) TableFormat() (TableFormat, error) {
+	return v.reader.TableFormat()
+}
@@ -1755,6 +1755,7 @@ func (w *RawRowWriter) Close() (err error) {
 		return err
 	}
 	w.meta.Properties = w.props
+	w.meta.TableFormat = w.tableFormat
 
 	// Check that the features present in the table are compatible with the format
 	// configured for the table.
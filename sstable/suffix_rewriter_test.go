@@ -71,7 +71,7 @@ func TestRewriteSuffixProps(t *testing.T) {
 
 			var sstBytes [2][]byte
 			adjustPropsForEffectiveFormat := func(effectiveFormat TableFormat) {
-				if effectiveFormat == TableFormatPebblev4 {
+				if effectiveFormat >= TableFormatPebblev4 {
 					expectedProps["obsolete-key"] = string([]byte{3})
 				} else {
 					delete(expectedProps, "obsolete-key")
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/bloom"
 	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/cache"
 	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
 	"github.com/cockroachdb/pebble/sstable/block"
 	"github.com/cockroachdb/pebble/sstable/rowblk"
@@ -533,7 +534,7 @@ func TestMetaIndexEntriesSorted(t *testing.T) {
 	require.NoError(t, err)
 
 	b, err := r.readBlock(
-		context.Background(), r.metaIndexBH, nil, nil, nil, nil, nil)
+		context.Background(), r.metaIndexBH, nil, nil, nil, nil, nil, cache.NormalPriority)
 	require.NoError(t, err)
 	defer b.Release()
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/cache"
 	"github.com/cockroachdb/pebble/internal/invariants"
 	"github.com/cockroachdb/pebble/objstorage/objstorageprovider/objiotracing"
 	"github.com/cockroachdb/pebble/sstable/block"
@@ -691,7 +692,7 @@ func (bpwc blockProviderWhenClosed) readBlockForVBR(
 	// The bpwc is not allowed to outlive the iterator tree, so it cannot
 	// outlive the buffer pool.
 	return bpwc.r.readBlock(
-		ctx, h, nil, nil, stats, nil /* iterStats */, nil /* buffer pool */)
+		ctx, h, nil, nil, stats, nil /* iterStats */, nil /* buffer pool */, cache.NormalPriority)
 }
 
 // ReaderProvider supports the implementation of blockProviderWhenClosed.
@@ -343,6 +343,8 @@ type WriterMetadata struct {
 	SmallestSeqNum   base.SeqNum
 	LargestSeqNum    base.SeqNum
 	Properties       Properties
+	// TableFormat is the format the table was written in.
+	TableFormat TableFormat
 }
 
 // SetSmallestPointKey sets the smallest point key to the given key.
@@ -105,6 +105,26 @@ type tableCacheOpts struct {
 	objProvider       objstorage.Provider
 	readerOpts        sstable.ReaderOptions
 	sstStatsCollector *sstable.CategoryStatsCollector
+	pinnedSpans       []KeyRange
+}
+
+// cachePriority returns the block cache priority with which the data blocks of
+// the provided file should be cached by an iterator with the provided options.
+func (o *tableCacheOpts) cachePriority(
+	opts *IterOptions, file *manifest.FileMetadata,
+) cache.Priority {
+	if len(o.pinnedSpans) > 0 {
+		cmp := o.readerOpts.Comparer.Compare
+		for i := range o.pinnedSpans {
+			if o.pinnedSpans[i].Overlaps(cmp, file) {
+				return cache.HighPriority
+			}
+		}
+	}
+	if opts != nil {
+		return opts.CachePriority
+	}
+	return cache.NormalPriority
 }
 
 // tableCacheContainer contains the table cache and
@@ -149,6 +169,7 @@ func newTableCacheContainer(
 	t.dbOpts.readerOpts.FilterMetricsTracker = &sstable.FilterMetricsTracker{}
 	t.dbOpts.iterCount = new(atomic.Int32)
 	t.dbOpts.sstStatsCollector = sstStatsCollector
+	t.dbOpts.pinnedSpans = opts.PinnedSpans
 	return t
 }
 
@@ -571,6 +592,9 @@ func (c *tableCacheShard) newPointIter(
 	}
 	transforms := file.IterTransforms()
 	transforms.HideObsoletePoints = hideObsoletePoints
+	if opts != nil {
+		transforms.Projection = opts.Projection
+	}
 	var categoryAndQoS sstable.CategoryAndQoS
 	if opts != nil {
 		categoryAndQoS = opts.CategoryAndQoS
@@ -583,10 +607,16 @@ func (c *tableCacheShard) newPointIter(
 		iter, err = cr.NewPointIter(
 			ctx, transforms, opts.GetLowerBound(), opts.GetUpperBound(), filterer, filterBlockSizeLimit,
 			internalOpts.stats, categoryAndQoS, dbOpts.sstStatsCollector, rp)
+		if err == nil && internalOpts.bufferPool != nil {
+			iter.SetBufferPool(internalOpts.bufferPool)
+		}
 	}
 	if err != nil {
 		return nil, err
 	}
+	if priority := dbOpts.cachePriority(opts, file); priority != cache.NormalPriority {
+		iter.SetCachePriority(priority)
+	}
 	// NB: v.closeHook takes responsibility for calling unrefValue(v) here. Take
 	// care to avoid introducing an allocation here by adding a closure.
 	iter.SetCloseHook(v.closeHook)
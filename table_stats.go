@@ -312,6 +312,9 @@ func (d *DB) loadTableStats(
 			stats.NumRangeKeySets = props.NumRangeKeySets
 			stats.ValueBlocksSize = props.ValueBlocksSize
 			stats.CompressionType = block.CompressionFromString(props.CompressionName)
+			if stats.TableFormat, err = r.TableFormat(); err != nil {
+				return
+			}
 			stats.TombstoneDenseBlocksRatio = float64(props.NumTombstoneDenseBlocks) / float64(props.NumDataBlocks)
 
 			if props.NumPointDeletions() > 0 {
@@ -638,7 +641,9 @@ func (d *DB) estimateReclaimedSizeBeneath(
 	return estimate, hintSeqNum, nil
 }
 
-func maybeSetStatsFromProperties(meta physicalMeta, props *sstable.Properties) bool {
+func maybeSetStatsFromProperties(
+	meta physicalMeta, props *sstable.Properties, format sstable.TableFormat,
+) bool {
 	// If a table contains range deletions or range key deletions, we defer the
 	// stats collection. There are two main reasons for this:
 	//
@@ -681,6 +686,7 @@ func maybeSetStatsFromProperties(meta physicalMeta, props *sstable.Properties) b
 	meta.Stats.RangeDeletionsBytesEstimate = 0
 	meta.Stats.ValueBlocksSize = props.ValueBlocksSize
 	meta.Stats.CompressionType = block.CompressionFromString(props.CompressionName)
+	meta.Stats.TableFormat = format
 	meta.StatsMarkValid()
 	return true
 }
@@ -1064,3 +1070,47 @@ func (a compressionTypeAggregator) Merge(
 	dst.unknown += src.unknown
 	return dst
 }
+
+// tableFormatAnnotator is a manifest.Annotator that annotates B-tree nodes
+// with the count and total size of files written in each table format. Its
+// annotation type is tableFormatCounts. A file's table format is only known
+// once its stats have been loaded, so its values are marked as cacheable only
+// if a file's stats have been loaded.
+var tableFormatAnnotator = manifest.Annotator[tableFormatCounts]{
+	Aggregator: tableFormatAggregator{},
+}
+
+type tableFormatAggregator struct{}
+
+type tableFormatCounts [sstable.NumTableFormats]struct {
+	count, size uint64
+}
+
+func (a tableFormatAggregator) Zero(dst *tableFormatCounts) *tableFormatCounts {
+	if dst == nil {
+		return new(tableFormatCounts)
+	}
+	*dst = tableFormatCounts{}
+	return dst
+}
+
+func (a tableFormatAggregator) Accumulate(
+	f *fileMetadata, dst *tableFormatCounts,
+) (v *tableFormatCounts, cacheOK bool) {
+	if !f.StatsValid() {
+		return dst, false
+	}
+	dst[f.Stats.TableFormat].count++
+	dst[f.Stats.TableFormat].size += f.Size
+	return dst, true
+}
+
+func (a tableFormatAggregator) Merge(
+	src *tableFormatCounts, dst *tableFormatCounts,
+) *tableFormatCounts {
+	for i := range src {
+		dst[i].count += src[i].count
+		dst[i].size += src[i].size
+	}
+	return dst
+}
@@ -86,6 +86,30 @@ func runTests(t *testing.T, path string) {
 					if err != nil {
 						d.Fatalf(t, "%v", err)
 					}
+					// Apply the operations in the input, if any, one per line.
+					for _, line := range strings.Split(d.Input, "\n") {
+						fields := strings.Fields(line)
+						if len(fields) == 0 {
+							continue
+						}
+						switch {
+						case fields[0] == "flush":
+							err = db.Flush()
+						case fields[0] == "set" && len(fields) == 3:
+							err = db.Set([]byte(fields[1]), []byte(fields[2]), nil)
+						case fields[0] == "range-key-set" && len(fields) == 5:
+							err = db.RangeKeySet([]byte(fields[1]), []byte(fields[2]), []byte(fields[3]), []byte(fields[4]), nil)
+						case fields[0] == "range-key-unset" && len(fields) == 4:
+							err = db.RangeKeyUnset([]byte(fields[1]), []byte(fields[2]), []byte(fields[3]), nil)
+						case fields[0] == "range-key-del" && len(fields) == 3:
+							err = db.RangeKeyDelete([]byte(fields[1]), []byte(fields[2]), nil)
+						default:
+							d.Fatalf(t, "unknown operation: %s", line)
+						}
+						if err != nil {
+							d.Fatalf(t, "%v", err)
+						}
+					}
 					db.Close()
 					return ""
 				}
@@ -36,9 +36,12 @@ type dbT struct {
 	Check      *cobra.Command
 	Checkpoint *cobra.Command
 	Get        *cobra.Command
+	History    *cobra.Command
+	HotRanges  *cobra.Command
 	Logs       *cobra.Command
 	LSM        *cobra.Command
 	Properties *cobra.Command
+	Repair     *cobra.Command
 	Scan       *cobra.Command
 	Set        *cobra.Command
 	Space      *cobra.Command
@@ -68,6 +71,7 @@ type dbT struct {
 	verbose       bool
 	bypassPrompt  bool
 	lsmURL        bool
+	lsmServe      string
 }
 
 func newDB(
@@ -125,6 +129,37 @@ process.
 		Args: cobra.ExactArgs(2),
 		Run:  d.runGet,
 	}
+	d.History = &cobra.Command{
+		Use:   "history <dir> <key>",
+		Short: "print every version of a key",
+		Long: `
+Print every internal version of a key (SET, MERGE, DEL, SINGLEDEL and the range
+deletions covering the key) found in the WALs and sstables, from newest to
+oldest. For each version, list the files containing it along with whether each
+file is in the memtable, in a level of the LSM (possibly through a virtual
+sstable) or obsolete, and the range of snapshot sequence numbers which would
+see the version. The versions of the range keys covering the key (RANGEKEYSET,
+RANGEKEYUNSET and RANGEKEYDEL) are listed separately in the same manner, since
+they do not shadow point keys. Then print the current state of the key as seen
+by the DB, including the range keys covering it. Requires that the specified
+database not be in use by another process.
+`,
+		Args: cobra.ExactArgs(2),
+		Run:  d.runHistory,
+	}
+	d.HotRanges = &cobra.Command{
+		Use:   "hot-ranges <dir> [<key>...]",
+		Short: "print sstables and keys receiving the most reads",
+		Long: `
+Open the DB with hot range tracking enabled, read each of the specified keys
+and, if --start or --end is specified, scan the corresponding range. Then print
+the sstables and key prefixes that served the most sampled reads. Gets are
+always sampled; scans are sampled roughly once per 64KB read. Requires that the
+specified database not be in use by another process.
+`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  d.runHotRanges,
+	}
 	d.Logs = logs.NewCmd()
 	d.LSM = &cobra.Command{
 		Use:   "lsm <dir>",
@@ -132,6 +167,12 @@ process.
 		Long: `
 Print the structure of the LSM tree. Requires that the specified database not
 be in use by another process.
+
+With --serve, also serve an interactive LSM explorer over HTTP (by default on
+localhost:8080) until interrupted. The explorer shows the levels, L0
+sublevels, tables (including virtual tables) and in-progress compactions, and
+steps through the history of the LSM recorded in the MANIFEST. All of its
+assets are embedded, so it does not require network access.
 `,
 		Args: cobra.ExactArgs(1),
 		Run:  d.runLSM,
@@ -145,6 +186,29 @@ Print SSTable properties, aggregated per level of the LSM.
 		Args: cobra.ExactArgs(1),
 		Run:  d.runProperties,
 	}
+	d.Repair = &cobra.Command{
+		Use:   "repair <dir>",
+		Short: "rebuild a missing or corrupted MANIFEST",
+		Long: `
+Rebuild the MANIFEST of a DB whose MANIFEST is missing or corrupted. Every
+sstable in the directory is read in full, verifying its checksums and key
+ordering, and the readable sstables are placed into levels such that newer
+versions of a key are above older ones; the repair fails if overlapping
+sstables cannot be ordered. The WALs which the readable prefix of the old
+MANIFESTs does not record as flushed are kept to be replayed when the DB is
+opened; a corrupted WAL is truncated to its readable prefix. Unreadable
+sstables, corrupted WALs and the old MANIFESTs are moved to the quarantine
+subdirectory, and the data lost is reported. Does nothing if the current
+MANIFEST is intact.
+
+The virtual sstables recorded in the old MANIFEST cannot be recovered, so an
+sstable backing virtual sstables is restored in full, including any excised
+keys. Sstables on shared or external storage are not recovered. Requires that
+the specified database not be in use by another process.
+`,
+		Args: cobra.ExactArgs(1),
+		Run:  d.runRepair,
+	}
 	d.Scan = &cobra.Command{
 		Use:   "scan <dir>",
 		Short: "print db records",
@@ -197,29 +261,42 @@ specified database.
 		Run:  d.runIOBench,
 	}
 
-	d.Root.AddCommand(d.Check, d.Checkpoint, d.Get, d.Logs, d.LSM, d.Properties, d.Scan, d.Set, d.Space, d.Excise, d.IOBench)
+	d.Root.AddCommand(d.Check, d.Checkpoint, d.Get, d.History, d.HotRanges, d.Logs, d.LSM, d.Properties, d.Repair, d.Scan, d.Set, d.Space, d.Excise, d.IOBench)
 	d.Root.PersistentFlags().BoolVarP(&d.verbose, "verbose", "v", false, "verbose output")
 
-	for _, cmd := range []*cobra.Command{d.Check, d.Checkpoint, d.Get, d.LSM, d.Properties, d.Scan, d.Set, d.Space, d.Excise} {
+	for _, cmd := range []*cobra.Command{d.Check, d.Checkpoint, d.Get, d.History, d.HotRanges, d.LSM, d.Properties, d.Repair, d.Scan, d.Set, d.Space, d.Excise} {
 		cmd.Flags().StringVar(
 			&d.comparerName, "comparer", "", "comparer name (use default if empty)")
 		cmd.Flags().StringVar(
 			&d.mergerName, "merger", "", "merger name (use default if empty)")
 	}
 
-	for _, cmd := range []*cobra.Command{d.Scan, d.Get} {
+	for _, cmd := range []*cobra.Command{d.Scan, d.Get, d.History} {
 		cmd.Flags().Var(
 			&d.fmtValue, "value", "value formatter")
 	}
 
 	d.LSM.Flags().BoolVar(
 		&d.lsmURL, "url", false, "generate LSM viewer URL")
+	d.LSM.Flags().StringVar(
+		&d.lsmServe, "serve", "", "serve an interactive LSM explorer over HTTP on the given address")
+	d.LSM.Flags().Lookup("serve").NoOptDefVal = "localhost:8080"
 
 	d.Space.Flags().Var(
 		&d.start, "start", "start key for the range")
 	d.Space.Flags().Var(
 		&d.end, "end", "inclusive end key for the range")
 
+	d.History.Flags().Var(
+		&d.fmtKey, "key", "key formatter")
+
+	d.HotRanges.Flags().Var(
+		&d.fmtKey, "key", "key formatter")
+	d.HotRanges.Flags().Var(
+		&d.start, "start", "start key for the scanned range")
+	d.HotRanges.Flags().Var(
+		&d.end, "end", "exclusive end key for the scanned range")
+
 	d.Scan.Flags().Var(
 		&d.fmtKey, "key", "key formatter")
 	d.Scan.Flags().Var(
@@ -236,6 +313,9 @@ specified database.
 	d.Excise.Flags().BoolVar(
 		&d.bypassPrompt, "yes", false, "bypass prompt")
 
+	d.Repair.Flags().BoolVar(
+		&d.bypassPrompt, "yes", false, "bypass prompt")
+
 	d.IOBench.Flags().BoolVar(
 		&d.allLevels, "all-levels", false, "if set, benchmark all levels (default is only L5/L6)")
 	d.IOBench.Flags().IntVar(
@@ -440,6 +520,71 @@ func (d *dbT) runGet(cmd *cobra.Command, args []string) {
 	}
 }
 
+type hotRangeTracking struct{}
+
+func (hotRangeTracking) Apply(dirname string, opts *pebble.Options) {
+	opts.Experimental.HotRangeSamplingPeriod = 1
+	opts.Experimental.ReadSamplingMultiplier = 1
+}
+
+func (d *dbT) runHotRanges(cmd *cobra.Command, args []string) {
+	stdout, stderr := cmd.OutOrStdout(), cmd.ErrOrStderr()
+	db, err := d.openDB(args[0], hotRangeTracking{})
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n", err)
+		return
+	}
+	defer d.closeDB(stderr, db)
+
+	// Update the internal formatter if this comparator has one specified.
+	if d.opts.Comparer != nil {
+		d.fmtKey.setForComparer(d.opts.Comparer.Name, d.comparers)
+	}
+
+	for _, arg := range args[1:] {
+		var k key
+		if err := k.Set(arg); err != nil {
+			fmt.Fprintf(stderr, "%s\n", err)
+			return
+		}
+		_, closer, err := db.Get(k)
+		if err != nil && !errors.Is(err, pebble.ErrNotFound) {
+			fmt.Fprintf(stderr, "%s\n", err)
+			return
+		}
+		if closer != nil {
+			closer.Close()
+		}
+	}
+	if d.start != nil || d.end != nil {
+		iter, err := db.NewIter(&pebble.IterOptions{UpperBound: d.end})
+		if err != nil {
+			fmt.Fprintf(stderr, "%s\n", err)
+			return
+		}
+		for valid := iter.SeekGE(d.start); valid; valid = iter.Next() {
+		}
+		if err := iter.Close(); err != nil {
+			fmt.Fprintf(stderr, "%s\n", err)
+			return
+		}
+	}
+
+	hot := db.HotRanges()
+	tw := tabwriter.NewWriter(stdout, 2, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "level\tfile\tsamples\tbounds\n")
+	for _, t := range hot.Tables {
+		fmt.Fprintf(tw, "L%d\t%s\t%d\t[%s-%s]\n", t.Level, t.FileNum, t.Samples,
+			d.fmtKey.fn(t.Smallest), d.fmtKey.fn(t.Largest))
+	}
+	tw.Flush()
+	fmt.Fprintf(tw, "\nprefix\tsamples\n")
+	for _, k := range hot.Keys {
+		fmt.Fprintf(tw, "%s\t%d\n", d.fmtKey.fn(k.Prefix), k.Samples)
+	}
+	tw.Flush()
+}
+
 func (d *dbT) runLSM(cmd *cobra.Command, args []string) {
 	stdout, stderr := cmd.OutOrStdout(), cmd.ErrOrStderr()
 	db, err := d.openDB(args[0])
@@ -453,6 +598,11 @@ func (d *dbT) runLSM(cmd *cobra.Command, args []string) {
 	if d.lsmURL {
 		fmt.Fprintf(stdout, "\nLSM viewer: %s\n", db.LSMViewURL())
 	}
+	if d.lsmServe != "" {
+		if err := d.serveLSMExplorer(stdout, args[0], db); err != nil {
+			fmt.Fprintf(stderr, "%s\n", err)
+		}
+	}
 }
 
 func (d *dbT) runScan(cmd *cobra.Command, args []string) {
@@ -477,6 +627,8 @@ func (d *dbT) runScan(cmd *cobra.Command, args []string) {
 
 	iter, _ := db.NewIter(&pebble.IterOptions{
 		UpperBound: d.end,
+		// A scan reads every block once; don't let it flush the block cache.
+		BypassCache: true,
 	})
 	for valid := iter.SeekGE(d.start); valid; valid = iter.Next() {
 		if fmtKeys || fmtValues {
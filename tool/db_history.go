@@ -0,0 +1,424 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package tool
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/keyspan"
+	"github.com/cockroachdb/pebble/internal/rangekey"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/spf13/cobra"
+)
+
+// keyVersion is a single internal version of a key: a point key, or a range
+// deletion covering the key. The same version can be found in several files,
+// e.g. in a WAL and in the table it was flushed to.
+type keyVersion struct {
+	key   base.InternalKey
+	value []byte
+	// locations describes each of the files in which the version was found.
+	locations []string
+	// live is true if the version is in the memtable or in a table in the LSM.
+	live bool
+}
+
+func (v *keyVersion) isRangeDel() bool {
+	return v.key.Kind() == base.InternalKeyKindRangeDelete
+}
+
+func (d *dbT) runHistory(cmd *cobra.Command, args []string) {
+	stdout, stderr := cmd.OutOrStdout(), cmd.ErrOrStderr()
+	var searchKey key
+	if err := searchKey.Set(args[1]); err != nil {
+		fmt.Fprintf(stderr, "%s\n", err)
+		return
+	}
+
+	// Find all of the versions of the key in the WALs and tables, including
+	// obsolete ones, using the machinery of the find tool.
+	f := &findT{
+		opts:         d.opts,
+		comparers:    d.comparers,
+		mergers:      d.mergers,
+		comparerName: d.comparerName,
+		fmtKey:       d.fmtKey,
+		fmtValue:     d.fmtValue,
+		rangeKeys:    true,
+	}
+	if f.comparerName == "" {
+		f.comparerName = base.DefaultComparer.Name
+	}
+	if err := f.findFiles(stdout, stderr, args[0]); err != nil {
+		fmt.Fprintf(stderr, "%s\n", err)
+		return
+	}
+	if err := f.load(stdout); err != nil {
+		fmt.Fprintf(stderr, "%s\n", err)
+		return
+	}
+	refs := f.search(stdout, searchKey)
+	versions := d.historyVersions(f, searchKey, refs)
+	rangeKeyVersions, err := d.historyRangeKeyVersions(f, searchKey, refs)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n", err)
+		return
+	}
+
+	if len(versions) == 0 {
+		fmt.Fprintf(stdout, "no versions found\n")
+	} else {
+		fmt.Fprintf(stdout, "%d %s\n", len(versions), makePlural("version", int64(len(versions))))
+	}
+	// A snapshot at sequence number s sees the newest live version with a
+	// sequence number below s. Walking the versions from newest to oldest, a
+	// version at sequence number q is seen by the snapshots in [q+1, p], where
+	// p is the sequence number of the next newer live version.
+	var newer base.SeqNum
+	haveNewer := false
+	for i := range versions {
+		v := &versions[i]
+		formatKeyValue(stdout, f.fmtKey, f.fmtValue, &v.key, v.value)
+		for _, loc := range v.locations {
+			fmt.Fprintf(stdout, "    %s\n", loc)
+		}
+		seqNum := v.key.SeqNum()
+		switch {
+		case !v.live:
+			fmt.Fprintf(stdout, "    not visible: no longer in the DB\n")
+			continue
+		case haveNewer && seqNum >= newer:
+			// A range deletion written at the same sequence number as a point
+			// key (by an ingestion) does not delete the point key.
+			fmt.Fprintf(stdout, "    not visible: shadowed at the same sequence number\n")
+			continue
+		case !haveNewer:
+			fmt.Fprintf(stdout, "    visible to snapshots >= #%d%s\n", seqNum+1, historyEffect(v))
+		case seqNum+1 == newer:
+			fmt.Fprintf(stdout, "    visible to snapshot #%d%s\n", newer, historyEffect(v))
+		default:
+			fmt.Fprintf(stdout, "    visible to snapshots #%d-#%d%s\n", seqNum+1, newer, historyEffect(v))
+		}
+		newer, haveNewer = seqNum, true
+	}
+
+	if len(rangeKeyVersions) > 0 {
+		printRangeKeyVersions(stdout, f, rangeKeyVersions)
+	}
+
+	// Print the current state of the key as seen by the DB.
+	db, err := d.openDB(args[0])
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n", err)
+		return
+	}
+	defer d.closeDB(stderr, db)
+	d.printCurrentState(stdout, stderr, db, f, searchKey)
+}
+
+// historyEffect describes the effect of a version on the key, as seen by the
+// snapshots which see the version.
+func historyEffect(v *keyVersion) string {
+	switch v.key.Kind() {
+	case base.InternalKeyKindDelete, base.InternalKeyKindDeleteSized, base.InternalKeyKindSingleDelete:
+		return " (deleted)"
+	case base.InternalKeyKindRangeDelete:
+		return " (deleted by range deletion)"
+	case base.InternalKeyKindMerge:
+		return " (merged with older versions)"
+	}
+	return ""
+}
+
+// historyVersions groups the references to the search key found by the find
+// tool into versions, ordered from newest to oldest, and determines where each
+// version lives.
+func (d *dbT) historyVersions(f *findT, searchKey []byte, refs []findRef) []keyVersion {
+	minUnflushedLogNum := f.minUnflushedLogNum()
+	var versions []keyVersion
+	index := make(map[base.InternalKeyTrailer]int)
+	for i := range refs {
+		r := &refs[i]
+		if rangekey.IsRangeKey(r.key.Kind()) {
+			continue
+		}
+		if r.key.Kind() == base.InternalKeyKindRangeDelete {
+			// The find tool also reports range deletions that end at the search
+			// key in the WALs.
+			if f.opts.Comparer.Compare(searchKey, r.value) >= 0 {
+				continue
+			}
+		}
+		j, ok := index[r.key.Trailer]
+		if !ok {
+			j = len(versions)
+			index[r.key.Trailer] = j
+			versions = append(versions, keyVersion{key: r.key, value: r.value})
+		}
+		v := &versions[j]
+		loc, live := f.refLocation(r, searchKey, minUnflushedLogNum)
+		v.locations = append(v.locations, fmt.Sprintf("%s: %s", r.filename, loc))
+		v.live = v.live || live
+	}
+
+	// Order the versions from newest to oldest. At the same sequence number, a
+	// point key sorts before a range deletion, which does not delete it.
+	slices.SortStableFunc(versions, func(a, b keyVersion) int {
+		if c := cmp.Compare(b.key.SeqNum(), a.key.SeqNum()); c != 0 {
+			return c
+		}
+		switch {
+		case a.isRangeDel() == b.isRangeDel():
+			return 0
+		case b.isRangeDel():
+			return -1
+		}
+		return +1
+	})
+	return versions
+}
+
+// rangeKeyVersion is a single internal version of a range key covering the
+// search key: a RANGEKEYSET or RANGEKEYUNSET of a single suffix, or a
+// RANGEKEYDEL.
+type rangeKeyVersion struct {
+	// span holds the bounds of the range key and its single key. The bounds are
+	// those of the first fragment found; fragments of the same range key in
+	// different files may have different bounds.
+	span keyspan.Span
+	// locations describes each of the files in which the version was found.
+	locations []string
+	// live is true if the version is in the memtable or in a table in the LSM.
+	live bool
+}
+
+// historyRangeKeyVersions groups the range keys found by the find tool into
+// versions, ordered from newest to oldest, and determines where each version
+// lives. Range keys do not shadow point keys, so they are listed separately.
+func (d *dbT) historyRangeKeyVersions(
+	f *findT, searchKey []byte, refs []findRef,
+) ([]rangeKeyVersion, error) {
+	type versionKey struct {
+		trailer base.InternalKeyTrailer
+		suffix  string
+	}
+	minUnflushedLogNum := f.minUnflushedLogNum()
+	var versions []rangeKeyVersion
+	index := make(map[versionKey]int)
+	for i := range refs {
+		r := &refs[i]
+		if !rangekey.IsRangeKey(r.key.Kind()) {
+			continue
+		}
+		s, err := rangekey.Decode(r.key, r.value, nil)
+		if err != nil {
+			return nil, err
+		}
+		loc, live := f.refLocation(r, searchKey, minUnflushedLogNum)
+		for _, k := range s.Keys {
+			vk := versionKey{trailer: k.Trailer, suffix: string(k.Suffix)}
+			j, ok := index[vk]
+			if !ok {
+				j = len(versions)
+				index[vk] = j
+				versions = append(versions, rangeKeyVersion{
+					span: keyspan.Span{Start: s.Start, End: s.End, Keys: []keyspan.Key{k}},
+				})
+			}
+			v := &versions[j]
+			v.locations = append(v.locations, fmt.Sprintf("%s: %s", r.filename, loc))
+			v.live = v.live || live
+		}
+	}
+	slices.SortStableFunc(versions, func(a, b rangeKeyVersion) int {
+		return cmp.Compare(b.span.Keys[0].SeqNum(), a.span.Keys[0].SeqNum())
+	})
+	return versions, nil
+}
+
+// printRangeKeyVersions prints the range key versions, from newest to oldest,
+// along with the range of snapshot sequence numbers which see each version. A
+// RANGEKEYSET or RANGEKEYUNSET is seen until a newer live version of the same
+// suffix or a newer live RANGEKEYDEL; a RANGEKEYDEL is seen until a newer live
+// RANGEKEYDEL.
+func printRangeKeyVersions(w io.Writer, f *findT, versions []rangeKeyVersion) {
+	fmt.Fprintf(w, "%d range key %s\n", len(versions), makePlural("version", int64(len(versions))))
+	var newerDel base.SeqNum
+	newerSuffix := make(map[string]base.SeqNum)
+	for i := range versions {
+		v := &versions[i]
+		k := &v.span.Keys[0]
+		fmt.Fprintf(w, "%s-%s#%d,%s", f.fmtKey.fn(v.span.Start), f.fmtKey.fn(v.span.End), k.SeqNum(), k.Kind())
+		switch k.Kind() {
+		case base.InternalKeyKindRangeKeySet:
+			fmt.Fprintf(w, " %s %s", k.Suffix, f.fmtValue.fn(v.span.Start, k.Value))
+		case base.InternalKeyKindRangeKeyUnset:
+			fmt.Fprintf(w, " %s", k.Suffix)
+		}
+		fmt.Fprintf(w, "\n")
+		for _, loc := range v.locations {
+			fmt.Fprintf(w, "    %s\n", loc)
+		}
+		if !v.live {
+			fmt.Fprintf(w, "    not visible: no longer in the DB\n")
+			continue
+		}
+		newer := newerDel
+		if k.Kind() != base.InternalKeyKindRangeKeyDelete {
+			if seqNum, ok := newerSuffix[string(k.Suffix)]; ok && (newer == 0 || seqNum < newer) {
+				newer = seqNum
+			}
+		}
+		seqNum := k.SeqNum()
+		switch {
+		case newer == 0:
+			fmt.Fprintf(w, "    visible to snapshots >= #%d%s\n", seqNum+1, rangeKeyHistoryEffect(k))
+		case seqNum+1 == newer:
+			fmt.Fprintf(w, "    visible to snapshot #%d%s\n", newer, rangeKeyHistoryEffect(k))
+		default:
+			fmt.Fprintf(w, "    visible to snapshots #%d-#%d%s\n", seqNum+1, newer, rangeKeyHistoryEffect(k))
+		}
+		if k.Kind() == base.InternalKeyKindRangeKeyDelete {
+			newerDel = seqNum
+		} else {
+			newerSuffix[string(k.Suffix)] = seqNum
+		}
+	}
+}
+
+// rangeKeyHistoryEffect describes the effect of a range key version on the
+// range keys covering the search key.
+func rangeKeyHistoryEffect(k *keyspan.Key) string {
+	switch k.Kind() {
+	case base.InternalKeyKindRangeKeyUnset:
+		return " (unset)"
+	case base.InternalKeyKindRangeKeyDelete:
+		return " (range keys deleted)"
+	}
+	return ""
+}
+
+// minUnflushedLogNum returns the last min-unflushed log num recorded in the
+// manifests. The memtable holds the contents of the WALs which have not been
+// flushed, i.e. those at or above it.
+func (f *findT) minUnflushedLogNum() base.DiskFileNum {
+	var minUnflushedLogNum base.DiskFileNum
+	for i := range f.edits {
+		if num := f.edits[i].MinUnflushedLogNum; num != 0 {
+			minUnflushedLogNum = num
+		}
+	}
+	return minUnflushedLogNum
+}
+
+// refLocation describes where the file containing the reference lives, and
+// whether it exposes the reference to the DB.
+func (f *findT) refLocation(
+	r *findRef, searchKey []byte, minUnflushedLogNum base.DiskFileNum,
+) (string, bool) {
+	switch {
+	case !f.isLog(r.fileNum):
+		return f.tableLocation(r.fileNum, searchKey)
+	case base.DiskFileNum(r.fileNum) >= minUnflushedLogNum:
+		return "memtable", true
+	default:
+		return "flushed", false
+	}
+}
+
+// isLog returns true if the specified file num is that of a WAL.
+func (f *findT) isLog(fileNum base.FileNum) bool {
+	for _, ll := range f.logs {
+		if base.FileNum(ll.Num) == fileNum {
+			return true
+		}
+	}
+	return false
+}
+
+// tableLocation describes where the table with the specified file num lives,
+// and whether it exposes the search key to the LSM, either directly or through
+// a virtual table it backs. A table which no longer exposes the key is either
+// obsolete, or excised if the key was excised from it.
+func (f *findT) tableLocation(fileNum base.FileNum, searchKey []byte) (string, bool) {
+	if _, ok := f.tableMeta[fileNum]; !ok {
+		return "not in the MANIFEST", false
+	}
+	level, removedBy := f.tableFate(fileNum)
+	if level >= 0 && removedBy < 0 {
+		return fmt.Sprintf("L%d", level), true
+	}
+	excised := removedBy >= 0 && f.excisedKey(fileNum, &f.edits[removedBy], searchKey)
+	for _, virtualFileNum := range f.virtualTables[base.PhysicalTableDiskFileNum(fileNum)] {
+		if !f.tableContains(virtualFileNum, searchKey) {
+			continue
+		}
+		level, removedBy := f.tableFate(virtualFileNum)
+		if level >= 0 && removedBy < 0 {
+			return fmt.Sprintf("L%d through virtual %s", level, virtualFileNum), true
+		}
+		excised = removedBy >= 0 && f.excisedKey(virtualFileNum, &f.edits[removedBy], searchKey)
+	}
+	if excised {
+		return "excised", false
+	}
+	return "obsolete", false
+}
+
+// printCurrentState prints the state of the search key as seen by the DB: the
+// newest point key, the range deletions and the range keys covering the key.
+func (d *dbT) printCurrentState(
+	stdout, stderr io.Writer, db *pebble.DB, f *findT, searchKey []byte,
+) {
+	fmt.Fprintf(stdout, "current state:\n")
+	upper := f.opts.Comparer.ImmediateSuccessor(nil, searchKey)
+	err := db.ScanInternal(context.Background(), sstable.CategoryAndQoS{}, searchKey, upper,
+		func(key *pebble.InternalKey, value pebble.LazyValue, iterInfo pebble.IteratorLevel) error {
+			v, _, err := value.Value(nil)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(stdout, "    %s: ", historyIteratorLevel(iterInfo))
+			formatKeyValue(stdout, f.fmtKey, f.fmtValue, key, v)
+			return nil
+		},
+		func(start, end []byte, seqNum pebble.SeqNum) error {
+			fmt.Fprintf(stdout, "    ")
+			k := base.MakeInternalKey(start, seqNum, base.InternalKeyKindRangeDelete)
+			formatKeyValue(stdout, f.fmtKey, f.fmtValue, &k, end)
+			return nil
+		},
+		func(start, end []byte, keys []keyspan.Key) error {
+			s := keyspan.Span{Start: start, End: end, Keys: keys}
+			fmt.Fprintf(stdout, "    range keys ")
+			formatSpan(stdout, f.fmtKey, f.fmtValue, &s)
+			return nil
+		},
+		nil /* visitSharedFile */, nil, /* visitExternalFile */
+	)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n", err)
+	}
+}
+
+// historyIteratorLevel describes the level at which ScanInternal found a key.
+func historyIteratorLevel(l pebble.IteratorLevel) string {
+	switch l.Kind {
+	case pebble.IteratorLevelFlushable:
+		return "memtable"
+	case pebble.IteratorLevelLSM:
+		if l.Level == 0 {
+			return fmt.Sprintf("L0.%d", l.Sublevel)
+		}
+		return fmt.Sprintf("L%d", l.Level)
+	}
+	return "unknown"
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package tool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/internal/humanize"
+	"github.com/cockroachdb/pebble/internal/lsmview"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+// lsmExplorerTemplate renders the current state of the LSM.
+var lsmExplorerTemplate = template.Must(template.New("lsm").Parse(strings.TrimSpace(lsmDataExplorerHTML)))
+
+// lsmExplorer is an http.Handler serving an interactive view of the LSM of an
+// open DB. All of the assets are embedded, so the explorer works without
+// network access.
+//
+// It serves:
+//   - /: the levels, L0 sublevels, tables (including virtual tables) and the
+//     compactions in progress;
+//   - /history: the evolution of the LSM through the version edits in the
+//     MANIFEST, as produced by the lsm command;
+//   - /lsm.json: the data underlying /, in JSON form.
+type lsmExplorer struct {
+	dir string
+	fs  vfs.FS
+	db  *pebble.DB
+	// newLSM returns the lsmT used to visualize the MANIFEST.
+	newLSM func() *lsmT
+}
+
+type lsmExplorerPage struct {
+	Dir         string
+	Refresh     int
+	NumKeys     int
+	Levels      []lsmExplorerLevel
+	Compactions []lsmview.Compaction
+}
+
+type lsmExplorerLevel struct {
+	Name   string
+	Size   string
+	Tables []lsmExplorerTable
+}
+
+type lsmExplorerTable struct {
+	Label      string
+	Size       string
+	Smallest   string
+	Largest    string
+	Details    string
+	Virtual    bool
+	Compacting bool
+	// Left and Width position the table in the key space, in percent.
+	Left  string
+	Width string
+}
+
+func (e *lsmExplorer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/":
+		e.serveLSM(w, r)
+	case "/history":
+		e.serveHistory(w)
+	case "/lsm.json":
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(e.db.LSMViewData()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case "/d3.v5.min.js":
+		w.Header().Set("Content-Type", "text/javascript")
+		_, _ = io.WriteString(w, lsmDataD3JS)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (e *lsmExplorer) serveLSM(w http.ResponseWriter, r *http.Request) {
+	page := lsmExplorerPage{Dir: e.dir}
+	if v := r.URL.Query().Get("refresh"); v != "" {
+		refresh, err := strconv.Atoi(v)
+		if err != nil || refresh < 0 {
+			http.Error(w, fmt.Sprintf("invalid refresh interval %q", v), http.StatusBadRequest)
+			return
+		}
+		page.Refresh = refresh
+	}
+
+	data := e.db.LSMViewData()
+	page.NumKeys = len(data.Keys)
+	page.Compactions = data.Compactions
+	compacting := make(map[string]bool)
+	for _, c := range data.Compactions {
+		for _, in := range c.Inputs {
+			for _, label := range in.Tables {
+				compacting[label] = true
+			}
+		}
+	}
+	for _, l := range data.Levels {
+		level := lsmExplorerLevel{Name: l.Name}
+		var size uint64
+		for _, t := range l.Tables {
+			size += t.Size
+			level.Tables = append(level.Tables, lsmExplorerTable{
+				Label:      t.Label,
+				Size:       humanize.Bytes.Uint64(t.Size).String(),
+				Smallest:   data.Keys[t.SmallestKey],
+				Largest:    data.Keys[t.LargestKey],
+				Details:    strings.Join(t.Details, "\n"),
+				Virtual:    t.Virtual,
+				Compacting: compacting[t.Label],
+				Left:       strconv.FormatFloat(100*float64(t.SmallestKey)/float64(len(data.Keys)), 'f', 3, 64),
+				Width:      strconv.FormatFloat(100*float64(t.LargestKey-t.SmallestKey+1)/float64(len(data.Keys)), 'f', 3, 64),
+			})
+		}
+		level.Size = humanize.Bytes.Uint64(size).String()
+		page.Levels = append(page.Levels, level)
+	}
+
+	var buf bytes.Buffer
+	if err := lsmExplorerTemplate.Execute(&buf, page); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = buf.WriteTo(w)
+}
+
+func (e *lsmExplorer) serveHistory(w http.ResponseWriter) {
+	desc, err := pebble.Peek(e.dir, e.fs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	l := e.newLSM()
+	var out bytes.Buffer
+	l.Root.SetOut(&out)
+	if ok, err := l.buildState(desc.ManifestFilename); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else if !ok {
+		http.Error(w, out.String(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	l.writeHTML(w, "d3.v5.min.js")
+}
+
+// serveLSMExplorer serves the LSM explorer for the DB on the given address
+// until the listener fails.
+func (d *dbT) serveLSMExplorer(stdout io.Writer, dir string, db *pebble.DB) error {
+	ln, err := net.Listen("tcp", d.lsmServe)
+	if err != nil {
+		return errors.Wrapf(err, "listening on %q", d.lsmServe)
+	}
+	e := &lsmExplorer{
+		dir: dir,
+		fs:  d.opts.FS,
+		db:  db,
+		newLSM: func() *lsmT {
+			return newLSM(d.opts, d.comparers)
+		},
+	}
+	fmt.Fprintf(stdout, "Serving the LSM explorer at http://%s/\n", ln.Addr())
+	return http.Serve(ln, e)
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package tool
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/internal/lsmview"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLSMExplorer(t *testing.T) {
+	opts := &pebble.Options{
+		FS:                          vfs.NewMem(),
+		DisableAutomaticCompactions: true,
+	}
+	db, err := pebble.Open("db", opts)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+	for _, k := range []string{"apple", "banana", "cherry"} {
+		require.NoError(t, db.Set([]byte(k), nil, nil))
+		require.NoError(t, db.Flush())
+	}
+	require.NoError(t, db.Compact([]byte("apple"), []byte("banana\x00"), false /* parallelize */))
+
+	comparers := sstable.Comparers{pebble.DefaultComparer.Name: pebble.DefaultComparer}
+	srv := httptest.NewServer(&lsmExplorer{
+		dir: "db",
+		fs:  opts.FS,
+		db:  db,
+		newLSM: func() *lsmT {
+			return newLSM(opts, comparers)
+		},
+	})
+	defer srv.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	// The explorer must not reference any external resources.
+	code, body := get("/")
+	require.Equal(t, http.StatusOK, code)
+	for _, s := range []string{"L0.0", "L6", "<td>cherry</td>", "Compactions in progress"} {
+		require.Contains(t, body, s)
+	}
+	require.NotContains(t, body, "https://")
+
+	code, body = get("/?refresh=5")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, `<meta http-equiv="refresh" content="5">`)
+	code, _ = get("/?refresh=x")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, body = get("/history")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, `<script src="d3.v5.min.js"></script>`)
+	require.NotContains(t, body, "https://")
+
+	code, body = get("/d3.v5.min.js")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, lsmDataD3JS, body)
+
+	code, body = get("/lsm.json")
+	require.Equal(t, http.StatusOK, code)
+	var data lsmview.Data
+	require.NoError(t, json.Unmarshal([]byte(body), &data))
+	require.Equal(t, 3, len(data.Keys))
+
+	code, _ = get("/missing")
+	require.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,775 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package tool
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/internal/humanize"
+	"github.com/cockroachdb/pebble/internal/keyspan"
+	"github.com/cockroachdb/pebble/internal/manifest"
+	"github.com/cockroachdb/pebble/internal/sstableinternal"
+	"github.com/cockroachdb/pebble/record"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/cockroachdb/pebble/vfs/atomicfs"
+	"github.com/cockroachdb/pebble/wal"
+	"github.com/spf13/cobra"
+)
+
+// manifestMarkerName is the name of the marker identifying the current
+// MANIFEST. It must match the name used by pebble.
+const manifestMarkerName = "manifest"
+
+// quarantineDirName is the subdirectory of the DB directory to which repair
+// moves the files it cannot use.
+const quarantineDirName = "quarantine"
+
+// repairT holds the state of a repair of the DB in dir.
+type repairT struct {
+	stdout    io.Writer
+	fs        vfs.FS
+	dir       string
+	cmp       *base.Comparer
+	comparers sstable.Comparers
+	mergers   sstable.Mergers
+	filters   map[string]sstable.FilterPolicy
+
+	// Listing of dir.
+	ls []string
+	// The next unused file num.
+	nextFileNum base.DiskFileNum
+	// The largest sequence number found in a table.
+	lastSeqNum base.SeqNum
+	// The readable tables.
+	tables []*manifest.FileMetadata
+	// The level of each readable table.
+	levels map[base.FileNum]int
+	// The min unflushed log num recovered from the readable records of the old
+	// MANIFESTs. The WALs below it are known to have been flushed.
+	flushedLogNum base.DiskFileNum
+	// The smallest log num of the WALs which must be replayed.
+	minUnflushedLogNum base.DiskFileNum
+}
+
+func (d *dbT) runRepair(cmd *cobra.Command, args []string) {
+	stdout, stderr := cmd.OutOrStdout(), cmd.ErrOrStderr()
+	dir := args[0]
+	if err := d.loadOptions(dir); err != nil {
+		fmt.Fprintf(stderr, "error loading options: %s\n", err)
+		return
+	}
+	r := &repairT{
+		stdout:    stdout,
+		fs:        d.opts.FS,
+		dir:       dir,
+		cmp:       d.opts.Comparer,
+		comparers: d.comparers,
+		mergers:   d.mergers,
+		filters:   d.opts.Filters,
+	}
+	if d.comparerName != "" {
+		r.cmp = d.comparers[d.comparerName]
+		if r.cmp == nil {
+			fmt.Fprintf(stderr, "unknown comparer %q\n", d.comparerName)
+			return
+		}
+	}
+	if r.cmp == nil {
+		r.cmp = base.DefaultComparer
+	}
+	d.fmtKey.setForComparer(r.cmp.Name, d.comparers)
+
+	var err error
+	if r.ls, err = r.fs.List(dir); err != nil {
+		fmt.Fprintf(stderr, "%s\n", err)
+		return
+	}
+	if err := r.checkManifest(); err == nil {
+		fmt.Fprintf(stdout, "MANIFEST is intact; nothing to repair\n")
+		return
+	} else {
+		fmt.Fprintf(stdout, "%s\n", err)
+	}
+
+	if !d.bypassPrompt {
+		fmt.Fprintf(stdout, "WARNING!!!\n")
+		fmt.Fprintf(stdout, "This command will replace the MANIFEST and move unreadable files to %s!\n",
+			r.fs.PathJoin(dir, quarantineDirName))
+		reader := bufio.NewReader(cmd.InOrStdin())
+		for {
+			fmt.Fprintf(stdout, "Continue? [Y/N] ")
+			answer, _ := reader.ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer == "y" || answer == "yes" {
+				break
+			}
+
+			if answer == "n" || answer == "no" {
+				fmt.Fprintf(stderr, "Aborting\n")
+				return
+			}
+		}
+	}
+
+	if err := r.run(); err != nil {
+		fmt.Fprintf(stderr, "%s\n", err)
+		return
+	}
+	r.printLevels(d.fmtKey)
+}
+
+// checkManifest returns an error describing why the current MANIFEST cannot be
+// used: it is missing, cannot be decoded, describes an inconsistent LSM or
+// references tables which do not exist.
+func (r *repairT) checkManifest() error {
+	marker, filename, err := atomicfs.LocateMarkerInListing(r.fs, r.dir, manifestMarkerName, r.ls)
+	if err != nil {
+		return err
+	}
+	if err := marker.Close(); err != nil {
+		return err
+	}
+	if filename == "" {
+		return errors.New("no current MANIFEST")
+	}
+
+	f, err := r.fs.Open(r.fs.PathJoin(r.dir, filename))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var bve manifest.BulkVersionEdit
+	bve.AddedByFileNum = make(map[base.FileNum]*manifest.FileMetadata)
+	// Mirror the checks performed when the DB loads the MANIFEST.
+	var minUnflushedLogNum base.DiskFileNum
+	nextFileNum := uint64(1)
+	rr := record.NewReader(f, 0 /* logNum */)
+	for {
+		offset := rr.Offset()
+		rec, err := rr.Next()
+		if err == io.EOF || record.IsInvalidRecord(err) {
+			// The DB ignores invalid records at the tail of the MANIFEST.
+			break
+		} else if err != nil {
+			return errors.Wrapf(err, "%s: offset %d", filename, offset)
+		}
+		var ve manifest.VersionEdit
+		if err := ve.Decode(rec); err != nil {
+			return errors.Wrapf(err, "%s: offset %d", filename, offset)
+		}
+		if ve.ComparerName != "" && ve.ComparerName != r.cmp.Name {
+			return errors.Errorf("%s: comparer %q does not match %q", filename, ve.ComparerName, r.cmp.Name)
+		}
+		if err := bve.Accumulate(&ve); err != nil {
+			return errors.Wrapf(err, "%s: offset %d", filename, offset)
+		}
+		if ve.MinUnflushedLogNum != 0 {
+			minUnflushedLogNum = ve.MinUnflushedLogNum
+		}
+		if ve.NextFileNum != 0 {
+			nextFileNum = ve.NextFileNum
+		}
+	}
+	if minUnflushedLogNum == 0 && nextFileNum < 2 {
+		return errors.Errorf("%s: malformed", filename)
+	}
+	v, err := bve.Apply(nil /* curr */, r.cmp, 0 /* flushSplitBytes */, 0 /* readCompactionRate */)
+	if err != nil {
+		return errors.Wrapf(err, "%s", filename)
+	}
+	for _, l := range v.Levels {
+		iter := l.Iter()
+		for m := iter.First(); m != nil; m = iter.Next() {
+			name := base.MakeFilename(base.FileTypeTable, m.FileBacking.DiskFileNum)
+			if !slices.Contains(r.ls, name) {
+				return errors.Errorf("%s: table %s is missing", filename, name)
+			}
+		}
+	}
+	return nil
+}
+
+// run rebuilds the MANIFEST from the tables and WALs in the DB directory.
+func (r *repairT) run() error {
+	r.nextFileNum = 1
+	var manifests []string
+	var tables []base.DiskFileNum
+	for _, name := range r.ls {
+		ft, fileNum, ok := base.ParseFilename(r.fs, name)
+		if !ok {
+			continue
+		}
+		r.nextFileNum = max(r.nextFileNum, fileNum+1)
+		switch ft {
+		case base.FileTypeManifest:
+			manifests = append(manifests, name)
+		case base.FileTypeTable:
+			tables = append(tables, fileNum)
+		}
+	}
+	slices.Sort(manifests)
+	slices.Sort(tables)
+	r.recoverFlushedLogNum(manifests)
+
+	var lost uint64
+	for _, fileNum := range tables {
+		name := base.MakeFilename(base.FileTypeTable, fileNum)
+		m, err := r.loadTable(name, fileNum)
+		if err == nil {
+			r.tables = append(r.tables, m)
+			r.lastSeqNum = max(r.lastSeqNum, m.LargestSeqNum)
+			continue
+		}
+		var size uint64
+		if stat, statErr := r.fs.Stat(r.fs.PathJoin(r.dir, name)); statErr == nil {
+			size = uint64(stat.Size())
+		}
+		lost += size
+		if err := r.quarantine(name); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.stdout, "quarantined %s (%s lost): %s\n", name, humanize.Bytes.Uint64(size), err)
+	}
+	if lost > 0 {
+		fmt.Fprintf(r.stdout, "lost %s of sstables\n", humanize.Bytes.Uint64(lost))
+	}
+
+	if err := r.placeTables(); err != nil {
+		return err
+	}
+	if err := r.repairLogs(); err != nil {
+		return err
+	}
+
+	ve := manifest.VersionEdit{
+		ComparerName:       r.cmp.Name,
+		MinUnflushedLogNum: r.minUnflushedLogNum,
+		LastSeqNum:         r.lastSeqNum,
+	}
+	for _, m := range r.tables {
+		ve.NewFiles = append(ve.NewFiles, manifest.NewFileEntry{Level: r.levels[m.FileNum], Meta: m})
+	}
+	// Sanity check the LSM described by the version edit before writing it.
+	var bve manifest.BulkVersionEdit
+	if err := bve.Accumulate(&ve); err != nil {
+		return err
+	}
+	v, err := bve.Apply(nil /* curr */, r.cmp, 0 /* flushSplitBytes */, 0 /* readCompactionRate */)
+	if err != nil {
+		return err
+	}
+	if err := v.CheckOrdering(); err != nil {
+		return err
+	}
+
+	// Move the unusable MANIFESTs out of the way, so that they are not
+	// mistaken for the current MANIFEST or deleted as obsolete.
+	for _, name := range manifests {
+		if err := r.quarantine(name); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.stdout, "quarantined %s\n", name)
+	}
+	return r.writeManifest(&ve)
+}
+
+// recoverFlushedLogNum reads the records of the old MANIFESTs up to the first
+// one which cannot be decoded, and recovers the largest min unflushed log num
+// they record.
+func (r *repairT) recoverFlushedLogNum(manifests []string) {
+	for _, name := range manifests {
+		f, err := r.fs.Open(r.fs.PathJoin(r.dir, name))
+		if err != nil {
+			continue
+		}
+		rr := record.NewReader(f, 0 /* logNum */)
+		for {
+			rec, err := rr.Next()
+			if err != nil {
+				break
+			}
+			var ve manifest.VersionEdit
+			if err := ve.Decode(rec); err != nil {
+				break
+			}
+			r.flushedLogNum = max(r.flushedLogNum, ve.MinUnflushedLogNum)
+		}
+		_ = f.Close()
+	}
+}
+
+// openTable opens the specified table of the DB directory.
+func (r *repairT) openTable(fileNum base.DiskFileNum) (*sstable.Reader, error) {
+	f, err := r.fs.Open(r.fs.PathJoin(r.dir, base.MakeFilename(base.FileTypeTable, fileNum)))
+	if err != nil {
+		return nil, err
+	}
+	readable, err := sstable.NewSimpleReadable(f)
+	if err != nil {
+		return nil, err
+	}
+	opts := sstable.ReaderOptions{
+		Comparer:  r.cmp,
+		Comparers: r.comparers,
+		Mergers:   r.mergers,
+		Filters:   r.filters,
+	}
+	opts.SetInternal(sstableinternal.ReaderOptions{
+		CacheOpts: sstableinternal.CacheOptions{FileNum: fileNum},
+	})
+	return sstable.NewReader(context.Background(), readable, opts)
+}
+
+// loadTable reads every key of the specified table, verifying the checksums of
+// its blocks and the ordering of its keys, and returns the metadata describing
+// it.
+func (r *repairT) loadTable(name string, fileNum base.DiskFileNum) (*manifest.FileMetadata, error) {
+	stat, err := r.fs.Stat(r.fs.PathJoin(r.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	reader, err := r.openTable(fileNum)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	if name := reader.Properties.ComparerName; name != "" && name != r.cmp.Name {
+		return nil, errors.Errorf("comparer %q does not match %q", name, r.cmp.Name)
+	}
+
+	m := &manifest.FileMetadata{
+		FileNum:        base.PhysicalTableFileNum(fileNum),
+		Size:           uint64(stat.Size()),
+		SmallestSeqNum: base.SeqNumMax,
+		CreationTime:   stat.ModTime().Unix(),
+	}
+	m.InitPhysicalBacking()
+	extendSeqNums := func(seqNum base.SeqNum) {
+		m.SmallestSeqNum = min(m.SmallestSeqNum, seqNum)
+		m.LargestSeqNum = max(m.LargestSeqNum, seqNum)
+	}
+
+	iter, err := reader.NewIter(sstable.NoTransforms, nil /* lower */, nil /* upper */)
+	if err != nil {
+		return nil, err
+	}
+	var smallest, prev base.InternalKey
+	for kv := iter.First(); kv != nil; kv = iter.Next() {
+		if prev.UserKey == nil {
+			smallest = kv.K.Clone()
+		} else if base.InternalCompare(r.cmp.Compare, prev, kv.K) >= 0 {
+			_ = iter.Close()
+			return nil, base.CorruptionErrorf("keys out of order: %s, %s",
+				prev.Pretty(r.cmp.FormatKey), kv.K.Pretty(r.cmp.FormatKey))
+		}
+		// Read the value to verify the checksums of value blocks.
+		if _, _, err := kv.Value(nil); err != nil {
+			_ = iter.Close()
+			return nil, err
+		}
+		extendSeqNums(kv.SeqNum())
+		prev.UserKey = append(prev.UserKey[:0], kv.K.UserKey...)
+		prev.Trailer = kv.K.Trailer
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	if prev.UserKey != nil {
+		m.ExtendPointKeyBounds(r.cmp.Compare, smallest, prev.Clone())
+	}
+
+	spanBounds := func(iter keyspan.FragmentIterator, err error) (smallest, largest base.InternalKey, ok bool, _ error) {
+		if err != nil || iter == nil {
+			return smallest, largest, false, err
+		}
+		defer iter.Close()
+		s, err := iter.First()
+		for ; s != nil; s, err = iter.Next() {
+			if !ok {
+				smallest, ok = s.SmallestKey().Clone(), true
+			}
+			for _, k := range s.Keys {
+				extendSeqNums(k.SeqNum())
+			}
+			largest = s.LargestKey().Clone()
+		}
+		return smallest, largest, ok, err
+	}
+	smallest, largest, ok, err := spanBounds(reader.NewRawRangeDelIter(context.Background(), sstable.NoFragmentTransforms))
+	if err != nil {
+		return nil, err
+	} else if ok {
+		m.ExtendPointKeyBounds(r.cmp.Compare, smallest, largest)
+	}
+	smallest, largest, ok, err = spanBounds(reader.NewRawRangeKeyIter(context.Background(), sstable.NoFragmentTransforms))
+	if err != nil {
+		return nil, err
+	} else if ok {
+		m.ExtendRangeKeyBounds(r.cmp.Compare, smallest, largest)
+	}
+
+	if !m.HasPointKeys && !m.HasRangeKeys {
+		return nil, errors.New("table contains no keys")
+	}
+	m.LargestSeqNumAbsolute = m.LargestSeqNum
+	if err := m.Validate(r.cmp.Compare, r.cmp.FormatKey); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// repairLogs determines which WALs must be replayed when the DB is opened. A
+// WAL is only known to have been flushed if it is below the min unflushed log
+// num recovered from the old MANIFESTs; every other WAL is replayed, even if
+// its writes may already be in tables. If no min unflushed log num could be
+// recovered, the WALs preceding the first WAL with a write above the largest
+// sequence number of the tables are assumed to have been flushed instead.
+// This assumption is wrong if a table was ingested while the writes of such a
+// WAL were still in the memtable, but replaying every WAL would place stale
+// versions of the keys in flushed WALs above the newer versions in tables. The
+// readable prefix of a corrupted WAL is kept, and the original WAL is
+// quarantined.
+func (r *repairT) repairLogs() error {
+	logs, err := wal.Scan(wal.Dir{FS: r.fs, Dirname: r.dir})
+	if err != nil {
+		return err
+	}
+	// Without a WAL to replay, the min unflushed log num must still be above
+	// the log nums of all of the WALs, which are obsolete.
+	r.minUnflushedLogNum = r.nextFileNum
+	for i, ll := range logs {
+		if base.DiskFileNum(ll.Num) < r.flushedLogNum {
+			fmt.Fprintf(r.stdout, "%s: flushed\n", logFilename(ll))
+			continue
+		}
+		n, maxSeqNum, err := readLog(ll)
+		// The DB tolerates invalid records at the tail of the last WAL, which
+		// are usually due to WAL preallocation or recycling.
+		tolerated := i == len(logs)-1 && record.IsInvalidRecord(err)
+		if r.flushedLogNum == 0 && maxSeqNum <= r.lastSeqNum && r.minUnflushedLogNum == r.nextFileNum {
+			fmt.Fprintf(r.stdout, "%s: flushed, as its writes are not above those of the tables\n", logFilename(ll))
+			continue
+		}
+		r.minUnflushedLogNum = min(r.minUnflushedLogNum, base.DiskFileNum(ll.Num))
+		if err == nil {
+			fmt.Fprintf(r.stdout, "%s: %d %s to replay\n", logFilename(ll), n, makePlural("record", int64(n)))
+			continue
+		} else if tolerated {
+			fmt.Fprintf(r.stdout, "%s: %d %s to replay, followed by invalid records: %s\n",
+				logFilename(ll), n, makePlural("record", int64(n)), err)
+			continue
+		}
+		if err := r.rewriteLog(ll, n); err != nil {
+			return err
+		}
+		fmt.Fprintf(r.stdout, "%s: corrupted after %d %s, which will be replayed: %s\n",
+			logFilename(ll), n, makePlural("record", int64(n)), err)
+	}
+	return nil
+}
+
+// logFilename returns the filename of the first segment of the WAL.
+func logFilename(ll wal.LogicalLog) string {
+	fs, path := ll.SegmentLocation(0)
+	return fs.PathBase(path)
+}
+
+// readLog returns the number of batches which can be read from the WAL before
+// the first error, and the largest sequence number assigned by these batches.
+// Returns a nil error if the whole WAL is readable.
+func readLog(ll wal.LogicalLog) (n int, maxSeqNum base.SeqNum, err error) {
+	err = readLogBatches(ll, -1, func(b *pebble.Batch) error {
+		n++
+		if b.Count() > 0 {
+			maxSeqNum = max(maxSeqNum, b.SeqNum()+base.SeqNum(b.Count())-1)
+		}
+		return nil
+	})
+	return n, maxSeqNum, err
+}
+
+// readLogBatches calls fn for each of the first n batches of the WAL, or each
+// batch if n is negative. Returns a nil error once the end of the WAL is
+// reached.
+func readLogBatches(ll wal.LogicalLog, n int, fn func(b *pebble.Batch) error) error {
+	rr := ll.OpenForRead()
+	defer rr.Close()
+	var buf bytes.Buffer
+	for i := 0; n < 0 || i < n; i++ {
+		rec, _, err := rr.NextRecord()
+		if err == nil {
+			buf.Reset()
+			_, err = io.Copy(&buf, rec)
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var b pebble.Batch
+		if err := b.SetRepr(slices.Clone(buf.Bytes())); err != nil {
+			return err
+		}
+		if err := fn(&b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteLog replaces the specified WAL with one containing its first n
+// batches, and quarantines the segments of the original WAL.
+func (r *repairT) rewriteLog(ll wal.LogicalLog, n int) error {
+	var batches [][]byte
+	if err := readLogBatches(ll, n, func(b *pebble.Batch) error {
+		batches = append(batches, b.Repr())
+		return nil
+	}); err != nil {
+		return err
+	}
+	// The new WAL replaces the first segment of the original WAL.
+	_, path := ll.SegmentLocation(0)
+	for i := 0; i < ll.NumSegments(); i++ {
+		_, segmentPath := ll.SegmentLocation(i)
+		if err := r.quarantine(r.fs.PathBase(segmentPath)); err != nil {
+			return err
+		}
+	}
+
+	f, err := r.fs.Create(path, vfs.WriteCategoryUnspecified)
+	if err != nil {
+		return err
+	}
+	w := record.NewLogWriter(f, base.DiskFileNum(ll.Num), record.LogWriterConfig{})
+	for _, b := range batches {
+		if _, err := w.WriteRecord(b); err != nil {
+			_ = w.Close()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// placeTables assigns the tables to levels. The tables are visited from the
+// oldest to the newest, and each table is placed in the deepest level above
+// all of the older tables it overlaps, so that newer versions of a key are
+// always above older ones. A table which overlaps an older table in L1 is
+// placed in L0, which permits overlapping tables.
+//
+// A table is only newer than an overlapping table if all of its sequence
+// numbers are above those of the other table. Overlapping tables whose
+// sequence number ranges interleave, such as a flushed table and a table
+// ingested while the memtable was being filled, are both placed in L0, which
+// orders them by their largest sequence numbers. This is only correct if the
+// keys they have in common are ordered the same way, which is checked; if they
+// are not, the LSM cannot represent the tables and the repair fails.
+func (r *repairT) placeTables() error {
+	slices.SortFunc(r.tables, func(a, b *manifest.FileMetadata) int {
+		if v := cmp.Compare(a.LargestSeqNum, b.LargestSeqNum); v != 0 {
+			return v
+		}
+		if v := cmp.Compare(a.SmallestSeqNum, b.SmallestSeqNum); v != 0 {
+			return v
+		}
+		return cmp.Compare(a.FileNum, b.FileNum)
+	})
+	inL0 := make(map[base.FileNum]bool)
+	for i, m := range r.tables {
+		bounds := m.UserKeyBounds()
+		for _, older := range r.tables[:i] {
+			olderBounds := older.UserKeyBounds()
+			if older.LargestSeqNum < m.SmallestSeqNum || !bounds.Overlaps(r.cmp.Compare, &olderBounds) {
+				continue
+			}
+			if err := r.checkInterleavedTables(older, m); err != nil {
+				return errors.Wrapf(err, "tables %s <#%d-#%d> and %s <#%d-#%d> overlap with interleaved sequence numbers",
+					older.FileNum, older.SmallestSeqNum, older.LargestSeqNum,
+					m.FileNum, m.SmallestSeqNum, m.LargestSeqNum)
+			}
+			inL0[older.FileNum], inL0[m.FileNum] = true, true
+		}
+	}
+	r.levels = make(map[base.FileNum]int)
+	for i, m := range r.tables {
+		level := manifest.NumLevels - 1
+		if inL0[m.FileNum] {
+			level = 0
+		}
+		bounds := m.UserKeyBounds()
+		for _, older := range r.tables[:i] {
+			olderBounds := older.UserKeyBounds()
+			if bounds.Overlaps(r.cmp.Compare, &olderBounds) {
+				level = min(level, max(r.levels[older.FileNum]-1, 0))
+			}
+		}
+		r.levels[m.FileNum] = level
+	}
+	return nil
+}
+
+// checkInterleavedTables checks that the overlapping tables older and newer,
+// whose sequence number ranges interleave, can be placed in L0 with newer
+// above older: every key found in both tables must only have versions in newer
+// which are newer than its versions in older. A range deletion in either table
+// overlapping the other table is rejected, as a point lookup does not look for
+// keys below a level containing a range deletion covering the key. Range keys
+// are always ordered by their sequence numbers, so they need not be checked.
+func (r *repairT) checkInterleavedTables(older, newer *manifest.FileMetadata) error {
+	olderReader, err := r.openTable(older.FileBacking.DiskFileNum)
+	if err != nil {
+		return err
+	}
+	defer olderReader.Close()
+	newerReader, err := r.openTable(newer.FileBacking.DiskFileNum)
+	if err != nil {
+		return err
+	}
+	defer newerReader.Close()
+
+	overlapsRangeDel := func(reader *sstable.Reader, m *manifest.FileMetadata) error {
+		iter, err := reader.NewRawRangeDelIter(context.Background(), sstable.NoFragmentTransforms)
+		if err != nil || iter == nil {
+			return err
+		}
+		defer iter.Close()
+		bounds := m.UserKeyBounds()
+		s, err := iter.First()
+		for ; s != nil; s, err = iter.Next() {
+			if spanBounds := s.Bounds(); bounds.Overlaps(r.cmp.Compare, &spanBounds) {
+				return errors.Errorf("range deletion %s overlaps %s", s.Pretty(r.cmp.FormatKey), m.FileNum)
+			}
+		}
+		return err
+	}
+	if err := overlapsRangeDel(olderReader, newer); err != nil {
+		return err
+	}
+	if err := overlapsRangeDel(newerReader, older); err != nil {
+		return err
+	}
+
+	olderIter, err := olderReader.NewIter(sstable.NoTransforms, nil /* lower */, nil /* upper */)
+	if err != nil {
+		return err
+	}
+	defer olderIter.Close()
+	newerIter, err := newerReader.NewIter(sstable.NoTransforms, nil /* lower */, nil /* upper */)
+	if err != nil {
+		return err
+	}
+	defer newerIter.Close()
+	// The versions of a key are ordered from newest to oldest, so the first
+	// version of a key in older must be below the last version of the key in
+	// newer.
+	olderKV, newerKV := olderIter.First(), newerIter.First()
+	for olderKV != nil && newerKV != nil {
+		switch v := r.cmp.Compare(olderKV.K.UserKey, newerKV.K.UserKey); {
+		case v < 0:
+			olderKV = olderIter.Next()
+		case v > 0:
+			newerKV = newerIter.Next()
+		default:
+			olderKey := olderKV.K.Clone()
+			newerKey := newerKV.K.Clone()
+			for newerKV != nil && r.cmp.Equal(newerKV.K.UserKey, olderKey.UserKey) {
+				newerKey.Trailer = newerKV.K.Trailer
+				newerKV = newerIter.Next()
+			}
+			if newerKey.SeqNum() <= olderKey.SeqNum() {
+				return errors.Errorf("%s in %s is older than %s in %s",
+					newerKey.Pretty(r.cmp.FormatKey), newer.FileNum, olderKey.Pretty(r.cmp.FormatKey), older.FileNum)
+			}
+			for olderKV != nil && r.cmp.Equal(olderKV.K.UserKey, olderKey.UserKey) {
+				olderKV = olderIter.Next()
+			}
+		}
+	}
+	return errors.CombineErrors(olderIter.Error(), newerIter.Error())
+}
+
+// writeManifest writes a new MANIFEST containing the version edit and makes it
+// the current MANIFEST.
+func (r *repairT) writeManifest(ve *manifest.VersionEdit) error {
+	manifestNum := r.nextFileNum
+	ve.NextFileNum = uint64(manifestNum + 1)
+	name := base.MakeFilename(base.FileTypeManifest, manifestNum)
+	f, err := r.fs.Create(r.fs.PathJoin(r.dir, name), vfs.WriteCategoryUnspecified)
+	if err != nil {
+		return err
+	}
+	w := record.NewWriter(f)
+	rw, err := w.Next()
+	if err == nil {
+		err = ve.Encode(rw)
+	}
+	err = errors.CombineErrors(err, w.Close())
+	err = errors.CombineErrors(err, f.Sync())
+	err = errors.CombineErrors(err, f.Close())
+	if err != nil {
+		return err
+	}
+
+	marker, _, err := atomicfs.LocateMarkerInListing(r.fs, r.dir, manifestMarkerName, r.ls)
+	if err != nil {
+		return err
+	}
+	if err := marker.Move(name); err != nil {
+		return errors.CombineErrors(err, marker.Close())
+	}
+	if err := marker.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.stdout, "created %s\n", name)
+	return nil
+}
+
+// quarantine moves the specified file of the DB directory to the quarantine
+// directory.
+func (r *repairT) quarantine(name string) error {
+	dir := r.fs.PathJoin(r.dir, quarantineDirName)
+	if err := r.fs.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return r.fs.Rename(r.fs.PathJoin(r.dir, name), r.fs.PathJoin(dir, name))
+}
+
+// printLevels prints the tables placed in each level.
+func (r *repairT) printLevels(fmtKey keyFormatter) {
+	for level := 0; level < manifest.NumLevels; level++ {
+		var size uint64
+		var tables []*manifest.FileMetadata
+		for _, m := range r.tables {
+			if r.levels[m.FileNum] == level {
+				size += m.Size
+				tables = append(tables, m)
+			}
+		}
+		if len(tables) == 0 {
+			continue
+		}
+		fmt.Fprintf(r.stdout, "L%d: %d %s (%s)\n", level, len(tables),
+			makePlural("table", int64(len(tables))), humanize.Bytes.Uint64(size))
+		slices.SortFunc(tables, func(a, b *manifest.FileMetadata) int {
+			return base.InternalCompare(r.cmp.Compare, a.Smallest, b.Smallest)
+		})
+		for _, m := range tables {
+			fmt.Fprintf(r.stdout, "  %s:%d", m.FileNum, m.Size)
+			formatSeqNumRange(r.stdout, m.SmallestSeqNum, m.LargestSeqNum)
+			formatKeyRange(r.stdout, fmtKey, &m.Smallest, &m.Largest)
+			fmt.Fprintf(r.stdout, "\n")
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package tool
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
+	"github.com/cockroachdb/pebble/record"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/cockroachdb/pebble/vfs/atomicfs"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBRepair(t *testing.T) {
+	fs := vfs.NewMem()
+	opts := &pebble.Options{
+		FS:                          fs,
+		DisableAutomaticCompactions: true,
+	}
+	db, err := pebble.Open("db", opts)
+	require.NoError(t, err)
+	set := func(k, v string) { require.NoError(t, db.Set([]byte(k), []byte(v), nil)) }
+	for _, k := range []string{"a", "b", "c", "d", "e"} {
+		set(k, k+"1")
+	}
+	require.NoError(t, db.Flush())
+	require.NoError(t, db.Compact([]byte("a"), []byte("f"), false /* parallelize */))
+	set("b", "b2")
+	set("c", "c2")
+	require.NoError(t, db.Flush())
+	// These writes are only in the WAL.
+	set("c", "c3")
+	require.NoError(t, db.Delete([]byte("d"), nil))
+	require.NoError(t, db.Close())
+
+	// Corrupt the MANIFEST and add an unreadable sstable.
+	_, manifestFilename, err := atomicfs.LocateMarker(fs, "db", manifestMarkerName)
+	require.NoError(t, err)
+	writeFile := func(name, data string) {
+		f, err := fs.Create(fs.PathJoin("db", name), vfs.WriteCategoryUnspecified)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(data))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+	writeFile(manifestFilename, "not a manifest")
+	writeFile(base.MakeFilename(base.FileTypeTable, 100), "not an sstable")
+
+	// Corrupt the record deleting d in the WAL, and add a newer WAL. As the
+	// corrupted WAL is no longer the last one, the DB does not tolerate the
+	// corruption.
+	walPath := fs.PathJoin("db", "000006.log")
+	f, err := fs.OpenReadWrite(walPath, vfs.WriteCategoryUnspecified)
+	require.NoError(t, err)
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	// The record is a batch containing a single DEL (kind 0) of key d.
+	i := bytes.Index(data, []byte("\x00\x01d"))
+	require.GreaterOrEqual(t, i, 0)
+	_, err = f.WriteAt([]byte("x"), int64(i+2))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	var b pebble.Batch
+	require.NoError(t, b.Set([]byte("f"), []byte("f1"), nil))
+	repr := b.Repr()
+	binary.LittleEndian.PutUint64(repr, 100 /* seqNum */)
+	f, err = fs.Create(fs.PathJoin("db", "000200.log"), vfs.WriteCategoryUnspecified)
+	require.NoError(t, err)
+	w := record.NewLogWriter(f, 200, record.LogWriterConfig{})
+	_, err = w.WriteRecord(repr)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = pebble.Open("db", opts)
+	require.Error(t, err)
+
+	repair := func() string {
+		var buf bytes.Buffer
+		c := &cobra.Command{}
+		c.AddCommand(New(FS(fs)).Commands...)
+		c.SetArgs([]string{"db", "repair", "db", "--yes"})
+		c.SetOut(&buf)
+		c.SetErr(&buf)
+		require.NoError(t, c.Execute())
+		return buf.String()
+	}
+	out := repair()
+	t.Log(out)
+	for _, s := range []string{
+		"quarantined 000100.sst",
+		fmt.Sprintf("quarantined %s", manifestFilename),
+		"000006.log: corrupted after 1 record",
+		"000200.log: 1 record to replay",
+		"created MANIFEST-",
+		"L5: 1 table",
+		"L6: 1 table",
+	} {
+		require.Contains(t, out, s)
+	}
+	for _, name := range []string{manifestFilename, "000100.sst", "000006.log"} {
+		_, err := fs.Stat(fs.PathJoin("db", quarantineDirName, name))
+		require.NoError(t, err)
+	}
+
+	// The repaired DB contains the data from the readable sstables and WALs. The
+	// deletion of d was in the corrupted record.
+	db, err = pebble.Open("db", opts)
+	require.NoError(t, err)
+	for k, want := range map[string]string{
+		"a": "a1", "b": "b2", "c": "c3", "d": "d1", "e": "e1", "f": "f1",
+	} {
+		v, closer, err := db.Get([]byte(k))
+		require.NoError(t, err)
+		require.Equal(t, want, string(v))
+		require.NoError(t, closer.Close())
+	}
+	require.NoError(t, db.Close())
+
+	require.Contains(t, repair(), "MANIFEST is intact; nothing to repair")
+}
+
+// runRepair runs the repair command on the DB in dir and returns its output.
+func runRepair(t *testing.T, fs vfs.FS, dir string) string {
+	var buf bytes.Buffer
+	c := &cobra.Command{}
+	c.AddCommand(New(FS(fs)).Commands...)
+	c.SetArgs([]string{"db", "repair", dir, "--yes"})
+	c.SetOut(&buf)
+	c.SetErr(&buf)
+	require.NoError(t, c.Execute())
+	t.Log(buf.String())
+	return buf.String()
+}
+
+// corruptManifest appends a record which cannot be decoded to the current
+// MANIFEST of the DB in dir.
+func corruptManifest(t *testing.T, fs vfs.FS, dir string) {
+	_, manifestFilename, err := atomicfs.LocateMarker(fs, dir, manifestMarkerName)
+	require.NoError(t, err)
+	f, err := fs.OpenReadWrite(fs.PathJoin(dir, manifestFilename), vfs.WriteCategoryUnspecified)
+	require.NoError(t, err)
+	stat, err := f.Stat()
+	require.NoError(t, err)
+	w := record.NewWriter(&offsetWriter{f: f, off: stat.Size()})
+	rw, err := w.Next()
+	require.NoError(t, err)
+	_, err = rw.Write([]byte("not a version edit"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
+
+type offsetWriter struct {
+	f   vfs.File
+	off int64
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.f.WriteAt(p, w.off)
+	w.off += int64(n)
+	return n, err
+}
+
+func TestDBRepairFlushedLogs(t *testing.T) {
+	// setup writes a merge operand to a flushed WAL and another to an unflushed
+	// WAL, and returns the names of the WALs.
+	setup := func(t *testing.T, fs vfs.FS) []string {
+		db, err := pebble.Open("db", &pebble.Options{FS: fs})
+		require.NoError(t, err)
+		require.NoError(t, db.Merge([]byte("a"), []byte("1"), nil))
+		require.NoError(t, db.Flush())
+		require.NoError(t, db.Merge([]byte("a"), []byte("2"), nil))
+		require.NoError(t, db.Close())
+		logs, err := fs.List("db")
+		require.NoError(t, err)
+		logs = slices.DeleteFunc(logs, func(name string) bool { return !strings.HasSuffix(name, ".log") })
+		slices.Sort(logs)
+		require.Len(t, logs, 2)
+		return logs
+	}
+	// check verifies that each merge operand was applied once. Replaying the
+	// first WAL would apply its merge twice.
+	check := func(t *testing.T, fs vfs.FS) {
+		db, err := pebble.Open("db", &pebble.Options{FS: fs})
+		require.NoError(t, err)
+		v, closer, err := db.Get([]byte("a"))
+		require.NoError(t, err)
+		require.Equal(t, "12", string(v))
+		require.NoError(t, closer.Close())
+		require.NoError(t, db.Close())
+	}
+
+	t.Run("corrupted-manifest", func(t *testing.T) {
+		// The MANIFEST records that the first WAL was flushed, so only the
+		// second WAL is replayed.
+		fs := vfs.NewMem()
+		logs := setup(t, fs)
+		corruptManifest(t, fs, "db")
+		out := runRepair(t, fs, "db")
+		require.Contains(t, out, logs[0]+": flushed\n")
+		require.Contains(t, out, logs[1]+": 1 record to replay")
+		check(t, fs)
+	})
+
+	t.Run("deleted-manifest", func(t *testing.T) {
+		// Without a MANIFEST, the first WAL is known to be flushed because its
+		// write is not above the writes of the tables.
+		fs := vfs.NewMem()
+		logs := setup(t, fs)
+		_, manifestFilename, err := atomicfs.LocateMarker(fs, "db", manifestMarkerName)
+		require.NoError(t, err)
+		require.NoError(t, fs.Remove(fs.PathJoin("db", manifestFilename)))
+		out := runRepair(t, fs, "db")
+		require.Contains(t, out, logs[0]+": flushed, as its writes are not above those of the tables")
+		require.Contains(t, out, logs[1]+": 1 record to replay")
+		check(t, fs)
+	})
+}
+
+func TestDBRepairInterleavedSeqNums(t *testing.T) {
+	// writeTable writes a table containing the specified keys to the DB in dir.
+	writeTable := func(t *testing.T, fs vfs.FS, dir string, fileNum base.DiskFileNum, keys ...string) {
+		f, err := fs.Create(fs.PathJoin(dir, base.MakeFilename(base.FileTypeTable, fileNum)), vfs.WriteCategoryUnspecified)
+		require.NoError(t, err)
+		w := sstable.NewRawWriter(objstorageprovider.NewFileWritable(f), sstable.WriterOptions{})
+		for _, k := range keys {
+			ikey := base.ParseInternalKey(k)
+			require.NoError(t, w.AddWithForceObsolete(ikey, []byte(k), false /* forceObsolete */))
+		}
+		require.NoError(t, w.Close())
+	}
+	setup := func(t *testing.T) vfs.FS {
+		fs := vfs.NewMem()
+		db, err := pebble.Open("db", &pebble.Options{FS: fs})
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+		corruptManifest(t, fs, "db")
+		return fs
+	}
+
+	t.Run("ordered", func(t *testing.T) {
+		// A table ingested while the memtable was filled: its sequence number is
+		// within those of the flushed table, which does not contain its key. Both
+		// tables are placed in L0, the flushed table above the ingested one.
+		fs := setup(t)
+		writeTable(t, fs, "db", 100, "a#10,SET", "z#30,SET")
+		writeTable(t, fs, "db", 101, "m#20,SET")
+		out := runRepair(t, fs, "db")
+		require.Contains(t, out, "L0: 2 tables")
+
+		db, err := pebble.Open("db", &pebble.Options{FS: fs})
+		require.NoError(t, err)
+		for k, want := range map[string]string{"a": "a#10,SET", "m": "m#20,SET", "z": "z#30,SET"} {
+			v, closer, err := db.Get([]byte(k))
+			require.NoError(t, err)
+			require.Equal(t, want, string(v))
+			require.NoError(t, closer.Close())
+		}
+		require.NoError(t, db.Close())
+	})
+
+	t.Run("unordered", func(t *testing.T) {
+		// The newer version of m is in the table with the smaller largest
+		// sequence number, so the tables cannot be stacked in either order.
+		fs := setup(t)
+		writeTable(t, fs, "db", 100, "m#40,SET", "z#100,SET")
+		writeTable(t, fs, "db", 101, "m#60,SET")
+		out := runRepair(t, fs, "db")
+		require.Contains(t, out, "overlap with interleaved sequence numbers")
+		require.Contains(t, out, "m#40,SET in 000100 is older than m#60,SET in 000101")
+		require.NotContains(t, out, "created MANIFEST-")
+	})
+}
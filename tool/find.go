@@ -14,11 +14,13 @@ import (
 	"slices"
 	"sort"
 
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/internal/keyspan"
 	"github.com/cockroachdb/pebble/internal/manifest"
 	"github.com/cockroachdb/pebble/internal/rangedel"
+	"github.com/cockroachdb/pebble/internal/rangekey"
 	"github.com/cockroachdb/pebble/internal/sstableinternal"
 	"github.com/cockroachdb/pebble/record"
 	"github.com/cockroachdb/pebble/sstable"
@@ -35,13 +37,10 @@ type findRef struct {
 
 // findT implements the find tool.
 //
-// TODO(bananabrick): Add support for virtual sstables in this tool. Currently,
-// the tool will work because we're parsing files from disk, so virtual sstables
-// will never be added to findT.tables. The manifest could contain information
-// about virtual sstables. This is fine because the manifest is only used to
-// compute the findT.editRefs, and editRefs is only used if a file in
-// findT.tables contains a key. Of course, the tool won't be completely
-// accurate without dealing with virtual sstable case.
+// Virtual sstables are never found on disk; only their backing tables are.
+// The virtual tables, and the bounds through which they expose the keys of
+// their backing table, are reconstructed from the version edits in the
+// manifests.
 type findT struct {
 	Root *cobra.Command
 
@@ -56,6 +55,11 @@ type findT struct {
 	fmtValue     valueFormatter
 	verbose      bool
 
+	// If set, the search also returns the range keys (RANGEKEYSET,
+	// RANGEKEYUNSET and RANGEKEYDEL) containing the search key, encoded as
+	// they are in the WALs. The find command does not set it.
+	rangeKeys bool
+
 	// Map from file num to version edit index which references the file num.
 	editRefs map[base.DiskFileNum][]int
 	// List of version edits.
@@ -70,6 +74,12 @@ type findT struct {
 	tableRefs map[base.FileNum]bool
 	// Map from file num to table metadata.
 	tableMeta map[base.FileNum]*manifest.FileMetadata
+	// Map from the disk file num of a backing table to the file nums of the
+	// virtual tables it backs, in creation order.
+	virtualTables map[base.DiskFileNum][]base.FileNum
+	// Map from the file num of a virtual table to the disk file num of its
+	// backing table.
+	virtualBacking map[base.FileNum]base.DiskFileNum
 	// List of error messages for SSTables that could not be decoded.
 	errors []string
 }
@@ -103,7 +113,9 @@ func newFind(
 		Long: `
 Find references to the specified key and any range tombstones that contain the
 key. This includes references to the key in WAL files and sstables, and the
-provenance of the sstables (flushed, ingested, compacted).
+provenance of the sstables (flushed, ingested, compacted). For sstables that
+back virtual sstables, the virtual sstables are listed along with whether the
+key is visible through them or was excised.
 `,
 		Args: cobra.ExactArgs(2),
 		Run:  f.run,
@@ -132,41 +144,58 @@ func (f *findT) run(cmd *cobra.Command, args []string) {
 		fmt.Fprintf(stdout, "%s\n", err)
 		return
 	}
-	f.readManifests(stdout)
-
-	f.opts.Comparer = f.comparers[f.comparerName]
-	if f.opts.Comparer == nil {
-		fmt.Fprintf(stderr, "unknown comparer %q", f.comparerName)
+	if err := f.load(stdout); err != nil {
+		fmt.Fprintf(stderr, "%s", err)
 		return
 	}
-	f.fmtKey.setForComparer(f.opts.Comparer.Name, f.comparers)
-	f.fmtValue.setForComparer(f.opts.Comparer.Name, f.comparers)
 
 	refs := f.search(stdout, key)
 	var lastFilename string
+	var lastFileNum base.FileNum
 	for i := range refs {
 		r := &refs[i]
 		if lastFilename != r.filename {
+			if lastFilename != "" {
+				f.printVirtualTables(stdout, lastFileNum, key)
+			}
 			lastFilename = r.filename
+			lastFileNum = r.fileNum
 			fmt.Fprintf(stdout, "%s", r.filename)
 			if m := f.tableMeta[r.fileNum]; m != nil {
 				fmt.Fprintf(stdout, " ")
 				formatKeyRange(stdout, f.fmtKey, &m.Smallest, &m.Largest)
 			}
 			fmt.Fprintf(stdout, "\n")
-			if p := f.tableProvenance(r.fileNum); p != "" {
+			if p := f.tableProvenance(r.fileNum, key); p != "" {
 				fmt.Fprintf(stdout, "    (%s)\n", p)
 			}
 		}
 		fmt.Fprintf(stdout, "    ")
 		formatKeyValue(stdout, f.fmtKey, f.fmtValue, &r.key, r.value)
 	}
+	if lastFilename != "" {
+		f.printVirtualTables(stdout, lastFileNum, key)
+	}
 
 	for _, errorMsg := range f.errors {
 		fmt.Fprint(stdout, errorMsg)
 	}
 }
 
+// Read the manifests and configure the comparer and formatters. The comparer
+// recorded in the manifests takes precedence over the configured one.
+func (f *findT) load(stdout io.Writer) error {
+	f.readManifests(stdout)
+
+	f.opts.Comparer = f.comparers[f.comparerName]
+	if f.opts.Comparer == nil {
+		return errors.Errorf("unknown comparer %q", errors.Safe(f.comparerName))
+	}
+	f.fmtKey.setForComparer(f.opts.Comparer.Name, f.comparers)
+	f.fmtValue.setForComparer(f.opts.Comparer.Name, f.comparers)
+	return nil
+}
+
 // Find all of the manifests, logs, and tables in the specified directory.
 func (f *findT) findFiles(stdout, stderr io.Writer, dir string) error {
 	f.editRefs = make(map[base.DiskFileNum][]int)
@@ -174,6 +203,8 @@ func (f *findT) findFiles(stdout, stderr io.Writer, dir string) error {
 	f.manifests = nil
 	f.tables = nil
 	f.tableMeta = make(map[base.FileNum]*manifest.FileMetadata)
+	f.virtualTables = make(map[base.DiskFileNum][]base.FileNum)
+	f.virtualBacking = make(map[base.FileNum]base.DiskFileNum)
 
 	if _, err := f.opts.FS.Stat(dir); err != nil {
 		return err
@@ -274,6 +305,10 @@ func (f *findT) readManifests(stdout io.Writer) {
 					}
 					if _, ok := f.tableMeta[nf.Meta.FileNum]; !ok {
 						f.tableMeta[nf.Meta.FileNum] = nf.Meta
+						if nf.Meta.Virtual {
+							f.virtualTables[nf.BackingFileNum] = append(f.virtualTables[nf.BackingFileNum], nf.Meta.FileNum)
+							f.virtualBacking[nf.Meta.FileNum] = nf.BackingFileNum
+						}
 					}
 				}
 			}
@@ -388,6 +423,20 @@ func (f *findT) searchLogs(stdout io.Writer, searchKey []byte, refs []findRef) [
 						if !t.Contains(cmp, searchKey) && cmp(t.End, searchKey) != 0 {
 							continue
 						}
+					case base.InternalKeyKindRangeKeySet,
+						base.InternalKeyKindRangeKeyUnset,
+						base.InternalKeyKindRangeKeyDelete:
+						if !f.rangeKeys {
+							continue
+						}
+						s, err := rangekey.Decode(ikey, value, nil)
+						if err != nil {
+							fmt.Fprintf(stdout, "%s: corrupt log file: %v", ll, err)
+							continue
+						}
+						if !s.Contains(cmp, searchKey) {
+							continue
+						}
 					default:
 						continue
 					}
@@ -559,8 +608,23 @@ func (f *findT) searchTables(stdout io.Writer, searchKey []byte, refs []findRef)
 				foundRef = true
 			}
 
+			if f.rangeKeys {
+				n := len(refs)
+				if refs, err = f.searchRangeKeys(r, fragTransforms, fl, searchKey, refs); err != nil {
+					return err
+				}
+				foundRef = foundRef || len(refs) > n
+			}
+
 			if foundRef {
 				f.tableRefs[base.PhysicalTableFileNum(fl.DiskFileNum)] = true
+				// The virtual tables backed by this table reference the key if their
+				// bounds contain it.
+				for _, fileNum := range f.virtualTables[fl.DiskFileNum] {
+					if f.tableContains(fileNum, searchKey) {
+						f.tableRefs[fileNum] = true
+					}
+				}
 			}
 			return nil
 		}()
@@ -568,11 +632,41 @@ func (f *findT) searchTables(stdout io.Writer, searchKey []byte, refs []findRef)
 	return refs
 }
 
+// Search the range key block of the specified table for the range keys
+// containing the search key.
+func (f *findT) searchRangeKeys(
+	r *sstable.Reader,
+	transforms sstable.FragmentIterTransforms,
+	fl fileLoc,
+	searchKey []byte,
+	refs []findRef,
+) ([]findRef, error) {
+	iter, err := r.NewRawRangeKeyIter(context.Background(), transforms)
+	if err != nil || iter == nil {
+		return refs, err
+	}
+	defer iter.Close()
+	s, err := iter.SeekGE(searchKey)
+	if err != nil || s == nil || !s.Contains(r.Compare, searchKey) {
+		return refs, err
+	}
+	err = rangekey.Encode(*s, func(k base.InternalKey, v []byte) error {
+		refs = append(refs, findRef{
+			key:      k.Clone(),
+			value:    slices.Clone(v),
+			fileNum:  base.PhysicalTableFileNum(fl.DiskFileNum),
+			filename: filepath.Base(fl.path),
+		})
+		return nil
+	})
+	return refs, err
+}
+
 // Determine the provenance of the specified table. We search the version edits
 // for the first edit which created the table, and then analyze the edit to
 // determine if it was a compaction, flush, or ingestion. Returns an empty
 // string if the provenance of a table cannot be determined.
-func (f *findT) tableProvenance(fileNum base.FileNum) string {
+func (f *findT) tableProvenance(fileNum base.FileNum, searchKey []byte) string {
 	editRefs := f.editRefs[base.PhysicalTableDiskFileNum(fileNum)]
 	for len(editRefs) > 0 {
 		ve := f.edits[editRefs[0]]
@@ -582,8 +676,32 @@ func (f *findT) tableProvenance(fileNum base.FileNum) string {
 				continue
 			}
 
+			// A virtual table is created when an excise or an ingest split removes
+			// part of an existing table backed by the same table (its parent).
+			var parents []base.FileNum
+			if nf.Meta.Virtual {
+				for df := range ve.DeletedFiles {
+					if f.backingFileNum(df.FileNum) == nf.BackingFileNum {
+						parents = append(parents, df.FileNum)
+					}
+				}
+				slices.Sort(parents)
+			}
+
 			var buf bytes.Buffer
 			switch {
+			case len(parents) > 0:
+				fmt.Fprintf(&buf, "virtualized from")
+				for _, parent := range parents {
+					fmt.Fprintf(&buf, " %s", parent)
+				}
+				fmt.Fprintf(&buf, " in L%d", nf.Level)
+
+			case f.isExcise(&ve) && nf.Meta.SmallestSeqNum == nf.Meta.LargestSeqNum:
+				// A version edit that excises tables and adds a table with a single
+				// seqnum is an ingestion with an excise span.
+				fmt.Fprintf(&buf, "ingested to L%d", nf.Level)
+
 			case len(ve.DeletedFiles) > 0:
 				// A version edit with deleted files is a compaction. The deleted
 				// files are the inputs to the compaction. We're going to
@@ -654,11 +772,16 @@ func (f *findT) tableProvenance(fileNum base.FileNum) string {
 			// table from one level and adds the same table to a different
 			// level. Loop over the remaining version edits for the table looking for
 			// such moves.
+			//
+			// A table can also be excised, which removes it and adds virtual tables
+			// backed by the same table that no longer contain the search key.
 			for len(editRefs) > 0 {
-				ve := f.edits[editRefs[0]]
+				ve := &f.edits[editRefs[0]]
 				editRefs = editRefs[1:]
+				added := false
 				for _, nf := range ve.NewFiles {
 					if fileNum == nf.Meta.FileNum {
+						added = true
 						for df := range ve.DeletedFiles {
 							if fileNum == df.FileNum {
 								fmt.Fprintf(&buf, ", moved to L%d", nf.Level)
@@ -668,6 +791,9 @@ func (f *findT) tableProvenance(fileNum base.FileNum) string {
 						break
 					}
 				}
+				if !added && f.excisedKey(fileNum, ve, searchKey) {
+					fmt.Fprintf(&buf, ", excised")
+				}
 			}
 
 			return buf.String()
@@ -675,3 +801,136 @@ func (f *findT) tableProvenance(fileNum base.FileNum) string {
 	}
 	return ""
 }
+
+// printVirtualTables prints the virtual tables backed by the table with the
+// specified file num, along with whether they expose the search key.
+func (f *findT) printVirtualTables(stdout io.Writer, fileNum base.FileNum, searchKey []byte) {
+	for _, virtualFileNum := range f.virtualTables[base.PhysicalTableDiskFileNum(fileNum)] {
+		m := f.tableMeta[virtualFileNum]
+		fmt.Fprintf(stdout, "    virtual %s ", virtualFileNum)
+		formatKeyRange(stdout, f.fmtKey, &m.Smallest, &m.Largest)
+		fmt.Fprintf(stdout, "\n")
+		if p := f.tableProvenance(virtualFileNum, searchKey); p != "" {
+			fmt.Fprintf(stdout, "        (%s)\n", p)
+		}
+		level, removedBy := f.tableFate(virtualFileNum)
+		if !f.tableContains(virtualFileNum, searchKey) {
+			fmt.Fprintf(stdout, "        key outside of bounds\n")
+			continue
+		}
+		if removedBy < 0 {
+			fmt.Fprintf(stdout, "        key visible in L%d\n", level)
+			continue
+		}
+		ve := &f.edits[removedBy]
+		if next, ok := f.virtualReplacement(virtualFileNum, ve, searchKey); ok {
+			fmt.Fprintf(stdout, "        key visible through %s\n", next)
+		} else if f.excisedKey(virtualFileNum, ve, searchKey) {
+			fmt.Fprintf(stdout, "        key excised from L%d\n", level)
+		} else {
+			fmt.Fprintf(stdout, "        key removed from L%d\n", level)
+		}
+	}
+}
+
+// backingFileNum returns the disk file num of the table backing the table
+// with the specified file num.
+func (f *findT) backingFileNum(fileNum base.FileNum) base.DiskFileNum {
+	if backing, ok := f.virtualBacking[fileNum]; ok {
+		return backing
+	}
+	return base.PhysicalTableDiskFileNum(fileNum)
+}
+
+// tableContains returns true if the bounds of the table with the specified
+// file num contain the search key. Returns false if the table's metadata is
+// unknown.
+func (f *findT) tableContains(fileNum base.FileNum, searchKey []byte) bool {
+	m := f.tableMeta[fileNum]
+	if m == nil {
+		return false
+	}
+	bounds := m.UserKeyBounds()
+	return bounds.ContainsUserKey(f.opts.Comparer.Compare, searchKey)
+}
+
+// tableFate returns the level the table with the specified file num was last
+// added to, and the index of the version edit which removed it from the LSM,
+// or -1 if the table is still live.
+func (f *findT) tableFate(fileNum base.FileNum) (level int, removedBy int) {
+	level, removedBy = -1, -1
+	for _, i := range f.editRefs[base.PhysicalTableDiskFileNum(fileNum)] {
+		ve := &f.edits[i]
+		added := false
+		for _, nf := range ve.NewFiles {
+			if fileNum == nf.Meta.FileNum {
+				level, added = nf.Level, true
+				break
+			}
+		}
+		if added {
+			removedBy = -1
+			continue
+		}
+		for df := range ve.DeletedFiles {
+			if fileNum == df.FileNum {
+				removedBy = i
+				break
+			}
+		}
+	}
+	return level, removedBy
+}
+
+// isExcise returns true if the version edit excised part of a table, i.e. it
+// removed a table and added a virtual table backed by the same table.
+func (f *findT) isExcise(ve *manifest.VersionEdit) bool {
+	for _, nf := range ve.NewFiles {
+		if !nf.Meta.Virtual {
+			continue
+		}
+		for df := range ve.DeletedFiles {
+			if df.FileNum != nf.Meta.FileNum && f.backingFileNum(df.FileNum) == nf.BackingFileNum {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// virtualReplacement returns the file num of the virtual table, added by the
+// version edit which removes the table with the specified file num, through
+// which the search key remains visible.
+func (f *findT) virtualReplacement(
+	fileNum base.FileNum, ve *manifest.VersionEdit, searchKey []byte,
+) (base.FileNum, bool) {
+	backing := f.backingFileNum(fileNum)
+	for _, nf := range ve.NewFiles {
+		if nf.Meta.Virtual && nf.BackingFileNum == backing && f.tableContains(nf.Meta.FileNum, searchKey) {
+			return nf.Meta.FileNum, true
+		}
+	}
+	return 0, false
+}
+
+// excisedKey returns true if the version edit, which removes the table with
+// the specified file num, excised the search key from the table. That is the
+// case if the table contains the search key, and the version edit replaced the
+// table with virtual tables backed by the same table, none of which contain
+// the search key.
+func (f *findT) excisedKey(fileNum base.FileNum, ve *manifest.VersionEdit, searchKey []byte) bool {
+	if !f.tableContains(fileNum, searchKey) {
+		return false
+	}
+	backing := f.backingFileNum(fileNum)
+	replaced := false
+	for _, nf := range ve.NewFiles {
+		if nf.Meta.Virtual && nf.BackingFileNum == backing {
+			if f.tableContains(nf.Meta.FileNum, searchKey) {
+				return false
+			}
+			replaced = true
+		}
+	}
+	return replaced
+}
@@ -138,15 +138,32 @@ func (l *lsmT) runLSM(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	edits := l.readManifest(args[0])
+	if ok, err := l.buildState(args[0]); !ok || err != nil {
+		return err
+	}
+
+	d3Src := "https://d3js.org/d3.v5.min.js"
+	if !l.embed {
+		d3Src = "data/d3.v5.min.js"
+	}
+	l.writeHTML(l.Root.OutOrStdout(), d3Src)
+	return nil
+}
+
+// buildState reads the MANIFEST at the given path and builds the state that is
+// visualized from the version edits selected by the flags. Returns false if
+// the MANIFEST could not be read; the error is printed in that case.
+func (l *lsmT) buildState(path string) (bool, error) {
+	edits := l.readManifest(path)
 	if edits == nil {
-		return nil
+		return false, nil
 	}
 
+	var err error
 	if l.startEdit > 0 {
 		edits, err = l.coalesceEdits(edits)
 		if err != nil {
-			return err
+			return false, err
 		}
 	}
 	if l.endEdit < int64(len(edits)) {
@@ -157,13 +174,15 @@ func (l *lsmT) runLSM(cmd *cobra.Command, args []string) error {
 	}
 
 	l.buildKeys(edits)
-	err = l.buildEdits(edits)
-	if err != nil {
-		return err
+	if err := l.buildEdits(edits); err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	w := l.Root.OutOrStdout()
-
+// writeHTML writes the visualization of the state to w, loading d3 from
+// d3Src.
+func (l *lsmT) writeHTML(w io.Writer, d3Src string) {
 	fmt.Fprintf(w, `<!DOCTYPE html>
 <html>
 <head>
@@ -176,11 +195,7 @@ func (l *lsmT) runLSM(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(w, "<link rel=\"stylesheet\" href=\"data/lsm.css\">\n")
 	}
 	fmt.Fprintf(w, "</head>\n<body>\n")
-	if l.embed {
-		fmt.Fprintf(w, "<script src=\"https://d3js.org/d3.v5.min.js\"></script>\n")
-	} else {
-		fmt.Fprintf(w, "<script src=\"data/d3.v5.min.js\"></script>\n")
-	}
+	fmt.Fprintf(w, "<script src=\"%s\"></script>\n", d3Src)
 	fmt.Fprintf(w, "<script type=\"text/javascript\">\n")
 	fmt.Fprintf(w, "data = %s\n", l.formatJSON(l.state))
 	fmt.Fprintf(w, "</script>\n")
@@ -190,8 +205,6 @@ func (l *lsmT) runLSM(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(w, "<script src=\"data/lsm.js\"></script>\n")
 	}
 	fmt.Fprintf(w, "</body>\n</html>\n")
-
-	return nil
 }
 
 func (l *lsmT) readManifest(path string) []*manifest.VersionEdit {
@@ -23,6 +23,22 @@ EOF
 
 cat data/lsm.js >> ${dest}
 
+cat >> ${dest} <<EOF
+\`
+
+var lsmDataD3JS = \`
+EOF
+
+cat data/d3.v5.min.js >> ${dest}
+
+cat >> ${dest} <<EOF
+\`
+
+var lsmDataExplorerHTML = \`
+EOF
+
+cat data/lsm_explorer.html >> ${dest}
+
 cat>> ${dest} <<EOF
 \`
 EOF
@@ -68,9 +68,13 @@ func newSSTable(
 	s.Check = &cobra.Command{
 		Use:   "check <sstables>",
 		Short: "verify checksums and metadata",
-		Long:  ``,
-		Args:  cobra.MinimumNArgs(1),
-		Run:   s.runCheck,
+		Long: `
+Verify the sstables. The checksum of every block is verified as the block is
+stored, and each corrupt block is reported. The keys are then checked to be in
+order and to be found by prefix iteration.
+`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  s.runCheck,
 	}
 	s.Layout = &cobra.Command{
 		Use:   "layout <sstables>",
@@ -185,6 +189,17 @@ func (s *sstableT) runCheck(cmd *cobra.Command, args []string) {
 		s.fmtKey.setForComparer(r.Properties.ComparerName, s.comparers)
 		s.fmtValue.setForComparer(r.Properties.ComparerName, s.comparers)
 
+		corrupt, err := r.FindCorruptBlocks(context.Background(), nil /* pace */)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s\n", err)
+			return
+		}
+		for _, b := range corrupt {
+			fmt.Fprintf(stdout, "WARNING: CORRUPT BLOCK!\n")
+			fmt.Fprintf(stdout, "    %s block [%d, %d): %s\n",
+				b.Name, b.Handle.Offset, b.Handle.Offset+b.Handle.Length, b.Err)
+		}
+
 		iter, err := r.NewIter(sstable.NoTransforms, nil, nil)
 		if err != nil {
 			fmt.Fprintf(stderr, "%s\n", err)
@@ -0,0 +1,102 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+const version = pebble.FormatVirtualSSTables
+const dbName = "find-virtual-db"
+
+// main generates a database in which excises turned a table into virtual
+// tables, for testing the find tool.
+func main() {
+	opts := &pebble.Options{
+		FormatMajorVersion:          version,
+		DisableAutomaticCompactions: true,
+		ErrorIfExists:               true,
+	}
+	opts.EnsureDefaults()
+
+	path, err := filepath.Abs(dbName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	db, err := pebble.Open(path, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		if db != nil {
+			db.Close()
+		}
+	}()
+
+	for _, k := range strings.Fields("aaa ccc hhh mmm rrr www") {
+		if err := db.Set([]byte(k), []byte(k), pebble.Sync); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := db.Flush(); err != nil {
+		log.Fatal(err)
+	}
+	if err := db.Compact([]byte("a"), []byte("z"), false /* parallelize */); err != nil {
+		log.Fatal(err)
+	}
+
+	// Ingest a table with key mmm, excising [ggg, ttt). The compacted table is
+	// replaced by two virtual tables with bounds [aaa, ccc] and [www, www].
+	sstPath := filepath.Join(path, "ingest.sst")
+	f, err := vfs.Default.Create(sstPath, vfs.WriteCategoryUnspecified)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := sstable.NewWriter(objstorageprovider.NewFileWritable(f), opts.MakeWriterOptions(0, version.MaxTableFormat()))
+	if err := w.Set([]byte("mmm"), []byte("ingested")); err != nil {
+		log.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		log.Fatal(err)
+	}
+	exciseSpan := pebble.KeyRange{Start: []byte("ggg"), End: []byte("ttt")}
+	if _, err := db.IngestAndExcise(context.Background(), []string{sstPath}, nil, nil, exciseSpan); err != nil {
+		log.Fatal(err)
+	}
+
+	// Excise [bbb, ddd), which narrows the first virtual table to [aaa, aaa].
+	exciseSpan = pebble.KeyRange{Start: []byte("bbb"), End: []byte("ddd")}
+	sstPath = filepath.Join(path, "excise.sst")
+	f, err = vfs.Default.Create(sstPath, vfs.WriteCategoryUnspecified)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w = sstable.NewWriter(objstorageprovider.NewFileWritable(f), opts.MakeWriterOptions(0, version.MaxTableFormat()))
+	if err := w.DeleteRange(exciseSpan.Start, exciseSpan.End); err != nil {
+		log.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := db.IngestAndExcise(context.Background(), []string{sstPath}, nil, nil, exciseSpan); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Generated db with following LSM:\n%s\n", db.DebugString())
+	err = db.Close()
+	db = nil
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -853,6 +853,10 @@ func (vs *versionSet) incrementCompactions(
 		vs.metrics.Compact.Count++
 		vs.metrics.Compact.RewriteCount++
 
+	case compactionKindTableFormatRewrite:
+		vs.metrics.Compact.Count++
+		vs.metrics.Compact.TableFormatRewriteCount++
+
 	case compactionKindCopy:
 		vs.metrics.Compact.Count++
 		vs.metrics.Compact.CopyCount++
@@ -625,6 +625,7 @@ func (wm *failoverManager) Create(wn NumWAL, jobID int) (Writer, error) {
 		minSyncInterval:             wm.opts.MinSyncInterval,
 		fsyncLatency:                wm.opts.FsyncLatency,
 		queueSemChan:                wm.opts.QueueSemChan,
+		compression:                 wm.opts.compression(),
 		stopper:                     wm.stopper,
 		failoverWriteAndSyncLatency: wm.opts.FailoverWriteAndSyncLatency,
 		writerClosed:                wm.writerClosed,
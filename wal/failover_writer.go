@@ -13,6 +13,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/record"
+	"github.com/cockroachdb/pebble/sstable/block"
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -456,6 +457,7 @@ type failoverWriterOpts struct {
 	minSyncInterval func() time.Duration
 	fsyncLatency    prometheus.Histogram
 	queueSemChan    chan struct{}
+	compression     block.Compression
 	stopper         *stopper
 
 	failoverWriteAndSyncLatency prometheus.Histogram
@@ -631,6 +633,7 @@ func (ww *failoverWriter) switchToNewDir(dir dirAndFileHandle) error {
 				WALMinSyncInterval:        ww.opts.minSyncInterval,
 				WALFsyncLatency:           ww.opts.fsyncLatency,
 				QueueSemChan:              ww.opts.queueSemChan,
+				Compression:               ww.opts.compression,
 				ExternalSyncQueueCallback: ww.doneSyncCallback,
 			})
 		closeWriter := func() bool {
@@ -0,0 +1,380 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package wal
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/record"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+// parallelManager implements Manager by writing each WAL as parallel streams,
+// with one log file per stream in each of Options.Primary and
+// Options.Streams. This allows the WAL's write and fsync throughput to scale
+// with the number of devices backing the dirs.
+//
+// Records are assigned to streams round-robin by the order in which they are
+// written, and a sync is only acknowledged once all the streams have synced
+// the records written before it. A reader interleaves the streams in the same
+// round-robin order, which recovers the original order of the records. See
+// parallelWALReader.
+//
+// Log files are not recycled, since recycling would require recycling a log
+// file in every dir at once.
+type parallelManager struct {
+	o Options
+	// dirs holds Options.Primary followed by Options.Streams. The log file for
+	// stream i is in dirs[i].
+	dirs []Dir
+	// dirHandles are the opened dirs, used to sync a dir after creating a log
+	// file in it.
+	dirHandles []vfs.File
+	// initialObsolete holds the set of DeletableLogs that formed the logs
+	// passed into Init. The initialObsolete logs are all obsolete. Once
+	// returned via Manager.Obsolete, initialObsolete is cleared.
+	initialObsolete []DeletableLog
+
+	// External synchronization is relied on when accessing w in Manager.Create,
+	// Writer.{WriteRecord,Close}.
+	w *parallelWriter
+
+	mu struct {
+		sync.Mutex
+		// The queue of WALs, containing both flushed and unflushed WALs. The
+		// flushed logs are a prefix, the unflushed logs a suffix. If w != nil,
+		// the last entry here is that active WAL.
+		queue []parallelLog
+	}
+}
+
+// parallelLog describes a WAL written by parallelManager.
+type parallelLog struct {
+	num NumWAL
+	// fileSizes[i] is the size of the log file of stream i. It is updated when
+	// the WAL is closed.
+	fileSizes []uint64
+}
+
+var _ Manager = &parallelManager{}
+
+// init implements Manager.
+func (m *parallelManager) init(o Options, initial Logs) error {
+	if o.Secondary != (Dir{}) {
+		return base.AssertionFailedf("cannot create parallelManager with a secondary")
+	}
+	*m = parallelManager{
+		o:    o,
+		dirs: o.Dirs(),
+	}
+	for _, dir := range m.dirs {
+		f, err := dir.FS.OpenDir(dir.Dirname)
+		if err != nil {
+			return firstError(err, m.closeDirs())
+		}
+		m.dirHandles = append(m.dirHandles, f)
+	}
+	for _, ll := range initial {
+		var err error
+		m.initialObsolete, err = appendDeletableLogs(m.initialObsolete, ll)
+		if err != nil {
+			return firstError(err, m.closeDirs())
+		}
+	}
+	return nil
+}
+
+func (m *parallelManager) closeDirs() error {
+	var err error
+	for _, f := range m.dirHandles {
+		err = firstError(err, f.Close())
+	}
+	m.dirHandles = nil
+	return err
+}
+
+// List implements Manager.
+func (m *parallelManager) List() (Logs, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	wals := make(Logs, len(m.mu.queue))
+	for i := range m.mu.queue {
+		wals[i] = LogicalLog{
+			Num:      m.mu.queue[i].num,
+			segments: make([]segment, len(m.dirs)),
+		}
+		for j := range m.dirs {
+			wals[i].segments[j] = segment{stream: j, dir: m.dirs[j]}
+		}
+	}
+	return wals, nil
+}
+
+// Obsolete implements Manager.
+func (m *parallelManager) Obsolete(
+	minUnflushedNum NumWAL, noRecycle bool,
+) (toDelete []DeletableLog, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// If this is the first call to Obsolete after Open, we may have deletable
+	// logs outside the queue.
+	toDelete, m.initialObsolete = m.initialObsolete, nil
+
+	i := 0
+	for ; i < len(m.mu.queue); i++ {
+		pl := m.mu.queue[i]
+		if pl.num >= minUnflushedNum {
+			break
+		}
+		for j, dir := range m.dirs {
+			toDelete = append(toDelete, DeletableLog{
+				FS:             dir.FS,
+				Path:           dir.FS.PathJoin(dir.Dirname, makeStreamLogFilename(pl.num, j)),
+				NumWAL:         pl.num,
+				ApproxFileSize: pl.fileSizes[j],
+			})
+		}
+	}
+	m.mu.queue = m.mu.queue[i:]
+	return toDelete, nil
+}
+
+// Create implements Manager.
+func (m *parallelManager) Create(wn NumWAL, jobID int) (_ Writer, err error) {
+	w := &parallelWriter{
+		m:       m,
+		writers: make([]*record.LogWriter, 0, len(m.dirs)),
+		sizes:   make([]int64, len(m.dirs)),
+	}
+	w.mu.syncedIndex = make([]int64, len(m.dirs))
+	for i := range w.mu.syncedIndex {
+		w.mu.syncedIndex[i] = record.NoSyncIndex
+	}
+	defer func() {
+		if err != nil {
+			for _, lw := range w.writers {
+				err = firstError(err, lw.Close())
+			}
+		}
+	}()
+	// All the streams of a WAL use the same compression.
+	compression := m.o.compression()
+	for i, dir := range m.dirs {
+		logName := dir.FS.PathJoin(dir.Dirname, makeStreamLogFilename(wn, i))
+		f, err := dir.FS.Create(logName, "pebble-wal")
+		if m.o.EventListener != nil {
+			m.o.EventListener.LogCreated(CreateInfo{
+				JobID: jobID,
+				Path:  logName,
+				Num:   wn,
+				Err:   err,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := m.dirHandles[i].Sync(); err != nil {
+			return nil, firstError(err, f.Close())
+		}
+		f = vfs.NewSyncingFile(f, vfs.SyncingFileOptions{
+			NoSyncOnClose:   m.o.NoSyncOnClose,
+			BytesPerSync:    m.o.BytesPerSync,
+			PreallocateSize: m.o.PreallocateSize(),
+		})
+		stream := i
+		w.writers = append(w.writers, record.NewLogWriter(f, base.DiskFileNum(wn), record.LogWriterConfig{
+			WALFsyncLatency:    m.o.FsyncLatency,
+			WALMinSyncInterval: m.o.MinSyncInterval,
+			Compression:        compression,
+			ExternalSyncQueueCallback: func(doneSync record.PendingSyncIndex, err error) {
+				w.doneSyncCallback(stream, doneSync, err)
+			},
+		}))
+	}
+	m.w = w
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mu.queue = append(m.mu.queue, parallelLog{num: wn, fileSizes: make([]uint64, len(m.dirs))})
+	return w, nil
+}
+
+// ElevateWriteStallThresholdForFailover implements Manager.
+func (m *parallelManager) ElevateWriteStallThresholdForFailover() bool {
+	return false
+}
+
+// Stats implements Manager.
+func (m *parallelManager) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var stats Stats
+	for i := range m.mu.queue {
+		for _, size := range m.mu.queue[i].fileSizes {
+			stats.LiveFileCount++
+			stats.LiveFileSize += size
+		}
+	}
+	for i := range m.initialObsolete {
+		stats.ObsoleteFileCount++
+		stats.ObsoleteFileSize += m.initialObsolete[i].ApproxFileSize
+	}
+	return stats
+}
+
+// Close implements Manager.
+func (m *parallelManager) Close() error {
+	var err error
+	if m.w != nil {
+		_, err = m.w.Close()
+	}
+	return firstError(err, m.closeDirs())
+}
+
+// RecyclerForTesting implements Manager.
+func (m *parallelManager) RecyclerForTesting() *LogRecycler {
+	return nil
+}
+
+// parallelWriter implements Writer by writing to one record.LogWriter per
+// stream. The LogWriters notify the parallelWriter of completed syncs via a
+// record.ExternalSyncQueueCallback, and the parallelWriter notifies a sync
+// waiter once every stream has synced the waiter's record index.
+type parallelWriter struct {
+	m       *parallelManager
+	writers []*record.LogWriter
+	// sizes[i] is the size of writers[i] after the last record written to it.
+	sizes []int64
+	// nextIndex is the index of the next record. Record i is written to stream
+	// i%len(writers).
+	nextIndex int64
+	// psiBacking is used to pass a PendingSyncIndex to the LogWriters without
+	// allocating.
+	psiBacking record.PendingSyncIndex
+
+	mu struct {
+		sync.Mutex
+		// syncedIndex[i] is the highest record index such that stream i has
+		// synced all its records with an index <= syncedIndex[i].
+		syncedIndex []int64
+		// waiters are the records that requested a sync and have not been
+		// notified yet, in increasing index order.
+		waiters []syncWaiter
+	}
+}
+
+// syncWaiter is a record that requested a sync.
+type syncWaiter struct {
+	index int64
+	opts  SyncOptions
+}
+
+var _ Writer = &parallelWriter{}
+
+// WriteRecord implements Writer.
+func (w *parallelWriter) WriteRecord(
+	p []byte, opts SyncOptions, _ RefCount,
+) (logicalOffset int64, err error) {
+	index := w.nextIndex
+	w.nextIndex++
+	stream := int(index % int64(len(w.writers)))
+	w.psiBacking = record.PendingSyncIndex{Index: record.NoSyncIndex}
+	if opts.Done != nil {
+		w.mu.Lock()
+		w.mu.waiters = append(w.mu.waiters, syncWaiter{index: index, opts: opts})
+		w.mu.Unlock()
+		w.psiBacking.Index = index
+	}
+	w.sizes[stream], err = w.writers[stream].SyncRecordGeneralized(p, &w.psiBacking)
+	if err == nil && opts.Done != nil {
+		// The records written to the other streams before this one must be
+		// synced too. The sync requested from each stream covers all of its
+		// records with an index <= index.
+		for i := range w.writers {
+			if i != stream {
+				if err = w.writers[i].RequestSync(&w.psiBacking); err != nil {
+					break
+				}
+			}
+		}
+	}
+	return w.size(), err
+}
+
+// size returns the sum of the sizes of the streams.
+func (w *parallelWriter) size() int64 {
+	var size int64
+	for _, s := range w.sizes {
+		size += s
+	}
+	return size
+}
+
+// doneSyncCallback is the record.ExternalSyncQueueCallback of the LogWriter
+// of the provided stream.
+func (w *parallelWriter) doneSyncCallback(stream int, doneSync record.PendingSyncIndex, err error) {
+	w.mu.Lock()
+	// Notify the waiters up to doneSync.Index of an error. Otherwise, the
+	// records that are durable are those synced by all the streams.
+	durableIndex := doneSync.Index
+	if err == nil {
+		w.mu.syncedIndex[stream] = max(w.mu.syncedIndex[stream], doneSync.Index)
+		for _, idx := range w.mu.syncedIndex {
+			durableIndex = min(durableIndex, idx)
+		}
+	}
+	n := 0
+	for n < len(w.mu.waiters) && w.mu.waiters[n].index <= durableIndex {
+		n++
+	}
+	waiters := w.mu.waiters[:n:n]
+	w.mu.waiters = w.mu.waiters[n:]
+	w.mu.Unlock()
+
+	for _, sw := range waiters {
+		*sw.opts.Err = err
+		sw.opts.Done.Done()
+		if w.m.o.QueueSemChan != nil {
+			<-w.m.o.QueueSemChan
+		}
+	}
+}
+
+// Close implements Writer.
+func (w *parallelWriter) Close() (logicalOffset int64, err error) {
+	// Closing a LogWriter syncs all its records, so the last record is synced
+	// by every stream once all of them are closed.
+	lastIndex := record.PendingSyncIndex{Index: w.nextIndex - 1}
+	if w.nextIndex == 0 {
+		lastIndex.Index = record.NoSyncIndex
+	}
+	for i := range w.writers {
+		w.sizes[i] = w.writers[i].Size()
+	}
+	logicalOffset = w.size()
+	for i := range w.writers {
+		err = firstError(err, w.writers[i].CloseWithLastQueuedRecord(lastIndex))
+	}
+
+	w.m.mu.Lock()
+	defer w.m.mu.Unlock()
+	pl := w.m.mu.queue[len(w.m.mu.queue)-1]
+	for i := range pl.fileSizes {
+		pl.fileSizes[i] = uint64(w.sizes[i])
+	}
+	w.m.w = nil
+	return logicalOffset, err
+}
+
+// Metrics implements Writer.
+func (w *parallelWriter) Metrics() record.LogWriterMetrics {
+	var metrics record.LogWriterMetrics
+	for _, lw := range w.writers {
+		m := lw.Metrics()
+		_ = metrics.Merge(&m)
+	}
+	return metrics
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 The LevelDB-Go and Pebble Authors. All rights reserved. Use
+// of this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+
+package wal
+
+import (
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/pebble/batchrepr"
+	"github.com/cockroachdb/pebble/internal/base"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelManager(t *testing.T) {
+	fs := vfs.NewCrashableMem()
+	for _, dirname := range []string{"primary", "stream1", "stream2"} {
+		require.NoError(t, fs.MkdirAll(dirname, 0755))
+	}
+	root, err := fs.OpenDir("")
+	require.NoError(t, err)
+	require.NoError(t, root.Sync())
+	require.NoError(t, root.Close())
+
+	queueSemChan := make(chan struct{}, 100)
+	o := Options{
+		Primary: Dir{FS: fs, Dirname: "primary"},
+		Streams: []Dir{
+			{FS: fs, Dirname: "stream1"},
+			{FS: fs, Dirname: "stream2"},
+		},
+		PreallocateSize: func() int { return 0 },
+		QueueSemChan:    queueSemChan,
+	}
+
+	// Parallel streams cannot be combined with failover.
+	_, err = Init(Options{
+		Primary:   o.Primary,
+		Secondary: Dir{FS: fs, Dirname: "secondary"},
+		Streams:   o.Streams,
+	}, nil)
+	require.Error(t, err)
+
+	m, err := Init(o, nil)
+	require.NoError(t, err)
+	w, err := m.Create(1, 0)
+	require.NoError(t, err)
+
+	// readSeqNums reads WAL 1 from the provided FS, returning the sequence
+	// numbers of its batches.
+	readSeqNums := func(fs vfs.FS) []base.SeqNum {
+		logs, err := Scan(
+			Dir{FS: fs, Dirname: "primary"},
+			Dir{FS: fs, Dirname: "stream1"},
+			Dir{FS: fs, Dirname: "stream2"},
+		)
+		require.NoError(t, err)
+		ll, ok := logs.Get(1)
+		require.True(t, ok)
+		require.Equal(t, 3, ll.NumSegments())
+		r := ll.OpenForRead()
+		defer func() { require.NoError(t, r.Close()) }()
+		var seqNums []base.SeqNum
+		for {
+			rr, _, err := r.NextRecord()
+			if err == io.EOF {
+				return seqNums
+			}
+			require.NoError(t, err)
+			b, err := io.ReadAll(rr)
+			require.NoError(t, err)
+			h, ok := batchrepr.ReadHeader(b)
+			require.True(t, ok)
+			seqNums = append(seqNums, h.SeqNum)
+		}
+	}
+
+	// Write 100 batches, requesting a sync for every 10th batch. Once a sync
+	// completes, the batch and all the batches before it must survive a crash,
+	// regardless of which streams they were written to.
+	const numBatches = 100
+	for i := 0; i < numBatches; i++ {
+		repr := make([]byte, batchrepr.HeaderLen+10)
+		batchrepr.SetSeqNum(repr, base.SeqNum(i+1))
+		batchrepr.SetCount(repr, 1)
+		if i%10 != 9 {
+			_, err := w.WriteRecord(repr, SyncOptions{}, nil)
+			require.NoError(t, err)
+			continue
+		}
+		var wg sync.WaitGroup
+		var syncErr error
+		wg.Add(1)
+		queueSemChan <- struct{}{}
+		_, err := w.WriteRecord(repr, SyncOptions{Done: &wg, Err: &syncErr}, nil)
+		require.NoError(t, err)
+		wg.Wait()
+		require.NoError(t, syncErr)
+		require.Empty(t, queueSemChan)
+
+		crashFS := fs.CrashClone(vfs.CrashCloneCfg{UnsyncedDataPercent: 0})
+		seqNums := readSeqNums(crashFS)
+		require.Len(t, seqNums, i+1)
+		for j := range seqNums {
+			require.Equal(t, base.SeqNum(j+1), seqNums[j])
+		}
+	}
+	logicalOffset, err := w.Close()
+	require.NoError(t, err)
+	require.Len(t, readSeqNums(fs), numBatches)
+
+	stats := m.Stats()
+	require.Equal(t, 3, stats.LiveFileCount)
+	require.Equal(t, uint64(logicalOffset), stats.LiveFileSize)
+
+	logs, err := m.List()
+	require.NoError(t, err)
+	require.Equal(t, "000001: {(primary,000), (stream1,s01), (stream2,s02)}", logs[0].String())
+
+	// Once WAL 1 is obsolete, the log files of all its streams are deletable.
+	w, err = m.Create(2, 0)
+	require.NoError(t, err)
+	_, err = w.Close()
+	require.NoError(t, err)
+	toDelete, err := m.Obsolete(2, false /* noRecycle */)
+	require.NoError(t, err)
+	var paths []string
+	for _, dl := range toDelete {
+		require.Equal(t, NumWAL(1), dl.NumWAL)
+		paths = append(paths, dl.Path)
+	}
+	require.Equal(t, []string{
+		"primary/000001.log",
+		"stream1/000001-s01.log",
+		"stream2/000001-s02.log",
+	}, paths)
+	require.NoError(t, m.Close())
+}
@@ -24,20 +24,35 @@ type LogicalLog struct {
 	Num NumWAL
 	// segments contains the list of the consistuent physical segment files that
 	// make up the single logical WAL file. segments is ordered by increasing
-	// logIndex.
+	// (stream, logIndex).
 	segments []segment
 }
 
 // A segment represents an individual physical file that makes up a contiguous
 // segment of a logical WAL. If a failover occurred during a WAL's lifetime, a
-// WAL may be composed of multiple segments.
+// WAL may be composed of multiple segments. If the WAL was written as parallel
+// streams, it is composed of one segment per stream.
 type segment struct {
 	logNameIndex LogNameIndex
-	dir          Dir
+	// stream is the stream of a WAL written as parallel streams, and zero
+	// otherwise.
+	stream int
+	dir    Dir
+}
+
+// filename returns the name of the segment's file.
+func (s segment) filename(wn NumWAL) string {
+	if s.stream > 0 {
+		return makeStreamLogFilename(wn, s.stream)
+	}
+	return makeLogFilename(wn, s.logNameIndex)
 }
 
 // String implements fmt.Stringer.
 func (s segment) String() string {
+	if s.stream > 0 {
+		return fmt.Sprintf("(%s,s%02d)", s.dir.Dirname, s.stream)
+	}
 	return fmt.Sprintf("(%s,%s)", s.dir.Dirname, s.logNameIndex)
 }
 
@@ -48,7 +63,7 @@ func (ll LogicalLog) NumSegments() int { return len(ll.segments) }
 // SegmentLocation returns the FS and path for the i-th physical segment file.
 func (ll LogicalLog) SegmentLocation(i int) (vfs.FS, string) {
 	s := ll.segments[i]
-	path := s.dir.FS.PathJoin(s.dir.Dirname, makeLogFilename(ll.Num, s.logNameIndex))
+	path := s.dir.FS.PathJoin(s.dir.Dirname, s.filename(ll.Num))
 	return s.dir.FS, path
 }
 
@@ -68,9 +83,17 @@ func (ll LogicalLog) PhysicalSize() (uint64, error) {
 
 // OpenForRead a logical WAL for reading.
 func (ll LogicalLog) OpenForRead() Reader {
+	if ll.isParallel() {
+		return newParallelWALReader(ll)
+	}
 	return newVirtualWALReader(ll)
 }
 
+// isParallel returns true if the WAL was written as parallel streams.
+func (ll LogicalLog) isParallel() bool {
+	return len(ll.segments) > 0 && ll.segments[len(ll.segments)-1].stream > 0
+}
+
 // String implements fmt.Stringer.
 func (ll LogicalLog) String() string {
 	var sb strings.Builder
@@ -154,7 +177,7 @@ func (a *FileAccumulator) Finish() Logs {
 func (a *FileAccumulator) maybeAccumulate(
 	fs vfs.FS, dirname, name string,
 ) (isLogFile bool, err error) {
-	dfn, li, ok := ParseLogFilename(name)
+	dfn, li, stream, ok := parseLogFilename(name)
 	if !ok {
 		return false, nil
 	}
@@ -167,17 +190,22 @@ func (a *FileAccumulator) maybeAccumulate(
 	}
 	// Ensure we haven't seen this log index yet, and find where it
 	// slots within this log's segments.
-	j, found := slices.BinarySearchFunc(a.wals[i].segments, li, func(s segment, li LogNameIndex) int {
-		return cmp.Compare(s.logNameIndex, li)
+	seg := segment{logNameIndex: li, stream: stream, dir: Dir{
+		FS:      fs,
+		Dirname: dirname,
+	}}
+	j, found := slices.BinarySearchFunc(a.wals[i].segments, seg, func(s, seg segment) int {
+		return cmp.Or(cmp.Compare(s.stream, seg.stream), cmp.Compare(s.logNameIndex, seg.logNameIndex))
 	})
 	if found {
+		if stream > 0 {
+			return false, errors.Errorf("wal: duplicate stream=%d for WAL %s in %s and %s",
+				stream, dfn, dirname, a.wals[i].segments[j].dir.Dirname)
+		}
 		return false, errors.Errorf("wal: duplicate logIndex=%s for WAL %s in %s and %s",
 			li, dfn, dirname, a.wals[i].segments[j].dir.Dirname)
 	}
-	a.wals[i].segments = slices.Insert(a.wals[i].segments, j, segment{logNameIndex: li, dir: Dir{
-		FS:      fs,
-		Dirname: dirname,
-	}})
+	a.wals[i].segments = slices.Insert(a.wals[i].segments, j, seg)
 	return true, nil
 }
 
@@ -370,3 +398,124 @@ func (r *virtualWALReader) nextFile() error {
 	r.currReader = record.NewReader(r.currFile, base.DiskFileNum(r.Num))
 	return nil
 }
+
+func newParallelWALReader(wal LogicalLog) *parallelWALReader {
+	return &parallelWALReader{LogicalLog: wal}
+}
+
+// A parallelWALReader implements the wal.Reader interface for a WAL written
+// as parallel streams. Records are assigned to the streams round-robin, so the
+// reader reads one record from each stream in turn, recovering the order in
+// which the records were written. Reading stops at the first stream that is
+// exhausted. A sync waits for all the streams to be synced, so any records in
+// the other streams beyond that point were never acknowledged as durable, and
+// replaying them would leave a gap in the recovered history.
+type parallelWALReader struct {
+	// VirtualWAL metadata.
+	LogicalLog
+
+	files   []vfs.File
+	readers []*record.Reader
+	// next is the stream from which the next record is read.
+	next int
+	// off describes the current Offset within the WAL. Offset.Physical is the
+	// offset within the current stream, and Offset.PreviousFilesBytes is the
+	// sum of the offsets within the other streams, so that their sum increases
+	// monotonically as records are read.
+	off Offset
+	// lastSeqNum is the sequence number of the batch contained within the last
+	// record returned to the user. The round-robin order must yield batches in
+	// increasing sequence number order; anything else is corruption.
+	lastSeqNum base.SeqNum
+	// recordBuf is a buffer used to hold the latest record read from a stream,
+	// and then returned to the user.
+	recordBuf bytes.Buffer
+}
+
+// *parallelWALReader implements wal.Reader.
+var _ Reader = (*parallelWALReader)(nil)
+
+// NextRecord returns a reader for the next record. It returns io.EOF if there
+// are no more records. The reader returned becomes stale after the next
+// NextRecord call, and should no longer be used.
+func (r *parallelWALReader) NextRecord() (io.Reader, Offset, error) {
+	if r.readers == nil {
+		if err := r.open(); err != nil {
+			return nil, Offset{}, err
+		}
+	}
+	for {
+		stream := r.next
+		r.next = (r.next + 1) % len(r.readers)
+		_, r.off.PhysicalFile = r.LogicalLog.SegmentLocation(stream)
+		r.off.Physical = r.readers[stream].Offset()
+		r.off.PreviousFilesBytes = 0
+		for i := range r.readers {
+			if i != stream {
+				r.off.PreviousFilesBytes += r.readers[i].Offset()
+			}
+		}
+
+		rec, err := r.readers[stream].Next()
+		r.recordBuf.Reset()
+		if err == nil {
+			_, err = io.Copy(&r.recordBuf, rec)
+		}
+		if err != nil {
+			// An exhausted stream ends the WAL. An invalid record is bubbled up
+			// as it is for the last segment of a virtual WAL, leaving the caller
+			// to decide whether it's consistent with an incomplete in-flight
+			// write at the time of a crash.
+			return nil, r.off, err
+		}
+		h, ok := batchrepr.ReadHeader(r.recordBuf.Bytes())
+		if !ok {
+			return nil, r.off, base.CorruptionErrorf("pebble: corrupt log file logNum=%d, stream=%d: invalid batch",
+				r.Num, errors.Safe(stream))
+		}
+		// LogData-only batches repeat a sequence number and are not relevant
+		// for recovery. See the comment in virtualWALReader.NextRecord.
+		if h.Count == 0 {
+			continue
+		}
+		if h.SeqNum <= r.lastSeqNum {
+			return nil, r.off, base.CorruptionErrorf("pebble: corrupt log file logNum=%d, stream=%d: batch seqnum %d <= %d",
+				r.Num, errors.Safe(stream), errors.Safe(h.SeqNum), errors.Safe(r.lastSeqNum))
+		}
+		r.lastSeqNum = h.SeqNum
+		return &r.recordBuf, r.off, nil
+	}
+}
+
+// open opens the files of all the streams.
+func (r *parallelWALReader) open() error {
+	r.files = make([]vfs.File, 0, len(r.segments))
+	r.readers = make([]*record.Reader, 0, len(r.segments))
+	for i := range r.segments {
+		// The streams of a WAL are created before any record is written, so
+		// the streams must be dense. Trailing streams may only be missing if
+		// the WAL was never written to.
+		if s := r.segments[i]; s.stream != i || s.logNameIndex != 0 {
+			return base.CorruptionErrorf("pebble: corrupt WAL %s: unexpected log file %s",
+				r.Num, errors.Safe(s.filename(r.Num)))
+		}
+		fs, path := r.LogicalLog.SegmentLocation(i)
+		f, err := fs.Open(path)
+		if err != nil {
+			return errors.Wrapf(err, "opening WAL stream file %q", path)
+		}
+		r.files = append(r.files, f)
+		r.readers = append(r.readers, record.NewReader(f, base.DiskFileNum(r.Num)))
+	}
+	return nil
+}
+
+// Close closes the reader, releasing open resources.
+func (r *parallelWALReader) Close() error {
+	var err error
+	for _, f := range r.files {
+		err = firstError(err, f.Close())
+	}
+	r.files = nil
+	return err
+}
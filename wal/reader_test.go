@@ -106,12 +106,17 @@ func TestReader(t *testing.T) {
 		case "define":
 			var logNum uint64
 			var index int64
+			var stream int
 			var recycleFilename string
 			td.ScanArgs(t, "logNum", &logNum)
 			td.MaybeScanArgs(t, "logNameIndex", &index)
+			td.MaybeScanArgs(t, "stream", &stream)
 			td.MaybeScanArgs(t, "recycleFilename", &recycleFilename)
 
 			filename := makeLogFilename(NumWAL(logNum), LogNameIndex(index))
+			if stream > 0 {
+				filename = makeStreamLogFilename(NumWAL(logNum), stream)
+			}
 			var f vfs.File
 			var err error
 			if recycleFilename != "" {
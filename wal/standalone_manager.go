@@ -197,6 +197,7 @@ func (m *StandaloneManager) Create(wn NumWAL, jobID int) (Writer, error) {
 		WALFsyncLatency:    m.o.FsyncLatency,
 		WALMinSyncInterval: m.o.MinSyncInterval,
 		QueueSemChan:       m.o.QueueSemChan,
+		Compression:        m.o.compression(),
 	})
 	m.w = &standaloneWriter{
 		m: m,
@@ -12,8 +12,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/internal/base"
 	"github.com/cockroachdb/pebble/record"
+	"github.com/cockroachdb/pebble/sstable/block"
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -29,7 +31,8 @@ type Dir struct {
 // NumWAL is the number of the virtual WAL. It can map to one or more physical
 // log files. In standalone mode, it will map to exactly one log file. In
 // failover mode, it can map to many log files, which are totally ordered
-// (using a dense logNameIndex).
+// (using a dense logNameIndex). With parallel streams, it maps to one log file
+// per stream, whose records are interleaved round-robin.
 //
 // In general, WAL refers to the virtual WAL, and file refers to a log file.
 // The Pebble MANIFEST only knows about virtual WALs and assigns numbers to
@@ -58,13 +61,33 @@ func makeLogFilename(wn NumWAL, index LogNameIndex) string {
 	return fmt.Sprintf("%s-%s.log", base.DiskFileNum(wn).String(), index)
 }
 
+// makeStreamLogFilename makes the filename of a WAL's log file for the
+// provided stream, when the WAL is written as parallel streams. The first
+// stream uses the same filename as a WAL that is not written as parallel
+// streams.
+func makeStreamLogFilename(wn NumWAL, stream int) string {
+	if stream == 0 {
+		return makeLogFilename(wn, 0)
+	}
+	return fmt.Sprintf("%s-s%02d.log", base.DiskFileNum(wn).String(), stream)
+}
+
 // ParseLogFilename takes a base filename and parses it into its constituent
 // NumWAL and LogNameIndex. If the filename is not a log file, it returns false
-// for the final return value.
+// for the final return value. The log file of a stream of a WAL that is
+// written as parallel streams has a LogNameIndex of zero.
 func ParseLogFilename(name string) (NumWAL, LogNameIndex, bool) {
+	wn, li, _, ok := parseLogFilename(name)
+	return wn, li, ok
+}
+
+// parseLogFilename is like ParseLogFilename, but additionally returns the
+// stream of a WAL that is written as parallel streams. The stream is zero for
+// log files that are not written as parallel streams.
+func parseLogFilename(name string) (_ NumWAL, _ LogNameIndex, stream int, ok bool) {
 	i := strings.IndexByte(name, '.')
 	if i < 0 || name[i:] != ".log" {
-		return 0, 0, false
+		return 0, 0, 0, false
 	}
 	j := strings.IndexByte(name[:i], '-')
 	if j < 0 {
@@ -76,19 +99,26 @@ func ParseLogFilename(name string) (NumWAL, LogNameIndex, bool) {
 			// It's conceivable that some of these found their way into a data
 			// directory, and erroring would cause an issue for an existing
 			// Cockroach deployment.
-			return 0, 0, false
+			return 0, 0, 0, false
 		}
-		return NumWAL(dfn), 0, true
+		return NumWAL(dfn), 0, 0, true
 	}
 	dfn, ok := base.ParseDiskFileNum(name[:j])
 	if !ok {
-		return 0, 0, false
+		return 0, 0, 0, false
+	}
+	if suffix := name[j+1 : i]; len(suffix) > 0 && suffix[0] == 's' {
+		s, err := strconv.ParseUint(suffix[1:], 10, 32)
+		if err != nil || s == 0 {
+			return 0, 0, 0, false
+		}
+		return NumWAL(dfn), 0, int(s), true
 	}
 	li, err := strconv.ParseUint(name[j+1:i], 10, 64)
 	if err != nil {
-		return 0, 0, false
+		return 0, 0, 0, false
 	}
-	return NumWAL(dfn), LogNameIndex(li), true
+	return NumWAL(dfn), LogNameIndex(li), 0, true
 }
 
 // Options provides configuration for the Manager.
@@ -99,6 +129,13 @@ type Options struct {
 	// Secondary is used for failover. Optional. It must already be created and
 	// synced up to the root.
 	Secondary Dir
+	// Streams are additional dirs, typically on separate devices, that each
+	// hold one stream of a WAL written as parallel streams. Optional, and
+	// cannot be combined with a Secondary. When set, records are assigned to
+	// the Primary and the Streams round-robin, and each stream is written and
+	// synced independently. They must already be created and synced up to the
+	// root.
+	Streams []Dir
 
 	// MinUnflushedLogNum is the smallest WAL number corresponding to
 	// mutations that have not been flushed to a sstable.
@@ -134,6 +171,10 @@ type Options struct {
 	// there is no syncQueue, so the pushback into the commit pipeline is
 	// unnecessary, but possibly harmless.
 	QueueSemChan chan struct{}
+	// Compression returns the compression to use for the chunks of a newly
+	// created WAL. It is called once per WAL, so every file of a WAL uses the
+	// same compression. A nil func disables compression.
+	Compression func() block.Compression
 
 	// Logger for logging.
 	Logger base.Logger
@@ -147,13 +188,26 @@ type Options struct {
 	FailoverWriteAndSyncLatency prometheus.Histogram
 }
 
+// compression returns the compression to use for a newly created WAL.
+func (o *Options) compression() block.Compression {
+	if o.Compression == nil {
+		return block.NoCompression
+	}
+	return o.Compression()
+}
+
 // Init constructs and initializes a WAL manager from the provided options and
 // the set of initial logs.
 func Init(o Options, initial Logs) (Manager, error) {
 	var m Manager
-	if o.Secondary == (Dir{}) {
+	switch {
+	case len(o.Streams) > 0 && o.Secondary != (Dir{}):
+		return nil, errors.New("wal: parallel streams cannot be combined with failover")
+	case len(o.Streams) > 0:
+		m = new(parallelManager)
+	case o.Secondary == (Dir{}):
 		m = new(StandaloneManager)
-	} else {
+	default:
 		m = new(failoverManager)
 	}
 	if err := m.init(o, initial); err != nil {
@@ -162,8 +216,11 @@ func Init(o Options, initial Logs) (Manager, error) {
 	return m, nil
 }
 
-// Dirs returns the primary Dir and the secondary if provided.
+// Dirs returns the primary Dir, and the secondary or stream Dirs if provided.
 func (o *Options) Dirs() []Dir {
+	if len(o.Streams) > 0 {
+		return append([]Dir{o.Primary}, o.Streams...)
+	}
 	if o.Secondary == (Dir{}) {
 		return []Dir{o.Primary}
 	}
@@ -370,7 +427,8 @@ type SyncOptions struct {
 
 // Writer writes to a virtual WAL. A Writer in standalone mode maps to a
 // single record.LogWriter. In failover mode, it can failover across multiple
-// physical log files.
+// physical log files. With parallel streams, it maps to one record.LogWriter
+// per stream.
 type Writer interface {
 	// WriteRecord writes a complete record. The record is asynchronously
 	// persisted to the underlying writer. If SyncOptions.Done != nil, the wait